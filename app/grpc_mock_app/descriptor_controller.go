@@ -0,0 +1,187 @@
+package grpc_mock_app
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strconv"
+
+	"go_mock_server/internal/domain/iface"
+	"go_mock_server/utils"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+)
+
+// DescriptorController 管理 gRPC mock 使用的 protobuf 描述符：上传、版本、下载与删除
+type DescriptorController struct {
+	DescriptorService iface.DescriptorService
+}
+
+func NewDescriptorController(descriptorService iface.DescriptorService) *DescriptorController {
+	return &DescriptorController{DescriptorService: descriptorService}
+}
+
+// UploadProtoRequest .proto 源文件上传请求，files 为 import 路径 -> 文件内容
+type UploadProtoRequest struct {
+	Files map[string]string `json:"files" validate:"required,min=1"`
+}
+
+// UploadDescriptorSet POST /mock/grpc/descriptors/{name}
+// 请求体为二进制 FileDescriptorSet（protoc --include_imports --descriptor_set_out 生成）
+func (c *DescriptorController) UploadDescriptorSet(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("UploadDescriptorSet Begin")
+	defer recoverPanic(b)
+
+	data, err := io.ReadAll(b.ReadRequest().Body)
+	if err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	d, err := c.DescriptorService.UploadDescriptorSet(b.Ctx, b.ReadPathParameter("name"), data)
+	if err != nil {
+		logger.Errorf("UploadDescriptorSet err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(d, "application/json")
+}
+
+// UploadProtoFiles POST /mock/grpc/descriptors/{name}/protos
+func (c *DescriptorController) UploadProtoFiles(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("UploadProtoFiles Begin")
+	defer recoverPanic(b)
+
+	var req UploadProtoRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	if len(req.Files) == 0 {
+		writeError(b, "files is required")
+		return
+	}
+	d, err := c.DescriptorService.UploadProtoFiles(b.Ctx, b.ReadPathParameter("name"), req.Files)
+	if err != nil {
+		logger.Errorf("UploadProtoFiles err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(d, "application/json")
+}
+
+// ListDescriptors GET /mock/grpc/descriptors?name=
+func (c *DescriptorController) ListDescriptors(b *rf.Context) {
+	logger := utils.GetLogger()
+	defer recoverPanic(b)
+
+	list, err := c.DescriptorService.ListDescriptors(b.Ctx, b.ReadQueryParameter("name"))
+	if err != nil {
+		logger.Errorf("ListDescriptors err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(struct {
+		Descriptors any `json:"descriptors"`
+	}{Descriptors: list}, "application/json")
+}
+
+// DownloadDescriptor GET /mock/grpc/descriptors/{name}/versions/{version}
+// version 为 latest 时返回最新版本，响应体为二进制 FileDescriptorSet
+func (c *DescriptorController) DownloadDescriptor(b *rf.Context) {
+	logger := utils.GetLogger()
+	defer recoverPanic(b)
+
+	version, err := parseVersion(b.ReadPathParameter("version"))
+	if err != nil {
+		writeError(b, err.Error())
+		return
+	}
+	d, err := c.DescriptorService.GetDescriptor(b.Ctx, b.ReadPathParameter("name"), version)
+	if err != nil {
+		logger.Errorf("GetDescriptor err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.AddHeader("Content-Type", "application/octet-stream")
+	b.AddHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%s.v%d.pb", d.Name, d.Version))
+	b.Write(d.Content)
+}
+
+// DeleteDescriptor DELETE /mock/grpc/descriptors/{name}?version=，不指定 version 时删除全部版本
+func (c *DescriptorController) DeleteDescriptor(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("DeleteDescriptor Begin")
+	defer recoverPanic(b)
+
+	version, err := parseVersion(b.ReadQueryParameter("version"))
+	if err != nil {
+		writeError(b, err.Error())
+		return
+	}
+	deleted, err := c.DescriptorService.DeleteDescriptor(b.Ctx, b.ReadPathParameter("name"), version)
+	if err != nil {
+		logger.Errorf("DeleteDescriptor err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(struct {
+		Deleted int64 `json:"deleted"`
+	}{Deleted: deleted}, "application/json")
+}
+
+// ListServices GET /mock/grpc/services
+func (c *DescriptorController) ListServices(b *rf.Context) {
+	b.WriteJSON(struct {
+		Services map[string][]string `json:"services"`
+	}{Services: c.DescriptorService.Services()}, "application/json")
+}
+
+// parseVersion 空串或 latest 表示 0（最新 / 全部版本）
+func parseVersion(s string) (int, error) {
+	if s == "" || s == "latest" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid version %q", s)
+	}
+	return v, nil
+}
+
+func recoverPanic(b *rf.Context) {
+	if err := recover(); err != nil {
+		utils.GetLogger().WithFields(map[string]interface{}{
+			"panic": err,
+			"stack": string(debug.Stack()),
+		}).Error("handle request panic")
+		writeError(b, "Internal server error")
+	}
+}
+
+// writeError writes a JSON error body
+func writeError(b *rf.Context, msg string) {
+	b.WriteJSON(struct {
+		Error string `json:"error"`
+	}{Error: msg}, "application/json")
+}
+
+func (c *DescriptorController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "GET", Path: "/mock/grpc/descriptors", ResourceFunc: c.ListDescriptors,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/grpc/descriptors/{name}", ResourceFunc: c.UploadDescriptorSet,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/grpc/descriptors/{name}/protos", ResourceFunc: c.UploadProtoFiles,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "GET", Path: "/mock/grpc/descriptors/{name}/versions/{version}", ResourceFunc: c.DownloadDescriptor,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "DELETE", Path: "/mock/grpc/descriptors/{name}", ResourceFunc: c.DeleteDescriptor,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "GET", Path: "/mock/grpc/services", ResourceFunc: c.ListServices,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
@@ -0,0 +1,330 @@
+package grpc_mock_app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// maxRecvMsgSize 单条入站消息的最大字节数，原生 gRPC 与 gRPC-Web / Connect 共用
+const maxRecvMsgSize = 4 << 20
+
+// GRPCMockServer gRPC mock 服务：所有请求由 unknown service handler 处理，
+// 按描述符仓库中的类型解码请求，通过规则引擎匹配后将响应的 JSON body 编码为响应消息，支持 unary 与三种 streaming 调用
+type GRPCMockServer struct {
+	server            *grpc.Server
+	descriptorService iface.DescriptorService
+	matchService      iface.RuleMatchService
+	listenAddr        string
+}
+
+func NewGRPCMockServer(config *configs.GRPCMockConfig, descriptorService iface.DescriptorService, matchService iface.RuleMatchService) (*GRPCMockServer, error) {
+	dirFiles, err := findDescriptorSetFiles(config.DescriptorSetDirs)
+	if err != nil {
+		return nil, err
+	}
+	files := append(append([]string{}, config.DescriptorSetFiles...), dirFiles...)
+	if len(files) > 0 {
+		if err := descriptorService.LoadDescriptorSetFiles(files); err != nil {
+			return nil, err
+		}
+	}
+
+	s := &GRPCMockServer{
+		descriptorService: descriptorService,
+		matchService:      matchService,
+		listenAddr:        config.ListenAddr,
+	}
+	s.server = grpc.NewServer(grpc.UnknownServiceHandler(s.handleStream), grpc.MaxRecvMsgSize(maxRecvMsgSize))
+	if !config.DisableReflection {
+		registerReflection(s.server, descriptorService)
+	}
+	return s, nil
+}
+
+// descriptorSetExts 目录扫描时识别为 FileDescriptorSet 的文件后缀
+var descriptorSetExts = map[string]bool{".pb": true, ".protoset": true, ".desc": true}
+
+// findDescriptorSetFiles 收集目录（不递归）下的描述符文件，按文件名排序保证加载顺序稳定
+func findDescriptorSetFiles(dirs []string) ([]string, error) {
+	var files []string
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read descriptor set dir %s: %w", dir, err)
+		}
+		for _, e := range entries {
+			if !e.IsDir() && descriptorSetExts[strings.ToLower(filepath.Ext(e.Name()))] {
+				files = append(files, filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+	return files, nil
+}
+
+// ListenAndServe 在配置的地址上启动 gRPC 服务，阻塞直到服务关闭
+func (s *GRPCMockServer) ListenAndServe() error {
+	lis, err := net.Listen("tcp", s.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.listenAddr, err)
+	}
+	utils.GetLogger().Infof("grpc mock server listening on %s", s.listenAddr)
+	return s.Serve(lis)
+}
+
+func (s *GRPCMockServer) Serve(lis net.Listener) error {
+	return s.server.Serve(lis)
+}
+
+func (s *GRPCMockServer) Close() {
+	s.server.GracefulStop()
+}
+
+// callStream 一次调用的消息收发，由原生 gRPC 的 grpc.ServerStream 与 gRPC-Web / Connect 的 HTTP 适配实现
+type callStream interface {
+	Context() context.Context
+	RecvMsg(m any) error // 入站消息读完时返回 io.EOF
+	SendMsg(m any) error
+	SetHeader(md metadata.MD) error
+	SetTrailer(md metadata.MD)
+}
+
+var _ callStream = (grpc.ServerStream)(nil)
+
+func (s *GRPCMockServer) handleStream(_ any, stream grpc.ServerStream) error {
+	fullMethod, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "failed to get method from stream")
+	}
+	return s.serveCall(stream, fullMethod)
+}
+
+// serveCall 处理一次调用：
+// unary / server streaming 读取一条请求后匹配规则；client streaming 读完全部请求后按合并结果匹配；
+// bidi streaming 按第一条请求匹配规则，之后每条入站消息都执行一次规则动作
+func (s *GRPCMockServer) serveCall(stream callStream, fullMethod string) error {
+	md, err := s.descriptorService.FindMethod(fullMethod)
+	if err != nil {
+		return status.Errorf(codes.Unimplemented, "%s: %v", fullMethod, err)
+	}
+
+	call := &grpcCall{server: s, stream: stream, method: md, fullMethod: fullMethod}
+	switch streamType(md) {
+	case model.GRPCClientStream:
+		var messages []proto.Message
+		for {
+			in, err := call.recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			messages = append(messages, in)
+		}
+		return call.respond(model.GRPCClientStream, messages)
+	case model.GRPCBidiStream:
+		var messages []proto.Message
+		for {
+			in, err := call.recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			messages = append(messages, in)
+			if err := call.respond(model.GRPCBidiStream, messages); err != nil || call.finished {
+				return err
+			}
+		}
+	default:
+		in, err := call.recv()
+		if err == io.EOF {
+			return status.Errorf(codes.InvalidArgument, "missing request message for %s", fullMethod)
+		}
+		if err != nil {
+			return err
+		}
+		return call.respond(streamType(md), []proto.Message{in})
+	}
+}
+
+func streamType(md protoreflect.MethodDescriptor) model.GRPCStreamType {
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		return model.GRPCBidiStream
+	case md.IsStreamingClient():
+		return model.GRPCClientStream
+	case md.IsStreamingServer():
+		return model.GRPCServerStream
+	default:
+		return model.GRPCUnary
+	}
+}
+
+// grpcCall 一次调用的状态，bidi streaming 中规则只匹配一次，响应头与 trailer 只设置一次
+type grpcCall struct {
+	server     *GRPCMockServer
+	stream     callStream
+	method     protoreflect.MethodDescriptor
+	fullMethod string
+	rule       *model.MockRule
+	headerSent bool
+	trailerSet bool
+	finished   bool // 规则返回了 gRPC 状态，调用已结束
+}
+
+func (c *grpcCall) recv() (proto.Message, error) {
+	in := dynamicpb.NewMessage(c.method.Input())
+	if err := c.stream.RecvMsg(in); err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		if ctxErr := c.stream.Context().Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode request as %s: %v", c.method.Input().FullName(), err)
+	}
+	return in, nil
+}
+
+// respond 匹配规则（bidi 只在第一条消息时匹配）并发送规则动作产生的消息
+func (c *grpcCall) respond(streamType model.GRPCStreamType, messages []proto.Message) error {
+	logger := utils.GetLogger()
+	ctx := c.stream.Context()
+	reqInfo := model.NewGRPCStreamRequest(ctx, c.fullMethod, streamType, messages)
+	if c.rule == nil {
+		rule, err := c.server.matchService.MatchRule(ctx, reqInfo)
+		if err != nil {
+			logger.Errorf("grpc match rule err: %v", err)
+			return status.Errorf(codes.Internal, "failed to match rule: %v", err)
+		}
+		if rule == nil {
+			return status.Errorf(codes.NotFound, "no mock rule matched %s", c.fullMethod)
+		}
+		c.rule = rule
+	}
+
+	resp, err := c.server.matchService.ExecuteRuleAction(ctx, c.rule, reqInfo)
+	if err != nil {
+		logger.Errorf("grpc execute rule %s err: %v", c.rule.ID, err)
+		return status.Errorf(codes.Internal, "failed to execute rule %s: %v", c.rule.ID, err)
+	}
+	if err := resp.GetError(); err != nil {
+		return status.Errorf(codes.Internal, "rule %s: %v", c.rule.ID, err)
+	}
+
+	replies := []model.ResponseInfo{resp}
+	if sr, ok := resp.(model.StreamResponseInfo); ok {
+		replies = sr.GetMessages()
+	}
+	var st *model.GRPCStatus
+	var trailers map[string]string
+	if gr, ok := resp.(model.GRPCResponseInfo); ok {
+		st, trailers = gr.GetGRPCStatus(), gr.GetTrailers()
+	}
+	switch {
+	case !st.IsOK() && !c.method.IsStreamingServer():
+		// 非 OK 状态的 unary / client streaming 调用不返回消息，只保留延迟
+		if err := c.wait(resp.GetDelay()); err != nil {
+			return err
+		}
+		replies = nil
+	case !c.method.IsStreamingServer() && len(replies) != 1:
+		// unary 与 client streaming 必须且只能返回一条消息
+		return status.Errorf(codes.Internal, "rule %s produced %d messages for non-streaming response of %s", c.rule.ID, len(replies), c.fullMethod)
+	}
+
+	if !c.headerSent {
+		c.headerSent = true
+		if header := responseMetadata(resp.GetHeaders()); len(header) > 0 {
+			if err := c.stream.SetHeader(header); err != nil {
+				return err
+			}
+		}
+	}
+	if !c.trailerSet {
+		if trailer := responseMetadata(trailers); len(trailer) > 0 {
+			c.trailerSet = true
+			c.stream.SetTrailer(trailer)
+		}
+	}
+	for _, reply := range replies {
+		if err := c.send(reply); err != nil {
+			return err
+		}
+	}
+
+	// 设置了状态时结束调用，bidi streaming 不再处理后续入站消息
+	if st == nil {
+		return nil
+	}
+	c.finished = true
+	grpcStatus, err := st.Status()
+	if err != nil {
+		return status.Errorf(codes.Internal, "rule %s: %v", c.rule.ID, err)
+	}
+	return grpcStatus.Err()
+}
+
+// send 等待消息的延迟后编码发送
+func (c *grpcCall) send(reply model.ResponseInfo) error {
+	out := dynamicpb.NewMessage(c.method.Output())
+	if body := reply.GetBody(); len(body) > 0 {
+		if err := protojson.Unmarshal(body, out); err != nil {
+			return status.Errorf(codes.Internal, "response body of rule %s does not fit %s: %v", c.rule.ID, c.method.Output().FullName(), err)
+		}
+	}
+
+	if err := c.wait(reply.GetDelay()); err != nil {
+		return err
+	}
+	return c.stream.SendMsg(out)
+}
+
+// wait 等待延迟，客户端取消时立即返回
+func (c *grpcCall) wait(delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	ctx := c.stream.Context()
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// responseMetadata 将响应头转换为 gRPC 元数据，跳过由传输层维护的头
+func responseMetadata(headers map[string]string) metadata.MD {
+	md := metadata.MD{}
+	for k, v := range headers {
+		key := strings.ToLower(k)
+		if key == "content-type" || strings.HasPrefix(key, "grpc-") || strings.HasPrefix(key, ":") {
+			continue
+		}
+		md.Append(key, v)
+	}
+	return md
+}
@@ -0,0 +1,171 @@
+package grpc_mock_app
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/domain/services"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+	"go_mock_server/internal/infra/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// greeterDescriptorSet 构造 mock.test.Greeter 服务的 FileDescriptorSet
+func greeterDescriptorSet(t *testing.T) []byte {
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	ts := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("mock/test/greeter.proto"),
+		Package:    proto.String("mock.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("HelloRequest"), Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("name"), JsonName: proto.String("name"), Number: proto.Int32(1), Type: str, Label: optional},
+			}},
+			{Name: proto.String("HelloReply"), Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("message"), JsonName: proto.String("message"), Number: proto.Int32(1), Type: str, Label: optional},
+				{Name: proto.String("sent_at"), JsonName: proto.String("sentAt"), Number: proto.Int32(2), Type: ts, Label: optional,
+					TypeName: proto.String(".google.protobuf.Timestamp")},
+			}},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{Name: proto.String("Greeter"), Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("SayHello"), InputType: proto.String(".mock.test.HelloRequest"), OutputType: proto.String(".mock.test.HelloReply")},
+				{Name: proto.String("StreamHello"), InputType: proto.String(".mock.test.HelloRequest"), OutputType: proto.String(".mock.test.HelloReply"),
+					ServerStreaming: proto.Bool(true)},
+				{Name: proto.String("CollectHello"), InputType: proto.String(".mock.test.HelloRequest"), OutputType: proto.String(".mock.test.HelloReply"),
+					ClientStreaming: proto.Bool(true)},
+				{Name: proto.String("ChatHello"), InputType: proto.String(".mock.test.HelloRequest"), OutputType: proto.String(".mock.test.HelloReply"),
+					ClientStreaming: proto.Bool(true), ServerStreaming: proto.Bool(true)},
+			}},
+		},
+	}
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fd}})
+	require.NoError(t, err)
+	return data
+}
+
+func newGreeterRule(id, name, body string) *model.MockRule {
+	rule := &model.MockRule{
+		ID:       id,
+		Name:     id,
+		Protocol: "grpc",
+		Status:   model.RuleStatusActive,
+		MatchConfig: model.MatchConfig{
+			Logical: "AND",
+			Conditions: []model.MatchCondition{
+				{Type: "path", Operator: "eq", Value: "mock.test.Greeter"},
+				{Type: "method", Operator: "eq", Value: "SayHello"},
+				{Type: "body_json", Operator: "eq", Key: "$.name", Value: name},
+			},
+		},
+		ActionConfig: model.ActionConfigWrapper{
+			AType: model.ActionTypeResponse,
+			Config: &model.ResponseAction{
+				Headers: map[string]string{"X-Mock-Rule": id, "Content-Type": "application/json"},
+				Body:    body,
+			},
+		},
+	}
+	_ = rule.Validate()
+	return rule
+}
+
+// startGreeterServer 上传 greeter 描述符并在 bufconn 上启动 mock 服务
+func startGreeterServer(t *testing.T, ruleRepo repo.RuleRepositoryIface) (*grpc.ClientConn, *services.DescriptorService) {
+	descriptorRepo, err := repo.NewDescriptorRepoImpl(storage.NewMemoryRuleStorage())
+	require.NoError(t, err)
+	descriptorService := services.NewDescriptorService(descriptorRepo)
+	_, err = descriptorService.UploadDescriptorSet(context.Background(), "greeter", greeterDescriptorSet(t))
+	require.NoError(t, err)
+
+	server, err := NewGRPCMockServer(&configs.GRPCMockConfig{DisableReflection: true}, descriptorService, services.NewRuleMatchService(ruleRepo))
+	require.NoError(t, err)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Close)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, descriptorService
+}
+
+func TestGRPCMockServer(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	require.NoError(t, ruleRepo.SaveRule(ctx, newGreeterRule("greet_alice", "alice", `{"message": "hi alice", "sentAt": "2024-01-02T03:04:05Z"}`)))
+	require.NoError(t, ruleRepo.SaveRule(ctx, newGreeterRule("greet_bad", "bad", `{"unknownField": 1}`)))
+
+	descriptorRepo, err := repo.NewDescriptorRepoImpl(storage.NewMemoryRuleStorage())
+	require.NoError(t, err)
+	descriptorService := services.NewDescriptorService(descriptorRepo)
+	d, err := descriptorService.UploadDescriptorSet(ctx, "greeter", greeterDescriptorSet(t))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mock.test.Greeter"}, d.Services)
+
+	server, err := NewGRPCMockServer(&configs.GRPCMockConfig{}, descriptorService, services.NewRuleMatchService(ruleRepo))
+	require.NoError(t, err)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Close()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	md, err := descriptorService.FindMethod("/mock.test.Greeter/SayHello")
+	require.NoError(t, err)
+	call := func(method, name string, header *metadata.MD) (*dynamicpb.Message, error) {
+		in := dynamicpb.NewMessage(md.Input())
+		in.Set(md.Input().Fields().ByName("name"), protoreflect.ValueOfString(name))
+		out := dynamicpb.NewMessage(md.Output())
+		opts := []grpc.CallOption{}
+		if header != nil {
+			opts = append(opts, grpc.Header(header))
+		}
+		return out, conn.Invoke(ctx, method, in, out, opts...)
+	}
+
+	var header metadata.MD
+	out, err := call("/mock.test.Greeter/SayHello", "alice", &header)
+	require.NoError(t, err)
+	assert.Equal(t, "hi alice", out.Get(md.Output().Fields().ByName("message")).String())
+	sentAt := out.Get(md.Output().Fields().ByName("sent_at")).Message()
+	assert.Equal(t, int64(1704164645), sentAt.Get(sentAt.Descriptor().Fields().ByName("seconds")).Int())
+	assert.Equal(t, []string{"greet_alice"}, header.Get("x-mock-rule"))
+
+	_, err = call("/mock.test.Greeter/SayHello", "bob", nil)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = call("/mock.test.Greeter/SayHello", "bad", nil)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = call("/mock.test.Greeter/Missing", "alice", nil)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = call("/mock.test.Unknown/SayHello", "alice", nil)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
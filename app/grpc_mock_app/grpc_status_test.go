@@ -0,0 +1,133 @@
+package grpc_mock_app
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestGRPCMockServerStatus(t *testing.T) {
+	ctx := context.Background()
+	var invalid model.GRPCStatus
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"code": "INVALID_ARGUMENT",
+		"message": "name is required",
+		"details": [
+			{"@type": "type.googleapis.com/google.rpc.BadRequest", "fieldViolations": [{"field": "name", "description": "must not be empty"}]},
+			{"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "1.500s"}
+		]
+	}`), &invalid))
+
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	for _, rule := range []*model.MockRule{
+		newStreamRule("unary_error", "SayHello", 0,
+			model.MatchCondition{Type: "body_json", Operator: "eq", Key: "$.name", Value: ""},
+			&model.ResponseAction{
+				Body:       `{"message": "ignored"}`,
+				Headers:    map[string]string{"x-request-id": "req-1"},
+				Trailers:   map[string]string{"x-mock-trailer": "unary"},
+				GRPCStatus: &invalid,
+			}, model.ActionTypeResponse),
+		newStreamRule("unary_ok_trailer", "SayHello", 0,
+			model.MatchCondition{Type: "body_json", Operator: "eq", Key: "$.name", Value: "ok"},
+			&model.ResponseAction{Body: `{"message": "hi"}`, Trailers: map[string]string{"x-mock-trailer": "ok"}}, model.ActionTypeResponse),
+		newStreamRule("stream_then_unavailable", "StreamHello", 0,
+			model.MatchCondition{Type: "body_json", Operator: "eq", Key: "$.name", Value: "alice"},
+			&model.StreamAction{
+				Messages:   []*model.StreamMessage{{Body: `{"message": "one"}`}},
+				GRPCStatus: &model.GRPCStatus{Code: codes.Unavailable, Message: "backend went away"},
+			}, model.ActionTypeStream),
+		newStreamRule("bidi_abort", "ChatHello", 0,
+			model.MatchCondition{Type: "header", Operator: "exists", Key: "content-type", Value: ""},
+			&model.StreamAction{Replies: []*model.StreamReply{
+				{Messages: []*model.StreamMessage{{Body: `{"message": "first"}`}}},
+				{Messages: []*model.StreamMessage{{Body: `{"message": "last"}`}}, GRPCStatus: &model.GRPCStatus{Code: codes.Aborted}},
+			}}, model.ActionTypeStream),
+	} {
+		require.NoError(t, rule.Validate())
+		require.NoError(t, ruleRepo.SaveRule(ctx, rule))
+	}
+	conn, descriptorService := startGreeterServer(t, ruleRepo)
+
+	md, err := descriptorService.FindMethod("/mock.test.Greeter/SayHello")
+	require.NoError(t, err)
+	request := func(name string) *dynamicpb.Message {
+		in := dynamicpb.NewMessage(md.Input())
+		in.Set(md.Input().Fields().ByName("name"), protoreflect.ValueOfString(name))
+		return in
+	}
+
+	t.Run("unary error with details", func(t *testing.T) {
+		var header, trailer metadata.MD
+		out := dynamicpb.NewMessage(md.Output())
+		err := conn.Invoke(ctx, "/mock.test.Greeter/SayHello", request(""), out, grpc.Header(&header), grpc.Trailer(&trailer))
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, "name is required", st.Message())
+		require.Len(t, st.Details(), 2)
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+		assert.Equal(t, "name", badRequest.GetFieldViolations()[0].GetField())
+		retry, ok := st.Details()[1].(*errdetails.RetryInfo)
+		require.True(t, ok)
+		assert.Equal(t, 1500*time.Millisecond, retry.GetRetryDelay().AsDuration())
+		assert.Equal(t, []string{"req-1"}, header.Get("x-request-id"))
+		assert.Equal(t, []string{"unary"}, trailer.Get("x-mock-trailer"))
+	})
+
+	t.Run("unary trailer", func(t *testing.T) {
+		var trailer metadata.MD
+		out := dynamicpb.NewMessage(md.Output())
+		require.NoError(t, conn.Invoke(ctx, "/mock.test.Greeter/SayHello", request("ok"), out, grpc.Trailer(&trailer)))
+		assert.Equal(t, []string{"ok"}, trailer.Get("x-mock-trailer"))
+	})
+
+	recvAll := func(stream grpc.ClientStream) ([]string, error) {
+		var got []string
+		for {
+			out := dynamicpb.NewMessage(md.Output())
+			if err := stream.RecvMsg(out); err != nil {
+				return got, err
+			}
+			got = append(got, out.Get(md.Output().Fields().ByName("message")).String())
+		}
+	}
+
+	t.Run("server stream ends with status", func(t *testing.T) {
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, "/mock.test.Greeter/StreamHello")
+		require.NoError(t, err)
+		require.NoError(t, stream.SendMsg(request("alice")))
+		require.NoError(t, stream.CloseSend())
+		got, err := recvAll(stream)
+		assert.Equal(t, []string{"one"}, got)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("bidi reply ends stream", func(t *testing.T) {
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}, "/mock.test.Greeter/ChatHello")
+		require.NoError(t, err)
+		require.NoError(t, stream.SendMsg(request("a")))
+		require.NoError(t, stream.SendMsg(request("b")))
+		got, err := recvAll(stream)
+		assert.Equal(t, []string{"first", "last"}, got)
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		assert.NotEqual(t, io.EOF, err)
+	})
+}
@@ -0,0 +1,161 @@
+package grpc_mock_app
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func newStreamRule(id, method string, priority int, cond model.MatchCondition, action model.Action, aType model.ActionType) *model.MockRule {
+	rule := &model.MockRule{
+		ID:       id,
+		Name:     id,
+		Protocol: "grpc",
+		Status:   model.RuleStatusActive,
+		Priority: priority,
+		MatchConfig: model.MatchConfig{
+			Logical: "AND",
+			Conditions: []model.MatchCondition{
+				{Type: "path", Operator: "eq", Value: "mock.test.Greeter"},
+				{Type: "method", Operator: "eq", Value: method},
+				cond,
+			},
+		},
+		ActionConfig: model.ActionConfigWrapper{AType: aType, Config: action},
+	}
+	_ = rule.Validate()
+	return rule
+}
+
+func TestGRPCMockServerStreaming(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	for _, rule := range []*model.MockRule{
+		newStreamRule("server_stream", "StreamHello", 0,
+			model.MatchCondition{Type: "body_json", Operator: "eq", Key: "$.name", Value: "alice"},
+			&model.StreamAction{Messages: []*model.StreamMessage{
+				{Body: `{"message": "one"}`},
+				{Body: `{"message": "two"}`, Delay: 20 * time.Millisecond},
+				{Body: `{"message": "bye {{.name}}"}`, Template: true},
+			}}, model.ActionTypeStream),
+		newStreamRule("client_first", "CollectHello", 10,
+			model.MatchCondition{Type: "stream_message", Operator: "eq", Key: "$[0].name", Value: "first"},
+			&model.ResponseAction{Body: `{"message": "first message matched"}`}, model.ActionTypeResponse),
+		newStreamRule("client_aggregated", "CollectHello", 0,
+			model.MatchCondition{Type: "body_json", Operator: "eq", Key: "$.name", Value: "last"},
+			&model.ResponseAction{Body: `{"message": "aggregated {{.name}}"}`, Template: true}, model.ActionTypeResponse),
+		newStreamRule("client_too_many", "CollectHello", 0,
+			model.MatchCondition{Type: "body_json", Operator: "eq", Key: "$.name", Value: "many"},
+			&model.StreamAction{Messages: []*model.StreamMessage{{Body: `{}`}, {Body: `{}`}}}, model.ActionTypeStream),
+		newStreamRule("bidi", "ChatHello", 0,
+			model.MatchCondition{Type: "header", Operator: "exists", Key: "content-type", Value: ""},
+			&model.StreamAction{Replies: []*model.StreamReply{
+				{Messages: []*model.StreamMessage{{Body: `{"message": "welcome {{.name}}"}`, Template: true}}},
+				{},
+				{Messages: []*model.StreamMessage{{Body: `{"message": "a"}`}, {Body: `{"message": "b"}`}}},
+			}}, model.ActionTypeStream),
+	} {
+		require.NoError(t, ruleRepo.SaveRule(ctx, rule))
+	}
+
+	conn, descriptorService := startGreeterServer(t, ruleRepo)
+	md, err := descriptorService.FindMethod("/mock.test.Greeter/ChatHello")
+	require.NoError(t, err)
+	request := func(name string) *dynamicpb.Message {
+		in := dynamicpb.NewMessage(md.Input())
+		in.Set(md.Input().Fields().ByName("name"), protoreflect.ValueOfString(name))
+		return in
+	}
+	recv := func(stream grpc.ClientStream) (string, error) {
+		out := dynamicpb.NewMessage(md.Output())
+		if err := stream.RecvMsg(out); err != nil {
+			return "", err
+		}
+		return out.Get(md.Output().Fields().ByName("message")).String(), nil
+	}
+	open := func(method string, clientStreams, serverStreams bool) grpc.ClientStream {
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ClientStreams: clientStreams, ServerStreams: serverStreams}, "/mock.test.Greeter/"+method)
+		require.NoError(t, err)
+		return stream
+	}
+
+	t.Run("server streaming", func(t *testing.T) {
+		stream := open("StreamHello", false, true)
+		require.NoError(t, stream.SendMsg(request("alice")))
+		require.NoError(t, stream.CloseSend())
+		start := time.Now()
+		var got []string
+		for {
+			msg, err := recv(stream)
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			got = append(got, msg)
+		}
+		assert.Equal(t, []string{"one", "two", "bye alice"}, got)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	})
+
+	t.Run("client streaming", func(t *testing.T) {
+		collect := func(names ...string) (string, error) {
+			stream := open("CollectHello", true, false)
+			for _, name := range names {
+				require.NoError(t, stream.SendMsg(request(name)))
+			}
+			require.NoError(t, stream.CloseSend())
+			return recv(stream)
+		}
+
+		msg, err := collect("first", "x", "last")
+		require.NoError(t, err)
+		assert.Equal(t, "first message matched", msg, "higher priority rule matches the 1st message")
+
+		msg, err = collect("x", "y", "last")
+		require.NoError(t, err)
+		assert.Equal(t, "aggregated last", msg)
+
+		_, err = collect("many")
+		assert.Equal(t, codes.Internal, status.Code(err))
+
+		_, err = collect("x", "y")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("bidi streaming", func(t *testing.T) {
+		stream := open("ChatHello", true, true)
+		require.NoError(t, stream.SendMsg(request("alice")))
+		msg, err := recv(stream)
+		require.NoError(t, err)
+		assert.Equal(t, "welcome alice", msg)
+
+		// 第 2 条消息没有回复，第 3 条消息回复两条
+		require.NoError(t, stream.SendMsg(request("two")))
+		require.NoError(t, stream.SendMsg(request("three")))
+		for _, want := range []string{"a", "b"} {
+			msg, err = recv(stream)
+			require.NoError(t, err)
+			assert.Equal(t, want, msg)
+		}
+
+		// 脚本用完且未设置 loop，不再回复
+		require.NoError(t, stream.SendMsg(request("four")))
+		require.NoError(t, stream.CloseSend())
+		_, err = recv(stream)
+		assert.Equal(t, io.EOF, err)
+	})
+}
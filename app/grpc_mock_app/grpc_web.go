@@ -0,0 +1,560 @@
+package grpc_mock_app
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// webProtocol HTTP 上承载 gRPC 调用的协议
+type webProtocol int
+
+const (
+	protocolGRPCWeb       webProtocol = iota // application/grpc-web(+proto|+json)
+	protocolGRPCWebText                      // application/grpc-web-text(+proto|+json)，请求与响应体为 base64
+	protocolConnectUnary                     // application/proto | application/json，消息不分帧
+	protocolConnectStream                    // application/connect+proto | application/connect+json
+)
+
+// 帧头 1 字节标志 + 4 字节大端长度
+const (
+	flagCompressed     byte = 0x01
+	flagConnectEnd     byte = 0x02 // Connect 流结束帧
+	flagGRPCWebTrailer byte = 0x80 // gRPC-Web trailer 帧
+)
+
+// GRPCWebHandler 在 HTTP 监听上处理 gRPC-Web（binary / text）与 Connect（unary / streaming）请求，
+// 请求转换为与原生 gRPC 相同的调用，复用同一套 grpc 规则，并按请求的协议编码响应
+// gRPC-Web 与 Connect 都是半双工的：先读完请求体，再依次产生响应
+type GRPCWebHandler struct {
+	server *GRPCMockServer
+}
+
+func NewGRPCWebHandler(server *GRPCMockServer) *GRPCWebHandler {
+	return &GRPCWebHandler{server: server}
+}
+
+// Handles 判断请求是否应交给该 handler：gRPC-Web 与 Connect streaming 按 Content-Type 识别，
+// Connect unary 的 Content-Type 与普通 HTTP 请求相同，需要带 Connect-Protocol-Version 头或路径是已注册的 gRPC 方法
+func (h *GRPCWebHandler) Handles(r *http.Request) bool {
+	if r.Method == http.MethodOptions {
+		return r.Header.Get("Access-Control-Request-Method") != "" && h.knownMethod(r.URL.Path)
+	}
+	protocol, _, ok := detectWebProtocol(r)
+	if !ok {
+		return false
+	}
+	if protocol == protocolConnectUnary && r.Header.Get("Connect-Protocol-Version") == "" {
+		return h.knownMethod(r.URL.Path)
+	}
+	return true
+}
+
+func (h *GRPCWebHandler) knownMethod(path string) bool {
+	_, err := h.server.descriptorService.FindMethod(path)
+	return err == nil
+}
+
+func (h *GRPCWebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeCORSHeaders(w, r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	protocol, codec, ok := detectWebProtocol(r)
+	if !ok {
+		http.Error(w, "unsupported content type "+r.Header.Get("Content-Type"), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), requestMetadata(r.Header))
+	if timeout, ok := requestTimeout(protocol, r.Header); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	stream := &webStream{ctx: ctx, w: w, protocol: protocol, codec: codec, contentType: responseContentType(protocol, codec)}
+	inbound, err := readWebRequest(w, r, protocol)
+	if err != nil {
+		stream.finish(err)
+		return
+	}
+	stream.inbound = inbound
+	stream.finish(h.server.serveCall(stream, r.URL.Path))
+}
+
+// detectWebProtocol 按 Content-Type（Connect GET 请求按 encoding 参数）识别协议与消息编码
+func detectWebProtocol(r *http.Request) (webProtocol, messageCodec, bool) {
+	if r.Method == http.MethodGet {
+		codec, ok := codecByName(r.URL.Query().Get("encoding"))
+		return protocolConnectUnary, codec, ok && r.URL.Query().Has("message")
+	}
+	if r.Method != http.MethodPost {
+		return 0, nil, false
+	}
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return 0, nil, false
+	}
+	base, sub, _ := strings.Cut(contentType, "+")
+	switch base {
+	case "application/grpc-web":
+		codec, ok := codecByName(sub)
+		return protocolGRPCWeb, codec, ok
+	case "application/grpc-web-text":
+		codec, ok := codecByName(sub)
+		return protocolGRPCWebText, codec, ok
+	case "application/connect":
+		codec, ok := codecByName(sub)
+		return protocolConnectStream, codec, ok && sub != ""
+	case "application/proto", "application/json":
+		codec, _ := codecByName(strings.TrimPrefix(base, "application/"))
+		return protocolConnectUnary, codec, sub == ""
+	}
+	return 0, nil, false
+}
+
+func responseContentType(protocol webProtocol, codec messageCodec) string {
+	switch protocol {
+	case protocolGRPCWeb:
+		return "application/grpc-web+" + codec.Name()
+	case protocolGRPCWebText:
+		return "application/grpc-web-text+" + codec.Name()
+	case protocolConnectStream:
+		return "application/connect+" + codec.Name()
+	default:
+		return "application/" + codec.Name()
+	}
+}
+
+// readWebRequest 解出请求中的全部消息：gRPC-Web 与 Connect streaming 为分帧消息，Connect unary 为单条消息
+// 与原生 gRPC 一致，单条消息超过 maxRecvMsgSize 时返回 ResourceExhausted
+func readWebRequest(w http.ResponseWriter, r *http.Request, protocol webProtocol) ([][]byte, error) {
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		message := []byte(q.Get("message"))
+		if q.Get("base64") == "1" {
+			decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(q.Get("message"), "="))
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid base64 message: %v", err)
+			}
+			message = decoded
+		}
+		if len(message) > maxRecvMsgSize {
+			return nil, errMessageTooLarge(len(message))
+		}
+		return [][]byte{message}, nil
+	}
+
+	// 请求体上限为一条最大消息加帧头，grpc-web-text 按 base64 编码后的长度计算
+	limit := maxRecvMsgSize + 5
+	if protocol == protocolGRPCWebText {
+		limit = base64.StdEncoding.EncodedLen(limit)
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(limit)))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, status.Errorf(codes.ResourceExhausted, "request body larger than max (%d)", tooLarge.Limit)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to read request body: %v", err)
+	}
+	switch protocol {
+	case protocolConnectUnary:
+		if len(body) > maxRecvMsgSize {
+			return nil, errMessageTooLarge(len(body))
+		}
+		return [][]byte{body}, nil
+	case protocolGRPCWebText:
+		if body, err = decodeBase64Chunks(body); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid grpc-web-text body: %v", err)
+		}
+	}
+
+	var messages [][]byte
+	for len(body) > 0 {
+		if len(body) < 5 {
+			return nil, status.Error(codes.InvalidArgument, "truncated message frame")
+		}
+		flag, size := body[0], binary.BigEndian.Uint32(body[1:5])
+		if size > maxRecvMsgSize {
+			return nil, errMessageTooLarge(int(size))
+		}
+		if uint64(len(body)-5) < uint64(size) {
+			return nil, status.Error(codes.InvalidArgument, "truncated message frame")
+		}
+		payload := body[5 : 5+size]
+		body = body[5+size:]
+		if flag&flagCompressed != 0 {
+			return nil, status.Error(codes.Unimplemented, "compressed messages are not supported")
+		}
+		if flag != 0 {
+			continue // 客户端不应发送 trailer / 结束帧，忽略
+		}
+		messages = append(messages, payload)
+	}
+	return messages, nil
+}
+
+func errMessageTooLarge(size int) error {
+	return status.Errorf(codes.ResourceExhausted, "received message larger than max (%d vs. %d)", size, maxRecvMsgSize)
+}
+
+// decodeBase64Chunks grpc-web-text 的请求体可能由多段带 padding 的 base64 拼接而成，按 4 字符分组解码
+func decodeBase64Chunks(data []byte) ([]byte, error) {
+	data = bytes.Join(bytes.Fields(data), nil)
+	if len(data)%4 != 0 {
+		return nil, errors.New("base64 length is not a multiple of 4")
+	}
+	out := make([]byte, 0, len(data)/4*3)
+	buf := make([]byte, 3)
+	for i := 0; i < len(data); i += 4 {
+		n, err := base64.StdEncoding.Decode(buf, data[i:i+4])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, buf[:n]...)
+	}
+	return out, nil
+}
+
+// requestMetadata HTTP 请求头转为 gRPC 元数据，与原生 gRPC 一样键为小写
+func requestMetadata(header http.Header) metadata.MD {
+	md := metadata.MD{}
+	for k, vs := range header {
+		key := strings.ToLower(k)
+		for _, v := range vs {
+			if strings.HasSuffix(key, "-bin") {
+				if decoded, err := base64.StdEncoding.DecodeString(v); err == nil {
+					v = string(decoded)
+				}
+			}
+			md.Append(key, v)
+		}
+	}
+	return md
+}
+
+// requestTimeout gRPC-Web 使用 grpc-timeout（如 500m、2S），Connect 使用 Connect-Timeout-Ms
+func requestTimeout(protocol webProtocol, header http.Header) (time.Duration, bool) {
+	if protocol == protocolConnectUnary || protocol == protocolConnectStream {
+		ms, err := strconv.ParseInt(header.Get("Connect-Timeout-Ms"), 10, 64)
+		return time.Duration(ms) * time.Millisecond, err == nil && ms > 0
+	}
+	v := header.Get("Grpc-Timeout")
+	if len(v) < 2 {
+		return 0, false
+	}
+	units := map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second, 'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond}
+	unit, ok := units[v[len(v)-1]]
+	n, err := strconv.ParseInt(v[:len(v)-1], 10, 64)
+	return time.Duration(n) * unit, ok && err == nil && n > 0
+}
+
+// writeCORSHeaders 浏览器客户端跨域访问需要的响应头
+func writeCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+	h := w.Header()
+	h.Set("Access-Control-Allow-Origin", origin)
+	h.Add("Vary", "Origin")
+	h.Set("Access-Control-Expose-Headers", "Grpc-Status, Grpc-Message, Grpc-Status-Details-Bin, *")
+	if r.Method == http.MethodOptions {
+		h.Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
+		if reqHeaders := r.Header.Get("Access-Control-Request-Headers"); reqHeaders != "" {
+			h.Set("Access-Control-Allow-Headers", reqHeaders)
+		}
+		h.Set("Access-Control-Max-Age", "7200")
+	}
+}
+
+// messageCodec 消息编码：+proto 为二进制 protobuf，+json 为 protojson
+type messageCodec interface {
+	Name() string
+	Marshal(m proto.Message) ([]byte, error)
+	Unmarshal(data []byte, m proto.Message) error
+}
+
+type protoCodec struct{}
+
+func (protoCodec) Name() string                                 { return "proto" }
+func (protoCodec) Marshal(m proto.Message) ([]byte, error)      { return proto.Marshal(m) }
+func (protoCodec) Unmarshal(data []byte, m proto.Message) error { return proto.Unmarshal(data, m) }
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                                 { return "json" }
+func (jsonCodec) Marshal(m proto.Message) ([]byte, error)      { return protojson.Marshal(m) }
+func (jsonCodec) Unmarshal(data []byte, m proto.Message) error { return protojson.Unmarshal(data, m) }
+
+// codecByName 空串按 proto 处理（application/grpc-web 默认为 protobuf）
+func codecByName(name string) (messageCodec, bool) {
+	switch name {
+	case "", "proto":
+		return protoCodec{}, true
+	case "json":
+		return jsonCodec{}, true
+	}
+	return nil, false
+}
+
+// webStream 将一次 gRPC-Web / Connect HTTP 请求适配为 callStream
+type webStream struct {
+	ctx         context.Context
+	w           http.ResponseWriter
+	protocol    webProtocol
+	codec       messageCodec
+	contentType string
+	inbound     [][]byte
+	header      metadata.MD
+	trailer     metadata.MD
+	wroteHeader bool
+	unaryBody   []byte // Connect unary 的 trailer 以响应头发送，消息在 finish 时与其一起写出
+}
+
+var _ callStream = (*webStream)(nil)
+
+func (s *webStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *webStream) RecvMsg(m any) error {
+	if len(s.inbound) == 0 {
+		return io.EOF
+	}
+	data := s.inbound[0]
+	s.inbound = s.inbound[1:]
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", m)
+	}
+	return s.codec.Unmarshal(data, msg)
+}
+
+func (s *webStream) SendMsg(m any) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", m)
+	}
+	data, err := s.codec.Marshal(msg)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+	if s.protocol == protocolConnectUnary {
+		s.unaryBody = data
+		return nil
+	}
+	s.writeHeader()
+	return s.writeFrame(0, data)
+}
+
+func (s *webStream) SetHeader(md metadata.MD) error {
+	if s.wroteHeader {
+		return errors.New("headers already sent")
+	}
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *webStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func (s *webStream) writeHeader() {
+	if s.wroteHeader {
+		return
+	}
+	s.wroteHeader = true
+	copyMetadata(s.w.Header(), "", s.header)
+	s.w.Header().Set("Content-Type", s.contentType)
+	s.w.WriteHeader(http.StatusOK)
+}
+
+func (s *webStream) writeFrame(flag byte, data []byte) error {
+	frame := make([]byte, 5+len(data))
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(data)))
+	copy(frame[5:], data)
+	if s.protocol == protocolGRPCWebText {
+		frame = []byte(base64.StdEncoding.EncodeToString(frame))
+	}
+	if _, err := s.w.Write(frame); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// finish 按协议写出调用结果：gRPC-Web 为 trailer 帧，Connect streaming 为结束帧，Connect unary 为响应体或错误 JSON
+func (s *webStream) finish(err error) {
+	st := status.Convert(err)
+	switch s.protocol {
+	case protocolGRPCWeb, protocolGRPCWebText:
+		s.writeHeader()
+		_ = s.writeFrame(flagGRPCWebTrailer, grpcWebTrailer(st, s.trailer))
+	case protocolConnectStream:
+		s.writeHeader()
+		end := struct {
+			Error    *connectError       `json:"error,omitempty"`
+			Metadata map[string][]string `json:"metadata,omitempty"`
+		}{Metadata: s.trailer}
+		if st.Code() != codes.OK {
+			end.Error = newConnectError(st)
+		}
+		data, _ := json.Marshal(end)
+		_ = s.writeFrame(flagConnectEnd, data)
+	default:
+		copyMetadata(s.w.Header(), "", s.header)
+		copyMetadata(s.w.Header(), "Trailer-", s.trailer)
+		if st.Code() != codes.OK {
+			s.w.Header().Set("Content-Type", "application/json")
+			s.w.WriteHeader(connectHTTPStatus(st.Code()))
+			_ = json.NewEncoder(s.w).Encode(newConnectError(st))
+			return
+		}
+		s.w.Header().Set("Content-Type", s.contentType)
+		s.w.WriteHeader(http.StatusOK)
+		_, _ = s.w.Write(s.unaryBody)
+	}
+}
+
+// copyMetadata 写入 HTTP 头，-bin 元数据按规范使用 base64
+func copyMetadata(h http.Header, prefix string, md metadata.MD) {
+	for k, vs := range md {
+		for _, v := range vs {
+			if strings.HasSuffix(k, "-bin") {
+				v = base64.RawStdEncoding.EncodeToString([]byte(v))
+			}
+			h.Add(prefix+k, v)
+		}
+	}
+}
+
+// grpcWebTrailer trailer 帧内容为 HTTP/1 头格式
+func grpcWebTrailer(st *status.Status, trailer metadata.MD) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "grpc-status: %d\r\n", st.Code())
+	if msg := st.Message(); msg != "" {
+		fmt.Fprintf(&b, "grpc-message: %s\r\n", encodeGRPCMessage(msg))
+	}
+	if len(st.Proto().GetDetails()) > 0 {
+		if data, err := proto.Marshal(st.Proto()); err == nil {
+			fmt.Fprintf(&b, "grpc-status-details-bin: %s\r\n", base64.RawStdEncoding.EncodeToString(data))
+		}
+	}
+	h := http.Header{}
+	copyMetadata(h, "", trailer)
+	for k, vs := range h {
+		for _, v := range vs {
+			fmt.Fprintf(&b, "%s: %s\r\n", strings.ToLower(k), v)
+		}
+	}
+	return []byte(b.String())
+}
+
+// encodeGRPCMessage grpc-message 中可打印 ASCII 以外的字节与 % 需要百分号编码
+func encodeGRPCMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// connectError Connect 协议的错误格式，details 的 value 为不带 padding 的 base64
+type connectError struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message,omitempty"`
+	Details []connectDetail `json:"details,omitempty"`
+}
+
+type connectDetail struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func newConnectError(st *status.Status) *connectError {
+	e := &connectError{Code: connectCode(st.Code()), Message: st.Message()}
+	for _, d := range st.Proto().GetDetails() {
+		typeName := d.GetTypeUrl()
+		if i := strings.LastIndex(typeName, "/"); i >= 0 {
+			typeName = typeName[i+1:]
+		}
+		e.Details = append(e.Details, connectDetail{Type: typeName, Value: base64.RawStdEncoding.EncodeToString(d.GetValue())})
+	}
+	return e
+}
+
+// connectCode gRPC 状态码在 Connect 中的名称，如 InvalidArgument -> invalid_argument
+func connectCode(c codes.Code) string {
+	if c == codes.Canceled {
+		return "canceled"
+	}
+	name := c.String()
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// connectHTTPStatus Connect unary 错误响应的 HTTP 状态码
+func connectHTTPStatus(c codes.Code) int {
+	switch c {
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
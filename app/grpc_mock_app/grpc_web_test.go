@@ -0,0 +1,254 @@
+package grpc_mock_app
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/domain/services"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+	"go_mock_server/internal/infra/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type webFrame struct {
+	flag byte
+	data []byte
+}
+
+func encodeFrames(frames ...webFrame) []byte {
+	var buf bytes.Buffer
+	for _, f := range frames {
+		buf.WriteByte(f.flag)
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(f.data)))
+		buf.Write(f.data)
+	}
+	return buf.Bytes()
+}
+
+func decodeFrames(t *testing.T, body []byte) []webFrame {
+	var frames []webFrame
+	for len(body) > 0 {
+		require.GreaterOrEqual(t, len(body), 5)
+		size := binary.BigEndian.Uint32(body[1:5])
+		frames = append(frames, webFrame{flag: body[0], data: body[5 : 5+size]})
+		body = body[5+size:]
+	}
+	return frames
+}
+
+func TestGRPCWebHandler(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	for _, rule := range []*model.MockRule{
+		newStreamRule("web_ok", "SayHello", 0,
+			model.MatchCondition{Type: "body_json", Operator: "eq", Key: "$.name", Value: "alice"},
+			&model.ResponseAction{
+				Body:     `{"message": "hi alice"}`,
+				Headers:  map[string]string{"x-mock-rule": "web_ok"},
+				Trailers: map[string]string{"x-mock-trailer": "done"},
+			}, model.ActionTypeResponse),
+		newStreamRule("web_not_found", "SayHello", 0,
+			model.MatchCondition{Type: "body_json", Operator: "eq", Key: "$.name", Value: "ghost"},
+			&model.ResponseAction{GRPCStatus: &model.GRPCStatus{Code: codes.NotFound, Message: "no such user: 100%"}}, model.ActionTypeResponse),
+		newStreamRule("web_stream", "StreamHello", 0,
+			model.MatchCondition{Type: "body_json", Operator: "eq", Key: "$.name", Value: "bob"},
+			&model.StreamAction{Messages: []*model.StreamMessage{{Body: `{"message": "one"}`}, {Body: `{"message": "two"}`}}}, model.ActionTypeStream),
+	} {
+		require.NoError(t, rule.Validate())
+		require.NoError(t, ruleRepo.SaveRule(ctx, rule))
+	}
+
+	descriptorRepo, err := repo.NewDescriptorRepoImpl(storage.NewMemoryRuleStorage())
+	require.NoError(t, err)
+	descriptorService := services.NewDescriptorService(descriptorRepo)
+	_, err = descriptorService.UploadDescriptorSet(ctx, "greeter", greeterDescriptorSet(t))
+	require.NoError(t, err)
+	server, err := NewGRPCMockServer(&configs.GRPCMockConfig{DisableReflection: true}, descriptorService, services.NewRuleMatchService(ruleRepo))
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	handler := NewGRPCWebHandler(server)
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	md, err := descriptorService.FindMethod("/mock.test.Greeter/SayHello")
+	require.NoError(t, err)
+	request := func(name string) []byte {
+		in := dynamicpb.NewMessage(md.Input())
+		in.Set(md.Input().Fields().ByName("name"), protoreflect.ValueOfString(name))
+		data, err := proto.Marshal(in)
+		require.NoError(t, err)
+		return data
+	}
+	replyMessage := func(data []byte) string {
+		out := dynamicpb.NewMessage(md.Output())
+		require.NoError(t, proto.Unmarshal(data, out))
+		return out.Get(md.Output().Fields().ByName("message")).String()
+	}
+	post := func(path, contentType string, body []byte, header map[string]string) (*http.Response, []byte) {
+		req, err := http.NewRequest(http.MethodPost, ts.URL+path, bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, data
+	}
+
+	t.Run("handles", func(t *testing.T) {
+		newReq := func(method, path, contentType string) *http.Request {
+			r := httptest.NewRequest(method, path, nil)
+			r.Header.Set("Content-Type", contentType)
+			return r
+		}
+		assert.True(t, handler.Handles(newReq(http.MethodPost, "/any/Path", "application/grpc-web+proto")))
+		assert.True(t, handler.Handles(newReq(http.MethodPost, "/any/Path", "application/connect+json")))
+		assert.True(t, handler.Handles(newReq(http.MethodPost, "/mock.test.Greeter/SayHello", "application/json")))
+		assert.False(t, handler.Handles(newReq(http.MethodPost, "/api/users", "application/json")))
+		connect := newReq(http.MethodPost, "/api/users", "application/json")
+		connect.Header.Set("Connect-Protocol-Version", "1")
+		assert.True(t, handler.Handles(connect))
+	})
+
+	t.Run("grpc-web binary unary", func(t *testing.T) {
+		resp, body := post("/mock.test.Greeter/SayHello", "application/grpc-web+proto", encodeFrames(webFrame{data: request("alice")}), nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/grpc-web+proto", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "web_ok", resp.Header.Get("X-Mock-Rule"))
+		frames := decodeFrames(t, body)
+		require.Len(t, frames, 2)
+		assert.Equal(t, "hi alice", replyMessage(frames[0].data))
+		assert.Equal(t, flagGRPCWebTrailer, frames[1].flag)
+		assert.Contains(t, string(frames[1].data), "grpc-status: 0\r\n")
+		assert.Contains(t, string(frames[1].data), "x-mock-trailer: done\r\n")
+	})
+
+	t.Run("grpc-web error status", func(t *testing.T) {
+		resp, body := post("/mock.test.Greeter/SayHello", "application/grpc-web", encodeFrames(webFrame{data: request("ghost")}), nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		frames := decodeFrames(t, body)
+		require.Len(t, frames, 1)
+		assert.Contains(t, string(frames[0].data), "grpc-status: 5\r\n")
+		assert.Contains(t, string(frames[0].data), "grpc-message: no such user: 100%25\r\n")
+	})
+
+	t.Run("grpc-web text server streaming", func(t *testing.T) {
+		reqBody := base64.StdEncoding.EncodeToString(encodeFrames(webFrame{data: request("bob")}))
+		resp, body := post("/mock.test.Greeter/StreamHello", "application/grpc-web-text", []byte(reqBody), nil)
+		assert.Equal(t, "application/grpc-web-text+proto", resp.Header.Get("Content-Type"))
+		decoded, err := decodeBase64Chunks(body)
+		require.NoError(t, err)
+		frames := decodeFrames(t, decoded)
+		require.Len(t, frames, 3)
+		assert.Equal(t, "one", replyMessage(frames[0].data))
+		assert.Equal(t, "two", replyMessage(frames[1].data))
+		assert.Contains(t, string(frames[2].data), "grpc-status: 0\r\n")
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		_, body := post("/mock.test.Greeter/Missing", "application/grpc-web", encodeFrames(webFrame{data: request("alice")}), nil)
+		frames := decodeFrames(t, body)
+		require.Len(t, frames, 1)
+		assert.Contains(t, string(frames[0].data), "grpc-status: 12\r\n")
+	})
+
+	t.Run("connect unary json", func(t *testing.T) {
+		resp, body := post("/mock.test.Greeter/SayHello", "application/json", []byte(`{"name": "alice"}`), map[string]string{"Connect-Protocol-Version": "1"})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "done", resp.Header.Get("Trailer-X-Mock-Trailer"))
+		assert.JSONEq(t, `{"message": "hi alice"}`, string(body))
+	})
+
+	t.Run("connect unary proto", func(t *testing.T) {
+		resp, body := post("/mock.test.Greeter/SayHello", "application/proto", request("alice"), nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "hi alice", replyMessage(body))
+	})
+
+	t.Run("connect unary get", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/mock.test.Greeter/SayHello?encoding=json&message=" + strings.ReplaceAll(`{"name":"alice"}`, `"`, "%22"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{"message": "hi alice"}`, string(body))
+	})
+
+	t.Run("connect unary error", func(t *testing.T) {
+		resp, body := post("/mock.test.Greeter/SayHello", "application/json", []byte(`{"name": "ghost"}`), map[string]string{"Connect-Protocol-Version": "1"})
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.JSONEq(t, `{"code": "not_found", "message": "no such user: 100%"}`, string(body))
+	})
+
+	t.Run("connect server streaming", func(t *testing.T) {
+		resp, body := post("/mock.test.Greeter/StreamHello", "application/connect+json",
+			encodeFrames(webFrame{data: []byte(`{"name": "bob"}`)}), map[string]string{"Connect-Protocol-Version": "1"})
+		assert.Equal(t, "application/connect+json", resp.Header.Get("Content-Type"))
+		frames := decodeFrames(t, body)
+		require.Len(t, frames, 3)
+		assert.JSONEq(t, `{"message": "one"}`, string(frames[0].data))
+		assert.JSONEq(t, `{"message": "two"}`, string(frames[1].data))
+		assert.Equal(t, flagConnectEnd, frames[2].flag)
+		var end map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(frames[2].data, &end))
+		assert.NotContains(t, end, "error")
+	})
+
+	t.Run("connect streaming error", func(t *testing.T) {
+		_, body := post("/mock.test.Greeter/SayHello", "application/connect+proto", encodeFrames(webFrame{data: request("ghost")}), nil)
+		frames := decodeFrames(t, body)
+		require.Len(t, frames, 1)
+		assert.Equal(t, flagConnectEnd, frames[0].flag)
+		assert.JSONEq(t, `{"error": {"code": "not_found", "message": "no such user: 100%"}}`, string(frames[0].data))
+	})
+
+	t.Run("oversized request", func(t *testing.T) {
+		oversized := bytes.Repeat([]byte{'a'}, maxRecvMsgSize+1)
+		_, body := post("/mock.test.Greeter/SayHello", "application/grpc-web+proto", encodeFrames(webFrame{data: oversized}), nil)
+		frames := decodeFrames(t, body)
+		require.Len(t, frames, 1)
+		assert.Contains(t, string(frames[0].data), "grpc-status: 8\r\n")
+
+		_, body = post("/mock.test.Greeter/SayHello", "application/proto", oversized, nil)
+		var connectErr map[string]string
+		require.NoError(t, json.Unmarshal(body, &connectErr))
+		assert.Equal(t, "resource_exhausted", connectErr["code"])
+	})
+
+	t.Run("cors preflight", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodOptions, ts.URL+"/mock.test.Greeter/SayHello", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", "http://localhost:3000")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+		assert.True(t, handler.Handles(req))
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "http://localhost:3000", resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "content-type,x-grpc-web", resp.Header.Get("Access-Control-Allow-Headers"))
+	})
+}
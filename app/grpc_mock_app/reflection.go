@@ -0,0 +1,126 @@
+package grpc_mock_app
+
+import (
+	"errors"
+
+	"go_mock_server/internal/domain/iface"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	v1reflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	v1alphareflectiongrpc "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// descriptorReflection 为 gRPC server reflection 提供数据：
+// mock 服务来自描述符仓库的当前快照，reflection 自身等编译期注册的服务回退到 protoregistry.GlobalFiles，
+// 每次查询都读取最新快照，上传或删除描述符后无需重启
+type descriptorReflection struct {
+	server            *grpc.Server
+	descriptorService iface.DescriptorService
+}
+
+var (
+	_ reflection.ServiceInfoProvider = (*descriptorReflection)(nil)
+	_ reflection.ExtensionResolver   = (*descriptorReflection)(nil)
+)
+
+// registerReflection 注册 v1 与 v1alpha 两个版本的 reflection 服务，grpcurl 等旧客户端只支持 v1alpha
+func registerReflection(server *grpc.Server, descriptorService iface.DescriptorService) {
+	r := &descriptorReflection{server: server, descriptorService: descriptorService}
+	opts := reflection.ServerOptions{Services: r, DescriptorResolver: r, ExtensionResolver: r}
+	v1reflectiongrpc.RegisterServerReflectionServer(server, reflection.NewServerV1(opts))
+	v1alphareflectiongrpc.RegisterServerReflectionServer(server, reflection.NewServer(opts))
+}
+
+// GetServiceInfo 合并静态注册的服务与描述符中的 mock 服务，reflection 只使用服务名
+func (r *descriptorReflection) GetServiceInfo() map[string]grpc.ServiceInfo {
+	info := r.server.GetServiceInfo()
+	for service, methods := range r.descriptorService.Services() {
+		if _, ok := info[service]; ok {
+			continue
+		}
+		si := grpc.ServiceInfo{Methods: make([]grpc.MethodInfo, 0, len(methods))}
+		for _, m := range methods {
+			si.Methods = append(si.Methods, grpc.MethodInfo{Name: m})
+		}
+		info[service] = si
+	}
+	return info
+}
+
+func (r *descriptorReflection) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	fd, err := r.descriptorService.Files().FindFileByPath(path)
+	if errors.Is(err, protoregistry.NotFound) {
+		return protoregistry.GlobalFiles.FindFileByPath(path)
+	}
+	return fd, err
+}
+
+func (r *descriptorReflection) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	d, err := r.descriptorService.Files().FindDescriptorByName(name)
+	if errors.Is(err, protoregistry.NotFound) {
+		return protoregistry.GlobalFiles.FindDescriptorByName(name)
+	}
+	return d, err
+}
+
+func (r *descriptorReflection) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	xt, err := dynamicpb.NewTypes(r.descriptorService.Files()).FindExtensionByName(field)
+	if errors.Is(err, protoregistry.NotFound) {
+		return protoregistry.GlobalTypes.FindExtensionByName(field)
+	}
+	return xt, err
+}
+
+func (r *descriptorReflection) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	xt, err := dynamicpb.NewTypes(r.descriptorService.Files()).FindExtensionByNumber(message, field)
+	if errors.Is(err, protoregistry.NotFound) {
+		return protoregistry.GlobalTypes.FindExtensionByNumber(message, field)
+	}
+	return xt, err
+}
+
+// RangeExtensionsByMessage dynamicpb.Types 不支持按消息遍历扩展，这里直接遍历描述符
+func (r *descriptorReflection) RangeExtensionsByMessage(message protoreflect.FullName, f func(protoreflect.ExtensionType) bool) {
+	seen := make(map[protoreflect.FieldNumber]bool)
+	stop := false
+	r.descriptorService.Files().RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		rangeExtensions(fd.Extensions(), fd.Messages(), func(xd protoreflect.ExtensionDescriptor) bool {
+			if xd.ContainingMessage().FullName() != message || seen[xd.Number()] {
+				return true
+			}
+			seen[xd.Number()] = true
+			stop = !f(dynamicpb.NewExtensionType(xd))
+			return !stop
+		})
+		return !stop
+	})
+	if stop {
+		return
+	}
+	protoregistry.GlobalTypes.RangeExtensionsByMessage(message, func(xt protoreflect.ExtensionType) bool {
+		if seen[xt.TypeDescriptor().Number()] {
+			return true
+		}
+		return f(xt)
+	})
+}
+
+// rangeExtensions 遍历文件级与嵌套在消息中的扩展声明
+func rangeExtensions(xds protoreflect.ExtensionDescriptors, mds protoreflect.MessageDescriptors, f func(protoreflect.ExtensionDescriptor) bool) bool {
+	for i := 0; i < xds.Len(); i++ {
+		if !f(xds.Get(i)) {
+			return false
+		}
+	}
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		if !rangeExtensions(md.Extensions(), md.Messages(), f) {
+			return false
+		}
+	}
+	return true
+}
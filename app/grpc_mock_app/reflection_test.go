@@ -0,0 +1,111 @@
+package grpc_mock_app
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go_mock_server/internal/domain/services"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+	"go_mock_server/internal/infra/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestGRPCMockServerReflection(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "greeter.protoset"), greeterDescriptorSet(t), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte("rules: []"), 0o644))
+
+	descriptorRepo, err := repo.NewDescriptorRepoImpl(storage.NewMemoryRuleStorage())
+	require.NoError(t, err)
+	descriptorService := services.NewDescriptorService(descriptorRepo)
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	server, err := NewGRPCMockServer(&configs.GRPCMockConfig{DescriptorSetDirs: []string{dir}}, descriptorService, services.NewRuleMatchService(ruleRepo))
+	require.NoError(t, err)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Close()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	ask := func(req *reflectionpb.ServerReflectionRequest) *reflectionpb.ServerReflectionResponse {
+		require.NoError(t, stream.Send(req))
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		return resp
+	}
+	listServices := func() []string {
+		resp := ask(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}})
+		var names []string
+		for _, s := range resp.GetListServicesResponse().GetService() {
+			names = append(names, s.GetName())
+		}
+		return names
+	}
+
+	assert.ElementsMatch(t, []string{
+		"mock.test.Greeter",
+		"grpc.reflection.v1.ServerReflection",
+		"grpc.reflection.v1alpha.ServerReflection",
+	}, listServices())
+
+	// 按服务名获取文件描述符，依赖的 well-known 类型一并返回
+	resp := ask(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{
+		FileContainingSymbol: "mock.test.Greeter",
+	}})
+	var files []string
+	for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		fd := &descriptorpb.FileDescriptorProto{}
+		require.NoError(t, proto.Unmarshal(raw, fd))
+		files = append(files, fd.GetName())
+	}
+	assert.ElementsMatch(t, []string{"mock/test/greeter.proto", "google/protobuf/timestamp.proto"}, files)
+
+	resp = ask(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{
+		FileContainingSymbol: "mock.test.Missing",
+	}})
+	assert.NotNil(t, resp.GetErrorResponse())
+
+	// 运行期间上传的描述符立即可见
+	_, err = descriptorService.UploadProtoFiles(ctx, "user", map[string]string{"user.proto": `syntax = "proto3";
+package user;
+message GetUserRequest { string id = 1; }
+message User { string id = 1; }
+service UserService { rpc GetUser(GetUserRequest) returns (User); }`})
+	require.NoError(t, err)
+	assert.Contains(t, listServices(), "user.UserService")
+}
+
+func TestGRPCMockServerReflectionDisabled(t *testing.T) {
+	descriptorRepo, err := repo.NewDescriptorRepoImpl(storage.NewMemoryRuleStorage())
+	require.NoError(t, err)
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	server, err := NewGRPCMockServer(&configs.GRPCMockConfig{DisableReflection: true},
+		services.NewDescriptorService(descriptorRepo), services.NewRuleMatchService(ruleRepo))
+	require.NoError(t, err)
+	assert.Empty(t, server.server.GetServiceInfo())
+
+	_, err = NewGRPCMockServer(&configs.GRPCMockConfig{DescriptorSetDirs: []string{filepath.Join(t.TempDir(), "missing")}},
+		services.NewDescriptorService(descriptorRepo), services.NewRuleMatchService(ruleRepo))
+	assert.Error(t, err)
+}
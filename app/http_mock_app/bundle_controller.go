@@ -0,0 +1,126 @@
+package http_mock_app
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strconv"
+
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/infra/converter"
+	"go_mock_server/utils"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+)
+
+// BundleController 规则集快照的导出 / 导入接口
+type BundleController struct {
+	BundleService iface.RuleBundleService
+}
+
+func NewBundleController(bundleService iface.RuleBundleService) *BundleController {
+	return &BundleController{BundleService: bundleService}
+}
+
+// ExportBundle GET /mock/bundle/export?format=json|yaml&protocol=&path=
+func (c *BundleController) ExportBundle(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("ExportBundle Begin")
+	defer c.recoverPanic(b)
+
+	format, err := converter.ParseBundleFormat(b.ReadQueryParameter("format"))
+	if err != nil {
+		writeError(b, err.Error())
+		return
+	}
+
+	bundle, err := c.BundleService.ExportBundle(b.Ctx, readRuleFilter(b))
+	if err != nil {
+		logger.Errorf("ExportBundle err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	data, err := converter.EncodeBundle(bundle, format)
+	if err != nil {
+		logger.Errorf("encode bundle err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	b.AddHeader("Content-Type", bundleContentType(format))
+	b.AddHeader("Content-Disposition", fmt.Sprintf("attachment; filename=rules.%s", format))
+	b.Write(data)
+}
+
+// ImportBundle POST /mock/bundle/import?mode=merge|replace&dryRun=true|false&format=json|yaml，请求体为 bundle 文件
+func (c *BundleController) ImportBundle(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("ImportBundle Begin")
+	defer c.recoverPanic(b)
+
+	format, err := converter.ParseBundleFormat(b.ReadQueryParameter("format"))
+	if err != nil {
+		writeError(b, err.Error())
+		return
+	}
+	mode := model.BundleImportMode(b.ReadQueryParameter("mode"))
+	if mode == "" {
+		mode = model.BundleImportMerge
+	}
+	dryRun := false
+	if v := b.ReadQueryParameter("dryRun"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			writeError(b, fmt.Sprintf("invalid dryRun: %s", v))
+			return
+		}
+	}
+
+	data, err := io.ReadAll(b.ReadRequest().Body)
+	if err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	bundle, err := converter.DecodeBundle(data, format)
+	if err != nil {
+		writeError(b, err.Error())
+		return
+	}
+
+	result, err := c.BundleService.ImportBundle(b.Ctx, bundle, mode, dryRun)
+	if err != nil {
+		logger.Errorf("ImportBundle err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	b.WriteJSON(result, "application/json")
+}
+
+func (c *BundleController) recoverPanic(b *rf.Context) {
+	if err := recover(); err != nil {
+		utils.GetLogger().WithFields(map[string]interface{}{
+			"panic": err,
+			"stack": string(debug.Stack()),
+		}).Error("handle request panic")
+		writeError(b, "Internal server error")
+	}
+}
+
+func bundleContentType(format converter.BundleFormat) string {
+	if format == converter.BundleFormatYAML {
+		return "application/yaml"
+	}
+	return "application/json"
+}
+
+func (c *BundleController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "GET", Path: "/mock/bundle/export", ResourceFunc: c.ExportBundle,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/bundle/import", ResourceFunc: c.ImportBundle,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
@@ -0,0 +1,32 @@
+package http_mock_app
+
+import (
+	"net/http"
+
+	"go_mock_server/internal/infra/certs"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+)
+
+// CAController 提供本地 CA 证书下载，客户端将其加入信任列表后即可访问 HTTPS mock
+type CAController struct {
+	CA *certs.CertificateAuthority
+}
+
+func NewCAController(ca *certs.CertificateAuthority) *CAController {
+	return &CAController{CA: ca}
+}
+
+func (c *CAController) DownloadCA(b *rf.Context) {
+	b.AddHeader("Content-Type", "application/x-x509-ca-cert")
+	b.AddHeader("Content-Disposition", `attachment; filename="go_mock_server-ca.crt"`)
+	b.WriteHeader(http.StatusOK)
+	_ = b.Write(c.CA.CertificatePEM())
+}
+
+func (c *CAController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "GET", Path: "/mock/https/ca.crt", ResourceFunc: c.DownloadCA,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
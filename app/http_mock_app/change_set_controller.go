@@ -0,0 +1,246 @@
+package http_mock_app
+
+import (
+	"runtime/debug"
+
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/utils"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+)
+
+// ChangeSetController 草稿变更集接口：暂存、预览、发布与丢弃
+type ChangeSetController struct {
+	ChangeSetService iface.ChangeSetService
+}
+
+func NewChangeSetController(changeSetService iface.ChangeSetService) *ChangeSetController {
+	return &ChangeSetController{ChangeSetService: changeSetService}
+}
+
+// CreateChangeSet POST /mock/changesets
+func (c *ChangeSetController) CreateChangeSet(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("CreateChangeSet Begin")
+	defer c.recoverPanic(b)
+
+	var req CreateChangeSetRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(b, err.Error())
+		return
+	}
+
+	cs, err := c.ChangeSetService.CreateChangeSet(b.Ctx, req.Name, req.Description)
+	if err != nil {
+		logger.Errorf("CreateChangeSet err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(cs, "application/json")
+}
+
+// ListChangeSets GET /mock/changesets?status=open|published|discarded
+func (c *ChangeSetController) ListChangeSets(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("ListChangeSets Begin")
+	defer c.recoverPanic(b)
+
+	sets, err := c.ChangeSetService.ListChangeSets(b.Ctx, model.ChangeSetStatus(b.ReadQueryParameter("status")))
+	if err != nil {
+		logger.Errorf("ListChangeSets err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(sets, "application/json")
+}
+
+// GetChangeSet GET /mock/changesets/{id}
+func (c *ChangeSetController) GetChangeSet(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("GetChangeSet Begin")
+	defer c.recoverPanic(b)
+
+	cs, err := c.ChangeSetService.GetChangeSet(b.Ctx, b.ReadPathParameter("id"))
+	if err != nil {
+		logger.Errorf("GetChangeSet err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(cs, "application/json")
+}
+
+// StageRule POST /mock/changesets/{id}/rules
+func (c *ChangeSetController) StageRule(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("StageRule Begin")
+	defer c.recoverPanic(b)
+
+	var req StageRuleRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(b, err.Error())
+		return
+	}
+	rule, err := req.ConvertToMockRule()
+	if err != nil {
+		writeError(b, err.Error())
+		return
+	}
+
+	item, err := c.ChangeSetService.StageRule(b.Ctx, b.ReadPathParameter("id"), rule, model.RuleStatus(req.TargetStatus))
+	if err != nil {
+		logger.Errorf("StageRule err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(item, "application/json")
+}
+
+// StageDelete POST /mock/changesets/{id}/rules/{ruleId}/delete
+func (c *ChangeSetController) StageDelete(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("StageDelete Begin")
+	defer c.recoverPanic(b)
+
+	item, err := c.ChangeSetService.StageDelete(b.Ctx, b.ReadPathParameter("id"), b.ReadPathParameter("ruleId"))
+	if err != nil {
+		logger.Errorf("StageDelete err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(item, "application/json")
+}
+
+// Unstage DELETE /mock/changesets/{id}/rules/{ruleId}
+func (c *ChangeSetController) Unstage(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("Unstage Begin")
+	defer c.recoverPanic(b)
+
+	if err := c.ChangeSetService.Unstage(b.Ctx, b.ReadPathParameter("id"), b.ReadPathParameter("ruleId")); err != nil {
+		logger.Errorf("Unstage err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	writeSuccess(b)
+}
+
+// Preview POST /mock/changesets/{id}/preview
+func (c *ChangeSetController) Preview(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("PreviewChangeSet Begin")
+	defer c.recoverPanic(b)
+
+	var req PreviewChangeSetRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		writeError(b, err.Error())
+		return
+	}
+	samples, err := req.ConvertToRequestInfos()
+	if err != nil {
+		writeError(b, err.Error())
+		return
+	}
+
+	preview, err := c.ChangeSetService.Preview(b.Ctx, b.ReadPathParameter("id"), samples)
+	if err != nil {
+		logger.Errorf("PreviewChangeSet err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(preview, "application/json")
+}
+
+// Publish POST /mock/changesets/{id}/publish
+func (c *ChangeSetController) Publish(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("PublishChangeSet Begin")
+	defer c.recoverPanic(b)
+
+	cs, err := c.ChangeSetService.Publish(b.Ctx, b.ReadPathParameter("id"))
+	if err != nil {
+		logger.Errorf("PublishChangeSet err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(cs, "application/json")
+}
+
+// Discard POST /mock/changesets/{id}/discard
+func (c *ChangeSetController) Discard(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("DiscardChangeSet Begin")
+	defer c.recoverPanic(b)
+
+	if err := c.ChangeSetService.Discard(b.Ctx, b.ReadPathParameter("id")); err != nil {
+		logger.Errorf("DiscardChangeSet err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	writeSuccess(b)
+}
+
+// RuleHistory GET /mock/rules/{id}/history
+func (c *ChangeSetController) RuleHistory(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("RuleHistory Begin")
+	defer c.recoverPanic(b)
+
+	histories, err := c.ChangeSetService.RuleHistory(b.Ctx, b.ReadPathParameter("id"))
+	if err != nil {
+		logger.Errorf("RuleHistory err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(histories, "application/json")
+}
+
+func (c *ChangeSetController) recoverPanic(b *rf.Context) {
+	if err := recover(); err != nil {
+		utils.GetLogger().WithFields(map[string]interface{}{
+			"panic": err,
+			"stack": string(debug.Stack()),
+		}).Error("handle request panic")
+		writeError(b, "Internal server error")
+	}
+}
+
+func (c *ChangeSetController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "POST", Path: "/mock/changesets", ResourceFunc: c.CreateChangeSet,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "GET", Path: "/mock/changesets", ResourceFunc: c.ListChangeSets,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "GET", Path: "/mock/changesets/{id}", ResourceFunc: c.GetChangeSet,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/changesets/{id}/rules", ResourceFunc: c.StageRule,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/changesets/{id}/rules/{ruleId}/delete", ResourceFunc: c.StageDelete,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "DELETE", Path: "/mock/changesets/{id}/rules/{ruleId}", ResourceFunc: c.Unstage,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/changesets/{id}/preview", ResourceFunc: c.Preview,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/changesets/{id}/publish", ResourceFunc: c.Publish,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/changesets/{id}/discard", ResourceFunc: c.Discard,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "GET", Path: "/mock/rules/{id}/history", ResourceFunc: c.RuleHistory,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
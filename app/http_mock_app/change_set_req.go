@@ -0,0 +1,75 @@
+package http_mock_app
+
+import (
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// CreateChangeSetRequest 创建变更集请求
+type CreateChangeSetRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	Description string `json:"description,omitempty" validate:"max=512"`
+}
+
+func (req *CreateChangeSetRequest) Validate() error {
+	if err := validator.New().Struct(req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	return nil
+}
+
+// StageRuleRequest 暂存规则请求，ruleId 为空时新建规则
+type StageRuleRequest struct {
+	RuleID       string                `json:"ruleId,omitempty" validate:"max=36"`
+	TargetStatus string                `json:"targetStatus,omitempty" validate:"omitempty,oneof=active inactive"`
+	Rule         CreateMockRuleRequest `json:"rule"`
+}
+
+func (req *StageRuleRequest) Validate() error {
+	if err := validator.New().Struct(req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	return req.Rule.Validate()
+}
+
+// ConvertToMockRule converts StageRuleRequest DTO to MockRule model
+func (req *StageRuleRequest) ConvertToMockRule() (*model.MockRule, error) {
+	rule, err := req.Rule.ConvertToMockRule()
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = req.RuleID
+	return rule, nil
+}
+
+// PreviewChangeSetRequest 变更集预览请求
+type PreviewChangeSetRequest struct {
+	Samples []DryRunMatchRequest `json:"samples" validate:"required,min=1,max=100"`
+}
+
+func (req *PreviewChangeSetRequest) Validate() error {
+	if err := validator.New().Struct(req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	for i := range req.Samples {
+		if err := req.Samples[i].Validate(); err != nil {
+			return fmt.Errorf("sample %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ConvertToRequestInfos converts samples to RequestInfo
+func (req *PreviewChangeSetRequest) ConvertToRequestInfos() ([]model.RequestInfo, error) {
+	infos := make([]model.RequestInfo, 0, len(req.Samples))
+	for i := range req.Samples {
+		info, err := req.Samples[i].ConvertToRequestInfo()
+		if err != nil {
+			return nil, fmt.Errorf("sample %d: %w", i, err)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
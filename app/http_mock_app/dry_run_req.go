@@ -0,0 +1,99 @@
+package http_mock_app
+
+import (
+	"encoding/base64"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DryRunMatchRequest 描述一个用于 dry-run 的合成请求
+type DryRunMatchRequest struct {
+	Protocol   string            `json:"protocol" validate:"required,oneof=http https grpc websocket"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path" validate:"required"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Query      map[string]string `json:"query,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	BodyBase64 string            `json:"bodyBase64,omitempty"`
+}
+
+// Validate performs validation on DryRunMatchRequest
+func (req *DryRunMatchRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	if req.Body != "" && req.BodyBase64 != "" {
+		return fmt.Errorf("invalid request: body and bodyBase64 are mutually exclusive")
+	}
+	return nil
+}
+
+// ConvertToRequestInfo converts DryRunMatchRequest DTO to RequestInfo
+func (req *DryRunMatchRequest) ConvertToRequestInfo() (model.RequestInfo, error) {
+	body := []byte(req.Body)
+	if req.BodyBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(req.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bodyBase64: %w", err)
+		}
+		body = decoded
+	}
+	return model.NewSyntheticRequest(req.Protocol, req.Method, req.Path, req.Headers, req.Query, body), nil
+}
+
+type DryRunResponseDTO struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	BodyBase64 string            `json:"bodyBase64,omitempty"`
+	DelayMs    int64             `json:"delayMs,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+type DryRunMatchResponse struct {
+	MatchIndex      string                  `json:"matchIndex"`
+	MatchedRuleID   string                  `json:"matchedRuleId,omitempty"`
+	MatchedRuleName string                  `json:"matchedRuleName,omitempty"`
+	Traces          []*model.RuleMatchTrace `json:"traces"`
+	Response        *DryRunResponseDTO      `json:"response,omitempty"`
+}
+
+// NewDryRunMatchResponse converts DryRunResult model to response DTO
+func NewDryRunMatchResponse(result *model.DryRunResult) *DryRunMatchResponse {
+	resp := &DryRunMatchResponse{
+		MatchIndex: result.MatchIndex,
+		Traces:     result.Traces,
+	}
+	if result.MatchedRule == nil {
+		return resp
+	}
+
+	resp.MatchedRuleID = result.MatchedRule.ID
+	resp.MatchedRuleName = result.MatchedRule.Name
+	resp.Response = &DryRunResponseDTO{}
+	if result.ActionError != nil {
+		resp.Response.Error = result.ActionError.Error()
+		return resp
+	}
+	if result.Response == nil {
+		return resp
+	}
+
+	resp.Response.StatusCode = result.Response.GetStatus()
+	resp.Response.Headers = result.Response.GetHeaders()
+	resp.Response.DelayMs = result.Response.GetDelay().Milliseconds()
+	if err := result.Response.GetError(); err != nil {
+		resp.Response.Error = err.Error()
+	}
+	body := result.Response.GetBody()
+	if utf8.Valid(body) {
+		resp.Response.Body = string(body)
+	} else {
+		resp.Response.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+	return resp
+}
@@ -0,0 +1,86 @@
+package http_mock_app
+
+import (
+	"runtime/debug"
+
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/utils"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+)
+
+// ExportController 提供将规则导出为外部格式的接口
+type ExportController struct {
+	ExportService iface.RuleExportService
+}
+
+func NewExportController(exportService iface.RuleExportService) *ExportController {
+	return &ExportController{ExportService: exportService}
+}
+
+type ExportResponse struct {
+	Data   any                     `json:"data"`
+	Report *model.ConversionReport `json:"report"`
+}
+
+func (c *ExportController) ExportWireMock(b *rf.Context) {
+	c.handleExport(b, "ExportWireMock", func(filter *model.RuleFilter) (any, *model.ConversionReport, error) {
+		return c.ExportService.ExportWireMock(b.Ctx, filter)
+	})
+}
+
+func (c *ExportController) ExportPostman(b *rf.Context) {
+	name := b.ReadQueryParameter("name")
+	if name == "" {
+		name = "go_mock_server"
+	}
+	c.handleExport(b, "ExportPostman", func(filter *model.RuleFilter) (any, *model.ConversionReport, error) {
+		return c.ExportService.ExportPostman(b.Ctx, name, filter)
+	})
+}
+
+func (c *ExportController) handleExport(b *rf.Context, name string, doExport func(filter *model.RuleFilter) (any, *model.ConversionReport, error)) {
+	logger := utils.GetLogger()
+	logger.Info(name + " Begin")
+
+	defer func() {
+		if err := recover(); err != nil {
+			logger.WithFields(map[string]interface{}{
+				"panic": err,
+				"stack": string(debug.Stack()),
+			}).Error("handle request panic")
+			writeError(b, "Internal server error")
+		}
+	}()
+
+	data, report, err := doExport(readRuleFilter(b))
+	if err != nil {
+		logger.Errorf("%s err: %v", name, err)
+		writeError(b, err.Error())
+		return
+	}
+
+	b.WriteJSON(&ExportResponse{Data: data, Report: report}, "application/json")
+}
+
+// readRuleFilter builds RuleFilter from query parameters
+func readRuleFilter(b *rf.Context) *model.RuleFilter {
+	filter := &model.RuleFilter{}
+	if v := b.ReadQueryParameter("protocol"); v != "" {
+		filter.Protocol = &v
+	}
+	if v := b.ReadQueryParameter("path"); v != "" {
+		filter.PathContains = &v
+	}
+	return filter
+}
+
+func (c *ExportController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "GET", Path: "/mock/export/wiremock", ResourceFunc: c.ExportWireMock,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "GET", Path: "/mock/export/postman", ResourceFunc: c.ExportPostman,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
@@ -87,9 +87,76 @@ func (c *MockController) CreateMockRule(b *rf.Context) {
 	}{Message: "success"}, "application/json")
 }
 
+// DryRunMatch evaluates a synthetic request against the live rule set without side effects
+func (c *MockController) DryRunMatch(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("DryRunMatch Begin")
+
+	// Record request metrics
+	metrics.CounterAdd("request_counter", 1, map[string]string{
+		"method":   b.ReadRequest().Method,
+		"endpoint": b.ReadRequest().URL.Path,
+	})
+
+	defer func() {
+		if err := recover(); err != nil {
+			logger.WithFields(map[string]interface{}{
+				"panic": err,
+				"stack": string(debug.Stack()),
+			}).Error("handle request panic")
+			writeError(b, "Internal server error")
+		}
+	}()
+
+	var req DryRunMatchRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		logger.Errorf("validate request err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	reqInfo, err := req.ConvertToRequestInfo()
+	if err != nil {
+		logger.Errorf("convert request err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	result, err := c.MockService.DryRunMatch(b.Ctx, reqInfo)
+	if err != nil {
+		logger.Errorf("dry run match err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	b.WriteJSON(NewDryRunMatchResponse(result), "application/json")
+}
+
+// writeError writes a JSON error body
+func writeError(b *rf.Context, msg string) {
+	b.WriteJSON(struct {
+		Error string `json:"error"`
+	}{Error: msg}, "application/json")
+}
+
+// writeSuccess writes a JSON success message
+func writeSuccess(b *rf.Context) {
+	b.WriteJSON(struct {
+		Message string `json:"message"`
+	}{Message: "success"}, "application/json")
+}
+
 func (c *MockController) URLPatterns() []rf.Route {
 	return []rf.Route{
 		{Method: "POST", Path: "/mock/create_rule", ResourceFunc: c.CreateMockRule,
 			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/dry_run", ResourceFunc: c.DryRunMatch,
+			Returns: []*rf.Returns{{Code: 200}}},
 	}
 }
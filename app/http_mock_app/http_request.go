@@ -56,3 +56,7 @@ func (h *HTTPRequestInfo) GetBodyJSON() (map[string]any, error) {
 	}
 	return bodyMap, nil
 }
+
+func (h *HTTPRequestInfo) GetMatchIndex() string {
+	return model.BuildL1MatchIndexKeyFromReq(h)
+}
@@ -0,0 +1,53 @@
+package http_mock_app
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"go_mock_server/internal/infra/certs"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/utils"
+)
+
+// HTTPSMockServer HTTPS mock 数据面：证书由本地 CA 按 SNI 主机名即时签发，
+// 客户端信任 CA 后可以直接使用生产环境的域名访问 mock
+type HTTPSMockServer struct {
+	listenAddr string
+	tlsConfig  *tls.Config
+	server     *http.Server
+}
+
+func NewHTTPSMockServer(config *configs.HTTPSMockConfig, ca *certs.CertificateAuthority, handler http.Handler) *HTTPSMockServer {
+	tlsConfig := ca.TLSConfig()
+	return &HTTPSMockServer{
+		listenAddr: config.ListenAddr,
+		tlsConfig:  tlsConfig,
+		server:     &http.Server{Handler: handler, TLSConfig: tlsConfig},
+	}
+}
+
+func (s *HTTPSMockServer) ListenAndServe() error {
+	lis, err := net.Listen("tcp", s.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.listenAddr, err)
+	}
+	utils.GetLogger().Infof("https mock server listening on %s", s.listenAddr)
+	return s.Serve(lis)
+}
+
+// Serve 在已有的 TCP 监听上提供 HTTPS 服务
+func (s *HTTPSMockServer) Serve(lis net.Listener) error {
+	// 证书由 TLSConfig.GetCertificate 提供，无需证书文件
+	err := s.server.ServeTLS(lis, "", "")
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *HTTPSMockServer) Close() {
+	_ = s.server.Close()
+}
@@ -0,0 +1,71 @@
+package http_mock_app
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/domain/services"
+	"go_mock_server/internal/infra/certs"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSMockServer(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	rule := newHTTPRule(t, "payments", "/v1/charges", model.ActionTypeResponse, &model.ResponseAction{StatusCode: 200, Body: "charged"})
+	rule.Protocol = "https"
+	rule.MatchConfig.Conditions = append(rule.MatchConfig.Conditions,
+		model.MatchCondition{Type: model.MatchSNI, Operator: "eq", Value: "api.payments.example.com"})
+	require.NoError(t, rule.Validate())
+	require.NoError(t, ruleRepo.SaveRule(ctx, rule))
+
+	config := &configs.HTTPSMockConfig{}
+	ca, err := certs.LoadOrCreateCA(config)
+	require.NoError(t, err)
+	server := NewHTTPSMockServer(config, ca, NewMockHandler(services.NewRuleMatchService(ruleRepo)))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate())
+	// 客户端使用生产域名，连接指向本地监听
+	get := func(host string) (*http.Response, error) {
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots},
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, lis.Addr().String())
+			},
+		}}
+		return client.Get("https://" + host + "/v1/charges")
+	}
+
+	t.Run("matches rule by sni", func(t *testing.T) {
+		resp, err := get("api.payments.example.com")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "charged", string(body))
+		assert.Equal(t, "api.payments.example.com", resp.TLS.PeerCertificates[0].DNSNames[0])
+	})
+
+	t.Run("other host does not match", func(t *testing.T) {
+		resp, err := get("api.orders.example.com")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
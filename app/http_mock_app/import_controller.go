@@ -0,0 +1,208 @@
+package http_mock_app
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/utils"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+	"github.com/go-playground/validator/v10"
+)
+
+// ImportController 提供从外部格式导入规则的接口
+type ImportController struct {
+	ImportService iface.RuleImportService
+}
+
+func NewImportController(importService iface.RuleImportService) *ImportController {
+	return &ImportController{ImportService: importService}
+}
+
+type OpenAPIImportRequest struct {
+	Spec          string `json:"spec" validate:"required"` // OpenAPI 文档内容（YAML 或 JSON）
+	DefaultStatus string `json:"defaultStatus,omitempty"`
+	Tag           string `json:"tag,omitempty" validate:"max=50"`
+	Priority      int    `json:"priority" validate:"min=0"`
+}
+
+// Validate performs validation on OpenAPIImportRequest
+func (req *OpenAPIImportRequest) Validate() error {
+	if err := validator.New().Struct(req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	return nil
+}
+
+type HARImportRequest struct {
+	HAR          json.RawMessage `json:"har" validate:"required"` // HAR 文件内容
+	MatchQuery   []string        `json:"matchQuery,omitempty"`
+	MatchHeaders []string        `json:"matchHeaders,omitempty"`
+	Hosts        []string        `json:"hosts,omitempty"`
+	Tag          string          `json:"tag,omitempty" validate:"max=50"`
+	Priority     int             `json:"priority" validate:"min=0"`
+}
+
+// Validate performs validation on HARImportRequest
+func (req *HARImportRequest) Validate() error {
+	if err := validator.New().Struct(req); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	return nil
+}
+
+type WireMockImportRequest struct {
+	Mappings json.RawMessage `json:"mappings" validate:"required"` // stub mappings，{"mappings": [...]} 或单个 stub
+}
+
+type PostmanImportRequest struct {
+	Collection json.RawMessage `json:"collection" validate:"required"` // Postman collection v2.1
+}
+
+func (c *ImportController) ImportOpenAPI(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("ImportOpenAPI Begin")
+
+	defer func() {
+		if err := recover(); err != nil {
+			logger.WithFields(map[string]interface{}{
+				"panic": err,
+				"stack": string(debug.Stack()),
+			}).Error("handle request panic")
+			writeError(b, "Internal server error")
+		}
+	}()
+
+	var req OpenAPIImportRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		logger.Errorf("validate request err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	result, err := c.ImportService.ImportOpenAPI(b.Ctx, []byte(req.Spec), model.OpenAPIImportOptions{
+		DefaultStatus: req.DefaultStatus,
+		Tag:           req.Tag,
+		Priority:      req.Priority,
+	})
+	if err != nil {
+		logger.Errorf("import openapi err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	b.WriteJSON(result, "application/json")
+}
+
+func (c *ImportController) ImportHAR(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("ImportHAR Begin")
+
+	defer func() {
+		if err := recover(); err != nil {
+			logger.WithFields(map[string]interface{}{
+				"panic": err,
+				"stack": string(debug.Stack()),
+			}).Error("handle request panic")
+			writeError(b, "Internal server error")
+		}
+	}()
+
+	var req HARImportRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	if err := req.Validate(); err != nil {
+		logger.Errorf("validate request err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	result, err := c.ImportService.ImportHAR(b.Ctx, req.HAR, model.HARImportOptions{
+		MatchQuery:   req.MatchQuery,
+		MatchHeaders: req.MatchHeaders,
+		Hosts:        req.Hosts,
+		Tag:          req.Tag,
+		Priority:     req.Priority,
+	})
+	if err != nil {
+		logger.Errorf("import har err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	b.WriteJSON(result, "application/json")
+}
+
+func (c *ImportController) ImportWireMock(b *rf.Context) {
+	var req WireMockImportRequest
+	c.handleImport(b, "ImportWireMock", &req, func() (*model.ImportResult, error) {
+		return c.ImportService.ImportWireMock(b.Ctx, req.Mappings)
+	})
+}
+
+func (c *ImportController) ImportPostman(b *rf.Context) {
+	var req PostmanImportRequest
+	c.handleImport(b, "ImportPostman", &req, func() (*model.ImportResult, error) {
+		return c.ImportService.ImportPostman(b.Ctx, req.Collection)
+	})
+}
+
+// handleImport reads and validates req, then runs the import
+func (c *ImportController) handleImport(b *rf.Context, name string, req any, doImport func() (*model.ImportResult, error)) {
+	logger := utils.GetLogger()
+	logger.Info(name + " Begin")
+
+	defer func() {
+		if err := recover(); err != nil {
+			logger.WithFields(map[string]interface{}{
+				"panic": err,
+				"stack": string(debug.Stack()),
+			}).Error("handle request panic")
+			writeError(b, "Internal server error")
+		}
+	}()
+
+	if err := b.ReadEntity(req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	if err := validator.New().Struct(req); err != nil {
+		logger.Errorf("validate request err: %v", err)
+		writeError(b, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+
+	result, err := doImport()
+	if err != nil {
+		logger.Errorf("%s err: %v", name, err)
+		writeError(b, err.Error())
+		return
+	}
+
+	b.WriteJSON(result, "application/json")
+}
+
+func (c *ImportController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "POST", Path: "/mock/import/openapi", ResourceFunc: c.ImportOpenAPI,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/import/har", ResourceFunc: c.ImportHAR,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/import/wiremock", ResourceFunc: c.ImportWireMock,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/import/postman", ResourceFunc: c.ImportPostman,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
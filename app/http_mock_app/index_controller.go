@@ -0,0 +1,53 @@
+package http_mock_app
+
+import (
+	"runtime/debug"
+	"strconv"
+
+	"go_mock_server/internal/domain/iface"
+	"go_mock_server/utils"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+)
+
+// IndexController 规则索引维护接口
+type IndexController struct {
+	Reconciler iface.IndexReconciler
+}
+
+func NewIndexController(reconciler iface.IndexReconciler) *IndexController {
+	return &IndexController{Reconciler: reconciler}
+}
+
+// Reconcile POST /mock/index/reconcile?dryRun=true
+func (c *IndexController) Reconcile(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("Reconcile Begin")
+
+	defer func() {
+		if err := recover(); err != nil {
+			logger.WithFields(map[string]interface{}{
+				"panic": err,
+				"stack": string(debug.Stack()),
+			}).Error("handle request panic")
+			writeError(b, "Internal server error")
+		}
+	}()
+
+	dryRun, _ := strconv.ParseBool(b.ReadQueryParameter("dryRun"))
+	report, err := c.Reconciler.Reconcile(b.Ctx, dryRun)
+	if err != nil {
+		logger.Errorf("Reconcile err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	b.WriteJSON(report, "application/json")
+}
+
+func (c *IndexController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "POST", Path: "/mock/index/reconcile", ResourceFunc: c.Reconcile,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
@@ -0,0 +1,110 @@
+package http_mock_app
+
+import (
+	"runtime/debug"
+	"strconv"
+
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/utils"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+)
+
+// LifecycleController 规则生命周期接口：状态迁移、归档（软删除）、恢复与物理删除
+type LifecycleController struct {
+	RuleManageService iface.RuleService
+}
+
+func NewLifecycleController(ruleManageService iface.RuleService) *LifecycleController {
+	return &LifecycleController{RuleManageService: ruleManageService}
+}
+
+// TransitionRuleRequest 状态迁移请求
+type TransitionRuleRequest struct {
+	Status model.RuleStatus `json:"status"`
+}
+
+// TransitionRule POST /mock/rules/{id}/status
+func (c *LifecycleController) TransitionRule(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("TransitionRule Begin")
+	defer c.recoverPanic(b)
+
+	var req TransitionRuleRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	rule, err := c.RuleManageService.TransitionRule(b.Ctx, b.ReadPathParameter("id"), req.Status)
+	if err != nil {
+		logger.Errorf("TransitionRule err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(rule, "application/json")
+}
+
+// DeleteRule DELETE /mock/rules/{id}?purge=true，默认归档，purge 时物理删除已归档规则
+func (c *LifecycleController) DeleteRule(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("DeleteRule Begin")
+	defer c.recoverPanic(b)
+
+	ruleID := b.ReadPathParameter("id")
+	if purge, _ := strconv.ParseBool(b.ReadQueryParameter("purge")); purge {
+		if err := c.RuleManageService.PurgeRule(b.Ctx, ruleID); err != nil {
+			logger.Errorf("PurgeRule err: %v", err)
+			writeError(b, err.Error())
+			return
+		}
+		writeSuccess(b)
+		return
+	}
+
+	rule, err := c.RuleManageService.ArchiveRule(b.Ctx, ruleID)
+	if err != nil {
+		logger.Errorf("ArchiveRule err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(rule, "application/json")
+}
+
+// RestoreRule POST /mock/rules/{id}/restore
+func (c *LifecycleController) RestoreRule(b *rf.Context) {
+	logger := utils.GetLogger()
+	logger.Info("RestoreRule Begin")
+	defer c.recoverPanic(b)
+
+	rule, err := c.RuleManageService.RestoreRule(b.Ctx, b.ReadPathParameter("id"))
+	if err != nil {
+		logger.Errorf("RestoreRule err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+	b.WriteJSON(rule, "application/json")
+}
+
+func (c *LifecycleController) recoverPanic(b *rf.Context) {
+	if err := recover(); err != nil {
+		utils.GetLogger().WithFields(map[string]interface{}{
+			"panic": err,
+			"stack": string(debug.Stack()),
+		}).Error("handle request panic")
+		writeError(b, "Internal server error")
+	}
+}
+
+func (c *LifecycleController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "POST", Path: "/mock/rules/{id}/status", ResourceFunc: c.TransitionRule,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "DELETE", Path: "/mock/rules/{id}", ResourceFunc: c.DeleteRule,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/rules/{id}/restore", ResourceFunc: c.RestoreRule,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
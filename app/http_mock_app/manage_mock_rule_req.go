@@ -10,10 +10,14 @@ import (
 
 type CreateMockRuleRequest struct {
 	Name     string         `json:"name" validate:"required,min=1,max=50"`
-	Protocol string         `json:"protocol" validate:"required,oneof=http grpc"`
+	Protocol string         `json:"protocol" validate:"required,oneof=http https grpc websocket"`
 	Match    MatchConfigDTO `json:"match" validate:"required"`
 	Action   ActionDTO      `json:"action" validate:"required"`
 	Priority int            `json:"priority" validate:"min=0"`
+	// 生效时间窗口（Unix 秒），ttl 为存活秒数，保存时换算为 activeUntil
+	ActiveFrom  int64 `json:"activeFrom,omitempty" validate:"min=0"`
+	ActiveUntil int64 `json:"activeUntil,omitempty" validate:"min=0"`
+	TTL         int64 `json:"ttl,omitempty" validate:"min=0"`
 }
 
 // Validate performs validation on CreateMockRuleRequest
@@ -33,6 +37,46 @@ func (req *CreateMockRuleRequest) Validate() error {
 		if err := validate.Struct(responseAction); err != nil {
 			return fmt.Errorf("invalid response action config: %w", err)
 		}
+		if err := responseAction.validateStatusCode(req.Protocol); err != nil {
+			return fmt.Errorf("invalid response action config: %w", err)
+		}
+	case "sequence":
+		var sequenceAction SequenceActionDTO
+		if err := json.Unmarshal(req.Action.Config, &sequenceAction); err != nil {
+			return fmt.Errorf("invalid sequence action config: %w", err)
+		}
+		if err := validate.Struct(sequenceAction); err != nil {
+			return fmt.Errorf("invalid sequence action config: %w", err)
+		}
+		for i := range sequenceAction.Responses {
+			if err := sequenceAction.Responses[i].validateStatusCode(req.Protocol); err != nil {
+				return fmt.Errorf("invalid sequence action config: responses[%d]: %w", i, err)
+			}
+		}
+	case "stream":
+		var streamAction StreamActionDTO
+		if err := json.Unmarshal(req.Action.Config, &streamAction); err != nil {
+			return fmt.Errorf("invalid stream action config: %w", err)
+		}
+		if err := validate.Struct(streamAction); err != nil {
+			return fmt.Errorf("invalid stream action config: %w", err)
+		}
+	case "websocket":
+		var websocketAction WebSocketActionDTO
+		if err := json.Unmarshal(req.Action.Config, &websocketAction); err != nil {
+			return fmt.Errorf("invalid websocket action config: %w", err)
+		}
+		if err := validate.Struct(websocketAction); err != nil {
+			return fmt.Errorf("invalid websocket action config: %w", err)
+		}
+	case "chunked":
+		var chunkedAction ChunkedActionDTO
+		if err := json.Unmarshal(req.Action.Config, &chunkedAction); err != nil {
+			return fmt.Errorf("invalid chunked action config: %w", err)
+		}
+		if err := validate.Struct(chunkedAction); err != nil {
+			return fmt.Errorf("invalid chunked action config: %w", err)
+		}
 	case "forward":
 		var forwardAction ForwardActionDTO
 		if err := json.Unmarshal(req.Action.Config, &forwardAction); err != nil {
@@ -52,25 +96,121 @@ type MatchConfigDTO struct {
 }
 
 type MatchConditionDTO struct {
-	Type     string         `json:"type" validate:"required,oneof=method path header body_json"`
-	Operator string         `json:"operator" validate:"required,oneof=eq regex exists json_path"`
+	Type     string         `json:"type" validate:"required,oneof=method path header query_param body_json body_raw stream_message sni"`
+	Operator string         `json:"operator" validate:"required,oneof=eq regex exists contains json_path"`
 	Key      any            `json:"key,omitempty"`
 	Value    any            `json:"value"`
 	Config   map[string]any `json:"config,omitempty"`
 }
 
 type ActionDTO struct {
-	Type   string          `json:"type" validate:"required,oneof=response forward error"`
+	Type   string          `json:"type" validate:"required,oneof=response forward error sequence stream websocket chunked"`
 	Config json.RawMessage `json:"config" validate:"required"`
 }
 
 type ResponseActionDTO struct {
-	StatusCode   int               `json:"statusCode" validate:"required,min=100,max=599"`
+	StatusCode   int               `json:"statusCode" validate:"omitempty,min=100,max=599"` // grpc 规则或设置了 grpcStatus 时可省略
 	Headers      map[string]string `json:"headers,omitempty"`
 	Body         string            `json:"body,omitempty"`
 	BodyBase64   string            `json:"bodyBase64,omitempty"`
 	Template     bool              `json:"template,omitempty"`
 	TemplateData map[string]any    `json:"templateData,omitempty"`
+	// gRPC 状态与 trailer，仅 grpc 规则使用，内容在规则校验时按协议检查
+	GRPCStatus json.RawMessage   `json:"grpcStatus,omitempty"`
+	Trailers   map[string]string `json:"trailers,omitempty"`
+}
+
+// validateStatusCode 只有 grpc 规则或设置了 grpcStatus 的响应可以省略 statusCode
+func (r *ResponseActionDTO) validateStatusCode(protocol string) error {
+	if r.StatusCode == 0 && protocol != string(model.ProtocolGRPC) && len(r.GRPCStatus) == 0 {
+		return fmt.Errorf("statusCode is required for %s rules", protocol)
+	}
+	return nil
+}
+
+type SequenceActionDTO struct {
+	Responses []ResponseActionDTO `json:"responses" validate:"required,min=1,dive"`
+	Loop      bool                `json:"loop,omitempty"`
+}
+
+// StreamActionDTO gRPC 流式响应：messages 依次发送，replies 为 bidi streaming 中每条入站消息的回复
+type StreamActionDTO struct {
+	Headers      map[string]string  `json:"headers,omitempty"`
+	Messages     []StreamMessageDTO `json:"messages,omitempty" validate:"required_without=Replies,excluded_with=Replies,dive"`
+	Replies      []StreamReplyDTO   `json:"replies,omitempty" validate:"dive"`
+	Loop         bool               `json:"loop,omitempty"`
+	TemplateData map[string]any     `json:"templateData,omitempty"`
+}
+
+type StreamMessageDTO struct {
+	Body     string `json:"body,omitempty"`
+	Template bool   `json:"template,omitempty"`
+	Delay    int64  `json:"delay,omitempty" validate:"min=0"` // 纳秒，与 ResponseAction.delay 一致
+}
+
+type StreamReplyDTO struct {
+	Messages []StreamMessageDTO `json:"messages" validate:"dive"`
+}
+
+// WebSocketActionDTO WebSocket 脚本：onConnect 连接后发送，replies 按顺序匹配入站帧，pushes 周期推送，close 定时关闭
+type WebSocketActionDTO struct {
+	Headers      map[string]string     `json:"headers,omitempty"`
+	OnConnect    []WebSocketMessageDTO `json:"onConnect,omitempty" validate:"dive"`
+	Replies      []WebSocketReplyDTO   `json:"replies,omitempty" validate:"dive"`
+	Pushes       []WebSocketPushDTO    `json:"pushes,omitempty" validate:"dive"`
+	Close        *WebSocketCloseDTO    `json:"close,omitempty"`
+	TemplateData map[string]any        `json:"templateData,omitempty"`
+}
+
+type WebSocketMessageDTO struct {
+	Type     string `json:"type,omitempty" validate:"omitempty,oneof=text json binary"`
+	Body     string `json:"body,omitempty"`
+	Template bool   `json:"template,omitempty"`
+	Delay    int64  `json:"delay,omitempty" validate:"min=0"` // 纳秒
+}
+
+type WebSocketReplyDTO struct {
+	FrameType string                `json:"frameType,omitempty" validate:"omitempty,oneof=text binary"`
+	Match     *MatchConfigDTO       `json:"match,omitempty"`
+	Messages  []WebSocketMessageDTO `json:"messages,omitempty" validate:"dive"`
+	Close     *WebSocketCloseDTO    `json:"close,omitempty"`
+}
+
+type WebSocketPushDTO struct {
+	Message  WebSocketMessageDTO `json:"message" validate:"required"`
+	Interval int64               `json:"interval" validate:"gt=0"` // 纳秒
+	Count    int                 `json:"count,omitempty" validate:"min=0"`
+}
+
+type WebSocketCloseDTO struct {
+	Code   int    `json:"code,omitempty" validate:"omitempty,min=1000,max=4999"`
+	Reason string `json:"reason,omitempty" validate:"max=123"`
+	Delay  int64  `json:"delay,omitempty" validate:"min=0"` // 纳秒
+}
+
+// ChunkedActionDTO 分块流式响应：mode 为 sse 时每个 chunk 是一个事件，raw 时原样写出
+type ChunkedActionDTO struct {
+	StatusCode   int                `json:"statusCode,omitempty" validate:"omitempty,min=100,max=599"`
+	Headers      map[string]string  `json:"headers,omitempty"`
+	Mode         string             `json:"mode,omitempty" validate:"omitempty,oneof=sse raw"`
+	Chunks       []ResponseChunkDTO `json:"chunks" validate:"required_without=Heartbeat,dive"`
+	Heartbeat    *HeartbeatDTO      `json:"heartbeat,omitempty"`
+	TemplateData map[string]any     `json:"templateData,omitempty"`
+}
+
+type ResponseChunkDTO struct {
+	ID       string `json:"id,omitempty"`
+	Event    string `json:"event,omitempty"`
+	Data     string `json:"data"`
+	Retry    int    `json:"retry,omitempty" validate:"min=0"` // 毫秒
+	Template bool   `json:"template,omitempty"`
+	Delay    int64  `json:"delay,omitempty" validate:"min=0"` // 纳秒
+}
+
+type HeartbeatDTO struct {
+	Interval int64  `json:"interval" validate:"gt=0"` // 纳秒
+	Data     string `json:"data,omitempty"`
+	Count    int    `json:"count,omitempty" validate:"min=0"`
 }
 
 type ForwardActionDTO struct {
@@ -96,16 +236,14 @@ func (dto *CreateMockRuleRequest) ConvertToMockRule() (*model.MockRule, error) {
 	}
 
 	// Convert ActionConfig
-	actionConfig, err := json.Marshal(dto.Action)
-	if dto.Action.Type == "response" {
-		var responseAction ResponseActionDTO
-		if err := json.Unmarshal(dto.Action.Config, &responseAction); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal response action config: %w", err)
-		}
-	}
+	actionJSON, err := json.Marshal(dto.Action)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal action config: %w", err)
 	}
+	var actionConfig model.ActionConfigWrapper
+	if err := actionConfig.UnmarshalJSON(actionJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal action config: %w", err)
+	}
 
 	return &model.MockRule{
 		Name:         dto.Name,
@@ -113,6 +251,9 @@ func (dto *CreateMockRuleRequest) ConvertToMockRule() (*model.MockRule, error) {
 		MatchConfig:  matchConfig,
 		ActionConfig: actionConfig,
 		Priority:     dto.Priority,
+		ActiveFrom:   dto.ActiveFrom,
+		ActiveUntil:  dto.ActiveUntil,
+		TTL:          dto.TTL,
 		Status:       model.RuleStatusActive, // Set default status
 		Version:      1,                      // Set initial version
 	}, nil
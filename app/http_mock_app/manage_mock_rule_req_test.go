@@ -0,0 +1,30 @@
+package http_mock_app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMockRuleRequestStatusCode(t *testing.T) {
+	newReq := func(protocol, config string) *CreateMockRuleRequest {
+		return &CreateMockRuleRequest{
+			Name:     "users",
+			Protocol: protocol,
+			Match: MatchConfigDTO{Logical: "AND", Conditions: []MatchConditionDTO{
+				{Type: "path", Operator: "eq", Value: "/users"},
+			}},
+			Action: ActionDTO{Type: "response", Config: json.RawMessage(config)},
+		}
+	}
+
+	assert.NoError(t, newReq("grpc", `{"body": "{}"}`).Validate())
+	assert.NoError(t, newReq("http", `{"grpcStatus": {"code": 5}}`).Validate(), "left to rule validation")
+	assert.ErrorContains(t, newReq("http", `{"body": "ok"}`).Validate(), "statusCode is required")
+	assert.Error(t, newReq("grpc", `{"statusCode": 42}`).Validate())
+
+	seq := newReq("http", `{"responses": [{"statusCode": 200}, {"body": "x"}]}`)
+	seq.Action.Type = "sequence"
+	assert.ErrorContains(t, seq.Validate(), "responses[1]")
+}
@@ -1 +1,128 @@
 package http_mock_app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/utils"
+)
+
+// MockHandler HTTP mock 数据面：按请求匹配规则并写出规则动作产生的响应，
+// 分块响应（SSE / chunked）逐块写出并立即 flush
+type MockHandler struct {
+	matchService iface.RuleMatchService
+}
+
+func NewMockHandler(matchService iface.RuleMatchService) *MockHandler {
+	return &MockHandler{matchService: matchService}
+}
+
+func (h *MockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLogger()
+	reqInfo := model.NewHTTPRequest(r)
+	rule, err := h.matchService.MatchRule(r.Context(), reqInfo)
+	if err != nil {
+		logger.Errorf("http match rule err: %v", err)
+		writeMockError(w, http.StatusInternalServerError, "failed to match rule")
+		return
+	}
+	if rule == nil {
+		writeMockError(w, http.StatusNotFound, fmt.Sprintf("no mock rule matched %s %s", r.Method, r.URL.Path))
+		return
+	}
+
+	resp, err := h.matchService.ExecuteRuleAction(r.Context(), rule, reqInfo)
+	if err == nil {
+		err = resp.GetError()
+	}
+	if err != nil {
+		logger.Errorf("http execute rule %s err: %v", rule.ID, err)
+		writeMockError(w, http.StatusInternalServerError, fmt.Sprintf("failed to execute rule %s", rule.ID))
+		return
+	}
+	writeMockResponse(r.Context(), w, resp)
+}
+
+// writeMockResponse 等待响应延迟后写出，客户端断开时停止
+func writeMockResponse(ctx context.Context, w http.ResponseWriter, resp model.ResponseInfo) {
+	if !waitFor(ctx, resp.GetDelay()) {
+		return
+	}
+	for k, v := range resp.GetHeaders() {
+		w.Header().Set(k, v)
+	}
+
+	cr, ok := resp.(model.ChunkedResponseInfo)
+	if !ok {
+		w.WriteHeader(resp.GetStatus())
+		_, _ = w.Write(resp.GetBody())
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeMockError(w, http.StatusInternalServerError, "streaming is not supported by the connection")
+		return
+	}
+	// 分块响应长度未知，由 chunked transfer encoding 分隔
+	w.Header().Del("Content-Length")
+	w.WriteHeader(resp.GetStatus())
+	flusher.Flush()
+
+	write := func(data []byte) bool {
+		if _, err := w.Write(data); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+	for _, c := range cr.GetChunks() {
+		if !waitFor(ctx, c.Delay) || !write(c.Data) {
+			return
+		}
+	}
+
+	hb := cr.GetHeartbeat()
+	if hb == nil || hb.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(hb.Interval)
+	defer ticker.Stop()
+	for sent := 0; hb.Count == 0 || sent < hb.Count; sent++ {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if !write(hb.Data) {
+			return
+		}
+	}
+}
+
+// waitFor 等待延迟，客户端断开时返回 false
+func waitFor(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func writeMockError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{Error: msg})
+}
@@ -0,0 +1,131 @@
+package http_mock_app
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/domain/services"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHTTPRule(t *testing.T, id, path string, aType model.ActionType, action model.Action) *model.MockRule {
+	rule := &model.MockRule{
+		ID:       id,
+		Name:     id,
+		Protocol: "http",
+		Status:   model.RuleStatusActive,
+		MatchConfig: model.MatchConfig{Logical: "AND", Conditions: []model.MatchCondition{
+			{Type: "method", Operator: "eq", Value: "GET"},
+			{Type: "path", Operator: "eq", Value: path},
+		}},
+		ActionConfig: model.ActionConfigWrapper{AType: aType, Config: action},
+	}
+	require.NoError(t, rule.Validate())
+	return rule
+}
+
+func TestMockHandler(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	for _, rule := range []*model.MockRule{
+		newHTTPRule(t, "plain", "/plain", model.ActionTypeResponse, &model.ResponseAction{StatusCode: 202, Body: "ok"}),
+		newHTTPRule(t, "sse", "/events", model.ActionTypeChunked, &model.ChunkedAction{
+			Chunks: []*model.ResponseChunk{
+				{ID: "1", Event: "greeting", Data: "hello"},
+				{ID: "2", Data: "world", Delay: 200 * time.Millisecond},
+			},
+			Heartbeat: &model.Heartbeat{Interval: 10 * time.Millisecond, Count: 2},
+		}),
+		newHTTPRule(t, "tokens", "/tokens", model.ActionTypeChunked, &model.ChunkedAction{
+			Mode:   model.ChunkedRaw,
+			Chunks: []*model.ResponseChunk{{Data: "Hel"}, {Data: "lo", Delay: 10 * time.Millisecond}},
+		}),
+		newHTTPRule(t, "endless", "/endless", model.ActionTypeChunked, &model.ChunkedAction{
+			Heartbeat: &model.Heartbeat{Interval: 5 * time.Millisecond},
+		}),
+	} {
+		require.NoError(t, ruleRepo.SaveRule(ctx, rule))
+	}
+	ts := httptest.NewServer(NewMockHandler(services.NewRuleMatchService(ruleRepo)))
+	t.Cleanup(ts.Close)
+
+	t.Run("plain response", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/plain")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assert.Equal(t, "ok", string(body))
+	})
+
+	t.Run("no rule", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/missing")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("sse events arrive before the stream ends", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/events")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		// 第一个事件在第二个事件的延迟结束前就能读到
+		reader := bufio.NewReader(resp.Body)
+		start := time.Now()
+		var first []string
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			if line == "\n" {
+				break
+			}
+			first = append(first, line)
+		}
+		assert.Less(t, time.Since(start), 150*time.Millisecond)
+		assert.Equal(t, []string{"id: 1\n", "event: greeting\n", "data: hello\n"}, first)
+
+		rest, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "id: 2\ndata: world\n\n: heartbeat\n\n: heartbeat\n\n", string(rest))
+	})
+
+	t.Run("raw chunks", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/tokens")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, []string{"chunked"}, resp.TransferEncoding)
+		assert.Equal(t, "Hello", string(body))
+	})
+
+	t.Run("infinite heartbeat stops when client disconnects", func(t *testing.T) {
+		reqCtx, cancel := context.WithCancel(ctx)
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, ts.URL+"/endless", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		reader := bufio.NewReader(resp.Body)
+		for i := 0; i < 3; i++ {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(line, ": heartbeat"))
+			_, _ = reader.ReadString('\n')
+		}
+		cancel()
+	})
+}
@@ -0,0 +1,27 @@
+package http_mock_app
+
+import (
+	"go_mock_server/internal/domain/iface"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+)
+
+// SourceController 展示文件规则来源的加载状态（含每个文件的错误）
+type SourceController struct {
+	Source iface.RuleSourceStatusProvider
+}
+
+func NewSourceController(source iface.RuleSourceStatusProvider) *SourceController {
+	return &SourceController{Source: source}
+}
+
+func (c *SourceController) SourceStatus(b *rf.Context) {
+	b.WriteJSON(c.Source.SourceStatus(), "application/json")
+}
+
+func (c *SourceController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "GET", Path: "/mock/source/status", ResourceFunc: c.SourceStatus,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
@@ -0,0 +1,99 @@
+package record_app
+
+import (
+	"runtime/debug"
+
+	"go_mock_server/internal/domain/iface"
+	"go_mock_server/utils"
+
+	rf "github.com/go-chassis/go-chassis/v2/server/restful"
+)
+
+// RecordController 提供录制结果的审核接口
+type RecordController struct {
+	RecordService iface.RecordService
+}
+
+func NewRecordController(recordService iface.RecordService) *RecordController {
+	return &RecordController{RecordService: recordService}
+}
+
+type RecordIDsRequest struct {
+	IDs []string `json:"ids" validate:"required,min=1"`
+}
+
+func (c *RecordController) ListPending(b *rf.Context) {
+	b.WriteJSON(struct {
+		Records any `json:"records"`
+	}{Records: c.RecordService.ListPending()}, "application/json")
+}
+
+func (c *RecordController) Approve(b *rf.Context) {
+	logger := utils.GetLogger()
+	defer recoverPanic(b)
+
+	var req RecordIDsRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	saved, err := c.RecordService.Approve(b.Ctx, req.IDs)
+	if err != nil {
+		logger.Errorf("approve recorded rules err: %v", err)
+		b.WriteJSON(struct {
+			Saved []string `json:"saved"`
+			Error string   `json:"error"`
+		}{Saved: saved, Error: err.Error()}, "application/json")
+		return
+	}
+
+	b.WriteJSON(struct {
+		Saved []string `json:"saved"`
+	}{Saved: saved}, "application/json")
+}
+
+func (c *RecordController) Discard(b *rf.Context) {
+	logger := utils.GetLogger()
+	defer recoverPanic(b)
+
+	var req RecordIDsRequest
+	if err := b.ReadEntity(&req); err != nil {
+		logger.Errorf("read request body err: %v", err)
+		writeError(b, err.Error())
+		return
+	}
+
+	b.WriteJSON(struct {
+		Discarded int `json:"discarded"`
+	}{Discarded: c.RecordService.Discard(req.IDs)}, "application/json")
+}
+
+func recoverPanic(b *rf.Context) {
+	if err := recover(); err != nil {
+		utils.GetLogger().WithFields(map[string]interface{}{
+			"panic": err,
+			"stack": string(debug.Stack()),
+		}).Error("handle request panic")
+		writeError(b, "Internal server error")
+	}
+}
+
+// writeError writes a JSON error body
+func writeError(b *rf.Context, msg string) {
+	b.WriteJSON(struct {
+		Error string `json:"error"`
+	}{Error: msg}, "application/json")
+}
+
+func (c *RecordController) URLPatterns() []rf.Route {
+	return []rf.Route{
+		{Method: "GET", Path: "/mock/record/pending", ResourceFunc: c.ListPending,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/record/approve", ResourceFunc: c.Approve,
+			Returns: []*rf.Returns{{Code: 200}}},
+		{Method: "POST", Path: "/mock/record/discard", ResourceFunc: c.Discard,
+			Returns: []*rf.Returns{{Code: 200}}},
+	}
+}
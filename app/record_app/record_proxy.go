@@ -0,0 +1,168 @@
+package record_app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/utils"
+
+	"github.com/google/martian/v3"
+)
+
+const requestBodyKey = "record.requestBody"
+
+// RecordProxy 录制代理：将流量转发到配置的上游，并把请求/响应转换为规则
+type RecordProxy struct {
+	proxy         *martian.Proxy
+	recordService iface.RecordService
+	upstreams     map[string]*url.URL
+	listenAddr    string
+}
+
+func NewRecordProxy(config *configs.RecordConfig, recordService iface.RecordService) (*RecordProxy, error) {
+	upstreams := make(map[string]*url.URL, len(config.Upstreams))
+	for host, target := range config.Upstreams {
+		u, err := url.Parse(target)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid upstream %q for host %s", target, host)
+		}
+		upstreams[strings.ToLower(host)] = u
+	}
+
+	p := &RecordProxy{
+		proxy:         martian.NewProxy(),
+		recordService: recordService,
+		upstreams:     upstreams,
+		listenAddr:    config.ListenAddr,
+	}
+	p.proxy.SetRequestModifier(martian.RequestModifierFunc(p.modifyRequest))
+	p.proxy.SetResponseModifier(martian.ResponseModifierFunc(p.modifyResponse))
+	return p, nil
+}
+
+// ListenAndServe 在配置的地址上启动代理，阻塞直到代理关闭
+func (p *RecordProxy) ListenAndServe() error {
+	lis, err := net.Listen("tcp", p.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", p.listenAddr, err)
+	}
+	utils.GetLogger().Infof("record proxy listening on %s", p.listenAddr)
+	return p.proxy.Serve(lis)
+}
+
+func (p *RecordProxy) Close() {
+	p.proxy.Close()
+}
+
+// modifyRequest 缓存请求体并将请求改写到对应的上游
+func (p *RecordProxy) modifyRequest(req *http.Request) error {
+	ctx := martian.NewContext(req)
+	if ctx == nil {
+		return nil
+	}
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		ctx.Set(requestBodyKey, body)
+	}
+
+	if upstream, ok := p.lookupUpstream(req.Host); ok {
+		req.URL.Scheme = upstream.Scheme
+		req.URL.Host = upstream.Host
+		req.Host = upstream.Host
+		if upstream.Path != "" && upstream.Path != "/" {
+			req.URL.Path = strings.TrimSuffix(upstream.Path, "/") + req.URL.Path
+		}
+	}
+	return nil
+}
+
+// modifyResponse 捕获响应并交给录制服务
+func (p *RecordProxy) modifyResponse(res *http.Response) error {
+	req := res.Request
+	if req == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	ex := &model.RecordedExchange{
+		Protocol:        string(model.ProtocolHTTP),
+		Host:            req.Host,
+		Method:          req.Method,
+		Path:            req.URL.Path,
+		Query:           req.URL.Query(),
+		RequestHeaders:  flattenHeader(req.Header),
+		StatusCode:      res.StatusCode,
+		ResponseHeaders: flattenHeader(res.Header),
+		ResponseBody:    decodeBody(res.Header.Get("Content-Encoding"), body),
+	}
+	if ctx := martian.NewContext(req); ctx != nil {
+		if v, ok := ctx.Get(requestBodyKey); ok {
+			ex.RequestBody, _ = v.([]byte)
+		}
+	}
+
+	if _, err := p.recordService.Record(context.Background(), ex); err != nil {
+		// 录制失败不影响代理转发
+		utils.GetLogger().Errorf("record %s %s err: %v", ex.Method, ex.Path, err)
+	}
+	return nil
+}
+
+func (p *RecordProxy) lookupUpstream(host string) (*url.URL, bool) {
+	host = strings.ToLower(host)
+	if u, ok := p.upstreams[host]; ok {
+		return u, true
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		u, ok := p.upstreams[h]
+		return u, ok
+	}
+	return nil, false
+}
+
+func flattenHeader(h http.Header) map[string]string {
+	headers := make(map[string]string, len(h))
+	for k, v := range h {
+		headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	return headers
+}
+
+// decodeBody 解压 gzip 响应体，保证生成的规则中保存的是原始内容
+func decodeBody(encoding string, body []byte) []byte {
+	if !strings.EqualFold(encoding, "gzip") {
+		return body
+	}
+	r, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return body
+	}
+	defer r.Close()
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return body
+	}
+	return decoded
+}
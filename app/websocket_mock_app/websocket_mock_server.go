@@ -0,0 +1,309 @@
+package websocket_mock_app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/utils"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait = 5 * time.Second // 单帧写超时
+	closeWait = time.Second     // 发送关闭帧后等待客户端回应的时间
+)
+
+var errSessionClosed = errors.New("websocket session closed")
+
+// WebSocketMockServer WebSocket mock 服务：按握手请求匹配 websocket 规则，连接建立后执行规则中的消息脚本
+// 既可以独立监听，也可以通过 Handles 挂到已有的 HTTP 监听上
+type WebSocketMockServer struct {
+	matchService   iface.RuleMatchService
+	upgrader       websocket.Upgrader
+	maxMessageSize int64
+	listenAddr     string
+	server         *http.Server
+	ctx            context.Context // 服务关闭时取消，所有连接以 1001 关闭
+	cancel         context.CancelFunc
+}
+
+func NewWebSocketMockServer(config *configs.WebSocketMockConfig, matchService iface.RuleMatchService) *WebSocketMockServer {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &WebSocketMockServer{
+		matchService:   matchService,
+		maxMessageSize: config.MaxMessageSize,
+		listenAddr:     config.ListenAddr,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	allowed := make(map[string]bool, len(config.AllowedOrigins))
+	for _, o := range config.AllowedOrigins {
+		allowed[o] = true
+	}
+	s.upgrader = websocket.Upgrader{
+		Subprotocols: config.Subprotocols,
+		CheckOrigin: func(r *http.Request) bool {
+			return len(allowed) == 0 || allowed[r.Header.Get("Origin")]
+		},
+	}
+	s.server = &http.Server{Handler: s}
+	return s
+}
+
+// ListenAndServe 在配置的地址上启动服务，阻塞直到服务关闭
+func (s *WebSocketMockServer) ListenAndServe() error {
+	lis, err := net.Listen("tcp", s.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.listenAddr, err)
+	}
+	utils.GetLogger().Infof("websocket mock server listening on %s", s.listenAddr)
+	return s.Serve(lis)
+}
+
+func (s *WebSocketMockServer) Serve(lis net.Listener) error {
+	err := s.server.Serve(lis)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Close 关闭监听并以 1001 关闭所有连接
+func (s *WebSocketMockServer) Close() {
+	s.cancel()
+	_ = s.server.Close()
+}
+
+// Handles 判断请求是否为 WebSocket 握手请求
+func (s *WebSocketMockServer) Handles(r *http.Request) bool {
+	return websocket.IsWebSocketUpgrade(r)
+}
+
+func (s *WebSocketMockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLogger()
+	if !s.Handles(r) {
+		http.Error(w, "websocket upgrade required", http.StatusUpgradeRequired)
+		return
+	}
+
+	reqInfo := model.NewWebSocketRequest(r)
+	rule, err := s.matchService.MatchRule(r.Context(), reqInfo)
+	if err != nil {
+		logger.Errorf("websocket match rule err: %v", err)
+		http.Error(w, "failed to match rule", http.StatusInternalServerError)
+		return
+	}
+	if rule == nil {
+		http.Error(w, "no mock rule matched "+r.URL.Path, http.StatusNotFound)
+		return
+	}
+
+	resp, err := s.matchService.ExecuteRuleAction(r.Context(), rule, reqInfo)
+	if err == nil {
+		err = resp.GetError()
+	}
+	if err != nil {
+		logger.Errorf("websocket execute rule %s err: %v", rule.ID, err)
+		http.Error(w, fmt.Sprintf("failed to execute rule %s", rule.ID), http.StatusInternalServerError)
+		return
+	}
+	script, ok := resp.(model.WebSocketResponseInfo)
+	if !ok {
+		http.Error(w, fmt.Sprintf("rule %s is not a websocket action", rule.ID), http.StatusInternalServerError)
+		return
+	}
+
+	header := http.Header{}
+	for k, v := range resp.GetHeaders() {
+		header.Set(k, v)
+	}
+	conn, err := s.upgrader.Upgrade(w, r, header)
+	if err != nil {
+		return // Upgrade 已写出错误响应
+	}
+	if s.maxMessageSize > 0 {
+		conn.SetReadLimit(s.maxMessageSize)
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	session := &wsSession{server: s, conn: conn, rule: rule, request: reqInfo, ctx: ctx, cancel: cancel}
+	session.run(script)
+}
+
+// wsSession 一个 WebSocket 连接：读循环中依次执行入站帧的回复，onConnect、周期推送与定时关闭在独立的 goroutine 中执行
+// gorilla/websocket 同一时间只允许一个写者，所有写操作通过 writeMu 串行化
+type wsSession struct {
+	server    *WebSocketMockServer
+	conn      *websocket.Conn
+	rule      *model.MockRule
+	request   *model.WebSocketRequestInfo
+	ctx       context.Context
+	cancel    context.CancelFunc
+	writeMu   sync.Mutex
+	closed    atomic.Bool
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func (s *wsSession) run(script model.WebSocketResponseInfo) {
+	defer s.conn.Close()
+	defer s.wg.Wait()
+	defer s.cancel()
+
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		s.watchClose(script.GetClose())
+	}()
+	go func() {
+		defer s.wg.Done()
+		if !s.sendFrames(script.GetFrames()) {
+			return
+		}
+		for _, p := range script.GetPushes() {
+			s.wg.Add(1)
+			go func(p *model.WebSocketPushFrame) {
+				defer s.wg.Done()
+				s.push(p)
+			}(p)
+		}
+	}()
+	s.readLoop()
+}
+
+// readLoop 读取入站帧并执行规则，客户端关闭或发送关闭帧后的回应超时时返回
+func (s *wsSession) readLoop() {
+	logger := utils.GetLogger()
+	for {
+		messageType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if !s.closed.Load() && !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived) {
+				logger.Debugf("websocket rule %s read err: %v", s.rule.ID, err)
+			}
+			return
+		}
+		if s.closed.Load() {
+			continue // 已发送关闭帧，只等待客户端回应
+		}
+
+		frameType := model.WebSocketText
+		if messageType == websocket.BinaryMessage {
+			frameType = model.WebSocketBinary
+		}
+		resp, err := s.server.matchService.ExecuteRuleAction(s.ctx, s.rule, s.request.WithFrame(frameType, data))
+		if err == nil {
+			err = resp.GetError()
+		}
+		if err != nil {
+			logger.Errorf("websocket execute rule %s err: %v", s.rule.ID, err)
+			s.close(websocket.CloseInternalServerErr, "failed to execute rule")
+			continue
+		}
+		reply, ok := resp.(model.WebSocketResponseInfo)
+		if !ok || !s.sendFrames(reply.GetFrames()) {
+			continue
+		}
+		if c := reply.GetClose(); c != nil && s.wait(c.Delay) {
+			s.close(c.CloseCode(), c.Reason)
+		}
+	}
+}
+
+// watchClose 规则配置了 close 时按延迟关闭连接；服务关闭时以 1001 关闭
+func (s *wsSession) watchClose(c *model.WebSocketClose) {
+	var timeout <-chan time.Time
+	if c != nil {
+		timer := time.NewTimer(c.Delay)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-timeout:
+		s.close(c.CloseCode(), c.Reason)
+	case <-s.ctx.Done():
+		if s.server.ctx.Err() != nil {
+			s.close(websocket.CloseGoingAway, "server shutting down")
+		}
+	}
+}
+
+// push 周期推送，count 为 0 时直到连接关闭
+func (s *wsSession) push(p *model.WebSocketPushFrame) {
+	if p.Interval <= 0 || !s.wait(p.Frame.Delay) {
+		return
+	}
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for sent := 0; p.Count == 0 || sent < p.Count; sent++ {
+		if sent > 0 {
+			select {
+			case <-ticker.C:
+			case <-s.ctx.Done():
+				return
+			}
+		}
+		if s.write(p.Frame) != nil {
+			return
+		}
+	}
+}
+
+// sendFrames 依次等待延迟并发送，连接关闭时返回 false
+func (s *wsSession) sendFrames(frames []*model.WebSocketFrame) bool {
+	for _, f := range frames {
+		if !s.wait(f.Delay) || s.write(f) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *wsSession) write(f *model.WebSocketFrame) error {
+	if s.closed.Load() {
+		return errSessionClosed
+	}
+	messageType := websocket.TextMessage
+	if f.Binary {
+		messageType = websocket.BinaryMessage
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return s.conn.WriteMessage(messageType, f.Data)
+}
+
+// close 发送关闭帧，之后不再发送消息，读循环在客户端回应或超时后结束
+func (s *wsSession) close(code int, reason string) {
+	s.closeOnce.Do(func() {
+		s.closed.Store(true)
+		s.writeMu.Lock()
+		_ = s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+		s.writeMu.Unlock()
+		_ = s.conn.SetReadDeadline(time.Now().Add(closeWait))
+	})
+}
+
+// wait 等待延迟，连接结束时立即返回 false
+func (s *wsSession) wait(delay time.Duration) bool {
+	if delay <= 0 {
+		return s.ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
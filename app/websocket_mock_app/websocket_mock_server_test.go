@@ -0,0 +1,139 @@
+package websocket_mock_app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/domain/services"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebSocketRule(t *testing.T, id, path, action string) *model.MockRule {
+	rule := &model.MockRule{
+		ID:       id,
+		Name:     id,
+		Protocol: string(model.ProtocolWebSocket),
+		Status:   model.RuleStatusActive,
+		MatchConfig: model.MatchConfig{Logical: "AND", Conditions: []model.MatchCondition{
+			{Type: "path", Operator: "eq", Value: path},
+		}},
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"type": "websocket", "config": `+action+`}`), &rule.ActionConfig))
+	require.NoError(t, rule.Validate())
+	return rule
+}
+
+func TestWebSocketMockServer(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	for _, rule := range []*model.MockRule{
+		newWebSocketRule(t, "ws_chat", "/ws/chat", `{
+			"headers": {"X-Mock-Rule": "ws_chat"},
+			"onConnect": [{"body": "welcome"}],
+			"replies": [
+				{"match": {"logical": "AND", "conditions": [{"type": "body_json", "operator": "eq", "key": "$.op", "value": "ping"}]},
+				 "messages": [{"type": "json", "body": "{\"op\":\"pong\",\"id\":\"{{.id}}\"}", "template": true}]},
+				{"frameType": "binary", "messages": [{"type": "binary", "body": "AQID"}]},
+				{"match": {"logical": "AND", "conditions": [{"type": "body_raw", "operator": "eq", "value": "bye"}]},
+				 "messages": [{"body": "see you"}], "close": {"code": 4000, "reason": "done"}}
+			]
+		}`),
+		newWebSocketRule(t, "ws_ticker", "/ws/ticker", `{
+			"pushes": [{"message": {"body": "tick"}, "interval": 5000000, "count": 3}],
+			"close": {"code": 4001, "reason": "expired", "delay": 200000000}
+		}`),
+	} {
+		require.NoError(t, ruleRepo.SaveRule(ctx, rule))
+	}
+
+	server := NewWebSocketMockServer(&configs.WebSocketMockConfig{}, services.NewRuleMatchService(ruleRepo))
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	t.Cleanup(server.Close)
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	dial := func(t *testing.T, path string) *websocket.Conn {
+		conn, resp, err := websocket.DefaultDialer.Dial(wsURL+path, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if path == "/ws/chat" {
+			assert.Equal(t, "ws_chat", resp.Header.Get("X-Mock-Rule"))
+		}
+		return conn
+	}
+	read := func(t *testing.T, conn *websocket.Conn) (int, string) {
+		messageType, data, err := conn.ReadMessage()
+		require.NoError(t, err)
+		return messageType, string(data)
+	}
+
+	t.Run("scripted exchange", func(t *testing.T) {
+		conn := dial(t, "/ws/chat")
+		_, msg := read(t, conn)
+		assert.Equal(t, "welcome", msg)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"op": "ping", "id": "42"}`)))
+		messageType, msg := read(t, conn)
+		assert.Equal(t, websocket.TextMessage, messageType)
+		assert.JSONEq(t, `{"op": "pong", "id": "42"}`, msg)
+
+		require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, []byte{9}))
+		messageType, msg = read(t, conn)
+		assert.Equal(t, websocket.BinaryMessage, messageType)
+		assert.Equal(t, string([]byte{1, 2, 3}), msg)
+
+		// 没有匹配的回复时不回复，下一条消息仍然正常处理
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("unknown")))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("bye")))
+		_, msg = read(t, conn)
+		assert.Equal(t, "see you", msg)
+
+		_, _, err := conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, 4000), "unexpected error: %v", err)
+		assert.Contains(t, err.Error(), "done")
+	})
+
+	t.Run("periodic pushes and scheduled close", func(t *testing.T) {
+		conn := dial(t, "/ws/ticker")
+		for i := 0; i < 3; i++ {
+			_, msg := read(t, conn)
+			assert.Equal(t, "tick", msg)
+		}
+		_, _, err := conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, 4001), "unexpected error: %v", err)
+	})
+
+	t.Run("no matching rule", func(t *testing.T) {
+		_, resp, err := websocket.DefaultDialer.Dial(wsURL+"/ws/missing", nil)
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("server shutdown", func(t *testing.T) {
+		conn := dial(t, "/ws/chat")
+		_, msg := read(t, conn)
+		assert.Equal(t, "welcome", msg)
+		server.cancel()
+		_, _, err := conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "unexpected error: %v", err)
+	})
+}
+
+func TestPushIgnoresNonPositiveInterval(t *testing.T) {
+	s := &wsSession{}
+	assert.NotPanics(t, func() { s.push(&model.WebSocketPushFrame{Frame: &model.WebSocketFrame{}}) })
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/infra/converter"
+)
+
+// runHAR 将 HAR 文件转换为规则 JSON 数组
+func runHAR(args []string) error {
+	fs := flag.NewFlagSet("har", flag.ExitOnError)
+	input := fs.String("f", "", "HAR file path (required)")
+	output := fs.String("o", "", "output file path, default stdout")
+	query := fs.String("query", "", "comma separated query params to match, default all")
+	headers := fs.String("headers", "", "comma separated request headers to match")
+	hosts := fs.String("hosts", "", "comma separated hosts to import, default all")
+	tag := fs.String("tag", "", "tag for imported rules")
+	priority := fs.Int("priority", 0, "base priority of imported rules")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *input == "" {
+		fs.Usage()
+		return errors.New("-f is required")
+	}
+
+	data, err := os.ReadFile(*input)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", *input, err)
+	}
+
+	rules, err := converter.FromHAR(data, model.HARImportOptions{
+		MatchQuery:   splitList(*query),
+		MatchHeaders: splitList(*headers),
+		Hosts:        splitList(*hosts),
+		Tag:          *tag,
+		Priority:     *priority,
+	})
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid rule %s: %w", rule.Name, err)
+		}
+	}
+
+	out, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rules: %w", err)
+	}
+	if *output == "" {
+		_, err = fmt.Println(string(out))
+		return err
+	}
+	if err := os.WriteFile(*output, out, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", *output, err)
+	}
+	fmt.Fprintf(os.Stderr, "wrote %d rules to %s\n", len(rules), *output)
+	return nil
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	result := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
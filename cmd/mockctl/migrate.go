@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"go_mock_server/internal/infra/migration"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// runMigrate 执行数据库结构迁移，或查看迁移状态
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	driver := fs.String("driver", "mysql", "database driver: mysql or sqlite")
+	dsn := fs.String("dsn", "", "mysql dsn, e.g. user:pass@tcp(host:3306)/db?parseTime=True, or sqlite file path")
+	status := fs.Bool("status", false, "print migration status without applying")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dsn == "" {
+		fs.Usage()
+		return errors.New("-dsn is required")
+	}
+
+	var dialector gorm.Dialector
+	switch *driver {
+	case "mysql":
+		dialector = mysql.Open(*dsn)
+	case "sqlite":
+		dialector = sqlite.Open(*dsn)
+	default:
+		return fmt.Errorf("unknown driver %q", *driver)
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Warn)})
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+
+	ctx := context.Background()
+	if !*status {
+		versions, err := migration.Run(ctx, db)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		for _, v := range versions {
+			fmt.Printf("applied %d\n", v)
+		}
+		return nil
+	}
+
+	list, err := migration.Status(ctx, db)
+	if err != nil {
+		return err
+	}
+	for _, s := range list {
+		applied := "pending"
+		if s.Applied {
+			applied = "applied " + time.Unix(s.AppliedAt, 0).Format(time.RFC3339)
+		}
+		fmt.Printf("%4d  %-32s %s\n", s.Version, s.Name, applied)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runReconcile 调用服务端接口检查并修复规则缓存与索引
+func runReconcile(args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	server := fs.String("server", "http://127.0.0.1:8080", "mock server admin address")
+	dryRun := fs.Bool("dry-run", false, "only report inconsistencies without repairing")
+	timeout := fs.Duration("timeout", time.Minute, "request timeout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	u := strings.TrimRight(*server, "/") + "/mock/index/reconcile?" + url.Values{"dryRun": {strconv.FormatBool(*dryRun)}}.Encode()
+	client := &http.Client{Timeout: *timeout}
+	resp, err := client.Post(u, "application/json", nil)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned %s: %s", resp.Status, body)
+	}
+	_, err = fmt.Println(string(body))
+	return err
+}
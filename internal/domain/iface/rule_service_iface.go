@@ -3,17 +3,123 @@ package iface
 import (
 	"context"
 	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
 // RuleService 规则服务接口
 type RuleService interface {
 	// CreateRule 创建规则
 	CreateRule(context context.Context, rule *model.MockRule) error
+	// TransitionRule 按生命周期迁移规则状态
+	TransitionRule(ctx context.Context, ruleID string, to model.RuleStatus) (*model.MockRule, error)
+	// ArchiveRule 软删除（归档）规则
+	ArchiveRule(ctx context.Context, ruleID string) (*model.MockRule, error)
+	// RestoreRule 恢复已归档规则
+	RestoreRule(ctx context.Context, ruleID string) (*model.MockRule, error)
+	// PurgeRule 物理删除已归档规则
+	PurgeRule(ctx context.Context, ruleID string) error
 }
 
 type RuleMatchService interface {
 	// MatchRule 匹配规则
 	MatchRule(ctx context.Context, reqInfo model.RequestInfo) (*model.MockRule, error)
 	// ExecuteRuleAction 执行规则动作
-	ExecuteRuleAction(ctx context.Context, rule *model.MockRule) (model.ResponseInfo, error)
+	ExecuteRuleAction(ctx context.Context, rule *model.MockRule, reqInfo model.RequestInfo) (model.ResponseInfo, error)
+	// DryRunMatch 评估请求但不产生副作用
+	DryRunMatch(ctx context.Context, reqInfo model.RequestInfo) (*model.DryRunResult, error)
+}
+
+// RecordService 录制服务接口
+type RecordService interface {
+	// Record 处理一次录制的请求/响应
+	Record(ctx context.Context, ex *model.RecordedExchange) (*model.RecordedRule, error)
+	// ListPending 列出待审核的录制结果
+	ListPending() []*model.RecordedRule
+	// Approve 保存待审核的录制结果
+	Approve(ctx context.Context, ids []string) ([]string, error)
+	// Discard 丢弃待审核的录制结果
+	Discard(ids []string) int
+}
+
+// RuleImportService 规则导入服务接口
+type RuleImportService interface {
+	// ImportOpenAPI 从 OpenAPI 3 文档导入规则
+	ImportOpenAPI(ctx context.Context, data []byte, opts model.OpenAPIImportOptions) (*model.ImportResult, error)
+	// ImportHAR 从 HAR 文件导入规则
+	ImportHAR(ctx context.Context, data []byte, opts model.HARImportOptions) (*model.ImportResult, error)
+	// ImportWireMock 从 WireMock stub mappings 导入规则
+	ImportWireMock(ctx context.Context, data []byte) (*model.ImportResult, error)
+	// ImportPostman 从 Postman collection 导入规则
+	ImportPostman(ctx context.Context, data []byte) (*model.ImportResult, error)
+}
+
+// RuleExportService 规则导出服务接口
+type RuleExportService interface {
+	// ExportWireMock 导出为 WireMock stub mappings
+	ExportWireMock(ctx context.Context, filter *model.RuleFilter) (any, *model.ConversionReport, error)
+	// ExportPostman 导出为 Postman collection
+	ExportPostman(ctx context.Context, name string, filter *model.RuleFilter) (any, *model.ConversionReport, error)
+}
+
+// RuleBundleService 规则集快照服务接口
+type RuleBundleService interface {
+	// ExportBundle 导出规则快照
+	ExportBundle(ctx context.Context, filter *model.RuleFilter) (*model.RuleBundle, error)
+	// ImportBundle 按 merge / replace 模式导入规则快照，dryRun 时只预览该模式的导入计划
+	ImportBundle(ctx context.Context, bundle *model.RuleBundle, mode model.BundleImportMode, dryRun bool) (*model.BundleImportResult, error)
+}
+
+// RuleSourceStatusProvider 提供规则来源（如文件目录）的加载状态
+type RuleSourceStatusProvider interface {
+	SourceStatus() *model.RuleSourceStatus
+}
+
+// IndexReconciler 规则缓存与索引一致性检查
+type IndexReconciler interface {
+	// Reconcile 以数据库为准修复缓存与索引，dryRun 时只生成报告
+	Reconcile(ctx context.Context, dryRun bool) (*model.IndexReconcileReport, error)
+}
+
+// ChangeSetService 草稿变更集服务接口
+type ChangeSetService interface {
+	CreateChangeSet(ctx context.Context, name, description string) (*model.ChangeSet, error)
+	GetChangeSet(ctx context.Context, id string) (*model.ChangeSet, error)
+	ListChangeSets(ctx context.Context, status model.ChangeSetStatus) ([]*model.ChangeSet, error)
+	// StageRule 暂存新增或修改的规则，发布后状态为 target
+	StageRule(ctx context.Context, changeSetID string, rule *model.MockRule, target model.RuleStatus) (*model.ChangeSetItem, error)
+	// StageDelete 暂存规则删除
+	StageDelete(ctx context.Context, changeSetID, ruleID string) (*model.ChangeSetItem, error)
+	// Unstage 撤销暂存的变更
+	Unstage(ctx context.Context, changeSetID, ruleID string) error
+	// Preview 对比样例请求在发布前后命中的规则
+	Preview(ctx context.Context, changeSetID string, samples []model.RequestInfo) (*model.ChangeSetPreview, error)
+	// Publish 原子发布变更集
+	Publish(ctx context.Context, changeSetID string) (*model.ChangeSet, error)
+	// Discard 丢弃变更集
+	Discard(ctx context.Context, changeSetID string) error
+	// RuleHistory 规则变更历史
+	RuleHistory(ctx context.Context, ruleID string) ([]*model.RuleHistory, error)
+}
+
+// DescriptorService gRPC mock 描述符管理服务接口
+type DescriptorService interface {
+	// UploadDescriptorSet 上传二进制 FileDescriptorSet，同名时生成新版本
+	UploadDescriptorSet(ctx context.Context, name string, data []byte) (*model.ProtoDescriptorSet, error)
+	// UploadProtoFiles 编译并上传 .proto 源文件
+	UploadProtoFiles(ctx context.Context, name string, files map[string]string) (*model.ProtoDescriptorSet, error)
+	ListDescriptors(ctx context.Context, name string) ([]*model.ProtoDescriptorSet, error)
+	GetDescriptor(ctx context.Context, name string, version int) (*model.ProtoDescriptorSet, error)
+	DeleteDescriptor(ctx context.Context, name string, version int) (int64, error)
+	// LoadDescriptorSetFiles 加载本地 FileDescriptorSet 文件
+	LoadDescriptorSetFiles(paths []string) error
+	// FindMethod 按 gRPC 完整方法名查找方法描述符
+	FindMethod(fullMethod string) (protoreflect.MethodDescriptor, error)
+	// Services 当前生效的服务及其方法名
+	Services() map[string][]string
+	// Files 当前生效的描述符注册表快照
+	Files() *protoregistry.Files
+	// ValidateResponse 校验规则响应是否符合方法输出类型
+	ValidateResponse(ctx context.Context, rule *model.MockRule) error
 }
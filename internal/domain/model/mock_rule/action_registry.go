@@ -9,4 +9,8 @@ func RegisterConfig(t ActionType, factory func() Action) {
 // 初始化时注册
 func init() {
 	RegisterConfig(ActionTypeResponse, func() Action { return &ResponseAction{} })
+	RegisterConfig(ActionTypeSequence, func() Action { return &SequenceAction{} })
+	RegisterConfig(ActionTypeStream, func() Action { return &StreamAction{} })
+	RegisterConfig(ActionTypeWebSocket, func() Action { return &WebSocketAction{} })
+	RegisterConfig(ActionTypeChunked, func() Action { return &ChunkedAction{} })
 }
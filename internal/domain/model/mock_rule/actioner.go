@@ -17,9 +17,13 @@ import (
 type ActionType string
 
 const (
-	ActionTypeResponse ActionType = "response" // 返回响应
-	ActionTypeForward  ActionType = "forward"  // 转发请求 (未来扩展)
-	ActionTypeError    ActionType = "error"    // 返回错误 (未来扩展)
+	ActionTypeResponse  ActionType = "response"  // 返回响应
+	ActionTypeForward   ActionType = "forward"   // 转发请求 (未来扩展)
+	ActionTypeError     ActionType = "error"     // 返回错误 (未来扩展)
+	ActionTypeSequence  ActionType = "sequence"  // 按调用次数依次返回
+	ActionTypeStream    ActionType = "stream"    // 流式响应 (gRPC streaming)
+	ActionTypeWebSocket ActionType = "websocket" // WebSocket 消息脚本
+	ActionTypeChunked   ActionType = "chunked"   // 分块流式响应 (SSE / chunked)
 )
 
 type Protocol string
@@ -46,6 +50,9 @@ type ActionConfigWrapper struct {
 // 实现 GORM 的 Scanner/Valuer 接口
 func (w *ActionConfigWrapper) Scan(value interface{}) error {
 	// 处理数据库读取
+	if str, ok := value.(string); ok {
+		return w.UnmarshalJSON([]byte(str))
+	}
 	bytes, _ := value.([]byte)
 	return w.UnmarshalJSON(bytes)
 }
@@ -87,6 +94,30 @@ func (w *ActionConfigWrapper) UnmarshalJSON(data []byte) error {
 			return err
 		}
 		w.Config = &cfg
+	case ActionTypeSequence:
+		var cfg SequenceAction
+		if err := json.Unmarshal(temp.Config, &cfg); err != nil {
+			return err
+		}
+		w.Config = &cfg
+	case ActionTypeStream:
+		var cfg StreamAction
+		if err := json.Unmarshal(temp.Config, &cfg); err != nil {
+			return err
+		}
+		w.Config = &cfg
+	case ActionTypeWebSocket:
+		var cfg WebSocketAction
+		if err := json.Unmarshal(temp.Config, &cfg); err != nil {
+			return err
+		}
+		w.Config = &cfg
+	case ActionTypeChunked:
+		var cfg ChunkedAction
+		if err := json.Unmarshal(temp.Config, &cfg); err != nil {
+			return err
+		}
+		w.Config = &cfg
 	// case ActionProxy:
 	// 	var cfg ProxyConfig
 	// 	if err := json.Unmarshal(temp.Config, &cfg); err != nil {
@@ -116,6 +147,8 @@ type ResponseAction struct {
 	Template     bool                   `json:"template,omitempty"`
 	TemplateData map[string]interface{} `json:"templateData,omitempty"`
 	Delay        time.Duration          `json:"delay,omitempty"`
+	GRPCStatus   *GRPCStatus            `json:"grpcStatus,omitempty"` // gRPC 状态码、消息与错误详情，仅 grpc 规则
+	Trailers     map[string]string      `json:"trailers,omitempty"`   // gRPC trailer，仅 grpc 规则
 }
 
 // 自定义序列化/反序列化逻辑
@@ -188,8 +221,9 @@ func mergeData(base, extra map[string]interface{}) map[string]interface{} {
 	return merged
 }
 
+// Validate statusCode 为 0 表示未设置，是否允许由 ValidateProtocol 按协议判断
 func (r *ResponseAction) Validate() error {
-	if r.StatusCode < 100 || r.StatusCode > 599 {
+	if r.StatusCode != 0 && (r.StatusCode < 100 || r.StatusCode > 599) {
 		return errors.New("invalid status code")
 	}
 	return nil
@@ -198,9 +232,11 @@ func (r *ResponseAction) Validate() error {
 func (r *ResponseAction) Execute(ctx context.Context, req RequestInfo) (ResponseInfo, error) {
 	// 创建基础响应对象
 	resp := &BaseResponse{
-		status:  r.StatusCode,
-		headers: r.Headers,
-		delay:   r.Delay,
+		status:     r.StatusCode,
+		headers:    r.Headers,
+		delay:      r.Delay,
+		grpcStatus: r.GRPCStatus,
+		trailers:   r.Trailers,
 	}
 
 	// 优先级1：二进制数据直接返回
@@ -235,13 +271,17 @@ func (r *ResponseAction) Execute(ctx context.Context, req RequestInfo) (Response
 	return resp, nil
 }
 
+var _ GRPCResponseInfo = (*BaseResponse)(nil)
+
 // 实现 ResponseInfo 接口的具体类型
 type BaseResponse struct {
-	status  int
-	headers map[string]string
-	body    []byte
-	delay   time.Duration
-	err     error
+	status     int
+	headers    map[string]string
+	body       []byte
+	delay      time.Duration
+	err        error
+	grpcStatus *GRPCStatus
+	trailers   map[string]string
 }
 
 // 实现 ResponseInfo 接口方法
@@ -0,0 +1,160 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleBundleVersion 当前 bundle 格式版本，格式不兼容时递增
+const RuleBundleVersion = 1
+
+// RuleBundle 可移植的规则快照，用于在不同环境之间共享规则
+type RuleBundle struct {
+	Version    int         `json:"version"`
+	ExportedAt int64       `json:"exportedAt"`
+	Rules      []*MockRule `json:"rules"`
+}
+
+// BundleImportMode bundle 导入模式
+type BundleImportMode string
+
+const (
+	BundleImportMerge   BundleImportMode = "merge"   // 新增或更新 bundle 中的规则，保留其他规则
+	BundleImportReplace BundleImportMode = "replace" // 以 bundle 为准，删除 bundle 之外的规则
+)
+
+func (m BundleImportMode) IsValid() bool {
+	switch m {
+	case BundleImportMerge, BundleImportReplace:
+		return true
+	}
+	return false
+}
+
+// BundleConflict 按 name+protocol 检测到的冲突
+type BundleConflict struct {
+	Name       string `json:"name"`
+	Protocol   string `json:"protocol"`
+	ExistingID string `json:"existingId"`
+	IncomingID string `json:"incomingId"`
+	Resolution string `json:"resolution"` // skipped / replaced
+}
+
+// BundleImportPlan 导入计划
+type BundleImportPlan struct {
+	Create    []*MockRule      `json:"-"`
+	Update    []*MockRule      `json:"-"`
+	Delete    []*MockRule      `json:"-"` // 将被删除的已有规则
+	Conflicts []BundleConflict `json:"conflicts,omitempty"`
+}
+
+// BundleImportResult bundle 导入结果
+type BundleImportResult struct {
+	Mode      BundleImportMode `json:"mode"`
+	DryRun    bool             `json:"dryRun"` // 只计算 mode 下的导入计划，不做任何修改
+	Applied   bool             `json:"applied"`
+	Created   []string         `json:"created"`
+	Updated   []string         `json:"updated"`
+	Deleted   []string         `json:"deleted"`
+	Conflicts []BundleConflict `json:"conflicts,omitempty"`
+}
+
+// Validate 校验 bundle 并计算规则派生字段
+func (b *RuleBundle) Validate() error {
+	if b.Version == 0 || b.Version > RuleBundleVersion {
+		return fmt.Errorf("unsupported bundle version %d, expected <= %d", b.Version, RuleBundleVersion)
+	}
+	ids := make(map[string]struct{}, len(b.Rules))
+	for i, rule := range b.Rules {
+		if rule == nil {
+			return fmt.Errorf("rules[%d] is empty", i)
+		}
+		if rule.ID == "" {
+			return fmt.Errorf("rules[%d] missing id", i)
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return fmt.Errorf("duplicate rule id %s", rule.ID)
+		}
+		ids[rule.ID] = struct{}{}
+		if rule.ActionConfig.Config == nil {
+			return fmt.Errorf("rule %s: action configuration is missing", rule.ID)
+		}
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+// ruleConflictKey 以 name+protocol 作为规则的业务唯一键
+func ruleConflictKey(rule *MockRule) string {
+	return strings.ToLower(rule.Protocol) + "|" + rule.Name
+}
+
+// PlanBundleImport 对比已有规则和 bundle 规则，得到导入计划
+//
+// 相同 ID 视为更新；ID 不同但 name+protocol 相同视为冲突：
+// merge 模式跳过 bundle 中的规则，replace 模式删除已有规则后写入 bundle 规则。
+// 预览（dry run）与实际导入使用同一个计划。
+func PlanBundleImport(existing []*MockRule, bundle *RuleBundle, mode BundleImportMode) *BundleImportPlan {
+	plan := &BundleImportPlan{}
+	byID := make(map[string]*MockRule, len(existing))
+	byKey := make(map[string]*MockRule, len(existing))
+	for _, rule := range existing {
+		byID[rule.ID] = rule
+		byKey[ruleConflictKey(rule)] = rule
+	}
+
+	kept := make(map[string]struct{}, len(bundle.Rules))
+	for _, rule := range bundle.Rules {
+		if old, ok := byID[rule.ID]; ok {
+			// 更新后的 name+protocol 与另一条已有规则冲突
+			if other, ok := byKey[ruleConflictKey(rule)]; ok && other.ID != rule.ID && mode != BundleImportReplace {
+				plan.Conflicts = append(plan.Conflicts, newBundleConflict(other, rule, "skipped"))
+				kept[old.ID] = struct{}{}
+				continue
+			}
+			plan.Update = append(plan.Update, rule)
+			kept[rule.ID] = struct{}{}
+			continue
+		}
+
+		if other, ok := byKey[ruleConflictKey(rule)]; ok {
+			if mode != BundleImportReplace {
+				plan.Conflicts = append(plan.Conflicts, newBundleConflict(other, rule, "skipped"))
+				kept[other.ID] = struct{}{}
+				continue
+			}
+			plan.Conflicts = append(plan.Conflicts, newBundleConflict(other, rule, "replaced"))
+		}
+		plan.Create = append(plan.Create, rule)
+	}
+
+	if mode == BundleImportReplace {
+		for _, rule := range existing {
+			if _, ok := kept[rule.ID]; !ok {
+				plan.Delete = append(plan.Delete, rule)
+			}
+		}
+	}
+	return plan
+}
+
+func newBundleConflict(existing, incoming *MockRule, resolution string) BundleConflict {
+	return BundleConflict{
+		Name:       incoming.Name,
+		Protocol:   incoming.Protocol,
+		ExistingID: existing.ID,
+		IncomingID: incoming.ID,
+		Resolution: resolution,
+	}
+}
+
+// RuleIDs 返回规则 ID 列表
+func RuleIDs(rules []*MockRule) []string {
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanBundleImport(t *testing.T) {
+	existing := []*MockRule{
+		{ID: "a", Name: "users", Protocol: "http"},
+		{ID: "b", Name: "orders", Protocol: "http"},
+		{ID: "c", Name: "stale", Protocol: "http"},
+	}
+	bundle := &RuleBundle{
+		Version: RuleBundleVersion,
+		Rules: []*MockRule{
+			{ID: "a", Name: "users", Protocol: "http"},    // 更新
+			{ID: "x", Name: "orders", Protocol: "HTTP"},   // 与 b 冲突
+			{ID: "y", Name: "payments", Protocol: "http"}, // 新增
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mode       BundleImportMode
+		create     []string
+		update     []string
+		delete     []string
+		resolution string
+	}{
+		{name: "merge", mode: BundleImportMerge, create: []string{"y"}, update: []string{"a"}, delete: []string{}, resolution: "skipped"},
+		{name: "replace", mode: BundleImportReplace, create: []string{"x", "y"}, update: []string{"a"}, delete: []string{"b", "c"}, resolution: "replaced"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PlanBundleImport(existing, bundle, tt.mode)
+			assert.Equal(t, tt.create, RuleIDs(plan.Create))
+			assert.Equal(t, tt.update, RuleIDs(plan.Update))
+			assert.Equal(t, tt.delete, RuleIDs(plan.Delete))
+			if assert.Len(t, plan.Conflicts, 1) {
+				assert.Equal(t, "b", plan.Conflicts[0].ExistingID)
+				assert.Equal(t, "x", plan.Conflicts[0].IncomingID)
+				assert.Equal(t, tt.resolution, plan.Conflicts[0].Resolution)
+			}
+		})
+	}
+}
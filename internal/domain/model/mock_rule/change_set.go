@@ -0,0 +1,135 @@
+package model
+
+import (
+	"errors"
+	"sort"
+)
+
+// ChangeSetStatus 变更集状态
+type ChangeSetStatus string
+
+const (
+	ChangeSetOpen      ChangeSetStatus = "open"      // 编辑中，可继续暂存变更
+	ChangeSetPublished ChangeSetStatus = "published" // 已原子发布
+	ChangeSetDiscarded ChangeSetStatus = "discarded" // 已丢弃
+)
+
+// ChangeSetOp 暂存的变更类型
+type ChangeSetOp string
+
+const (
+	ChangeSetOpUpsert ChangeSetOp = "upsert"
+	ChangeSetOpDelete ChangeSetOp = "delete"
+)
+
+// 规则历史变更类型，对应 mock_rule_histories.change_type
+const (
+	HistoryChangeCreate = "create"
+	HistoryChangeUpdate = "update"
+	HistoryChangeDelete = "delete"
+)
+
+var (
+	ErrChangeSetNotFound = errors.New("change set not found")
+	ErrChangeSetNotOpen  = errors.New("change set is not open")
+)
+
+// ChangeSet 一组待发布的规则变更，发布时在同一事务中写入，数据面不会看到部分生效的变更
+type ChangeSet struct {
+	ID          string           `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	Name        string           `gorm:"type:varchar(100);not null" json:"name"`
+	Description string           `gorm:"type:varchar(512)" json:"description,omitempty"`
+	Status      ChangeSetStatus  `gorm:"type:varchar(20);not null;index:idx_change_set_status" json:"status"`
+	CreatedAt   int64            `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   int64            `gorm:"autoUpdateTime" json:"updatedAt"`
+	PublishedAt int64            `gorm:"not null;default:0" json:"publishedAt,omitempty"`
+	Items       []*ChangeSetItem `gorm:"-" json:"items,omitempty"`
+}
+
+func (ChangeSet) TableName() string {
+	return "mock_change_sets"
+}
+
+// ChangeSetItem 变更集中的一条暂存变更，同一变更集内每条规则只保留最后一次暂存
+type ChangeSetItem struct {
+	ID           int64       `gorm:"primaryKey;autoIncrement" json:"id"`
+	ChangeSetID  string      `gorm:"type:varchar(36);not null;uniqueIndex:uk_change_set_rule,priority:1" json:"changeSetId"`
+	RuleID       string      `gorm:"type:varchar(36);not null;uniqueIndex:uk_change_set_rule,priority:2" json:"ruleId"`
+	Op           ChangeSetOp `gorm:"type:varchar(20);not null" json:"op"`
+	Rule         *MockRule   `gorm:"type:json;serializer:json" json:"rule,omitempty"` // upsert 时的规则内容，状态为 draft
+	TargetStatus RuleStatus  `gorm:"type:varchar(20)" json:"targetStatus,omitempty"`  // 发布后的规则状态，默认 active
+	CreatedAt    int64       `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (ChangeSetItem) TableName() string {
+	return "mock_change_set_items"
+}
+
+// PublishedRule 返回发布后的规则：复制暂存内容并将 draft 状态替换为目标状态
+func (i *ChangeSetItem) PublishedRule() *MockRule {
+	if i.Op != ChangeSetOpUpsert || i.Rule == nil {
+		return nil
+	}
+	rule := *i.Rule
+	rule.Status = i.TargetStatus
+	if rule.Status == "" || rule.Status == RuleStatusDraft {
+		rule.Status = RuleStatusActive
+	}
+	return &rule
+}
+
+// RuleHistory 规则变更历史，每次发布为每条变更写入一行
+type RuleHistory struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	RuleID      string    `gorm:"type:varchar(36);not null;index:idx_rule_id" json:"ruleId"`
+	Version     int       `gorm:"not null" json:"version"`
+	ChangeType  string    `gorm:"type:varchar(20);not null" json:"changeType"`
+	Content     *MockRule `gorm:"type:json;not null;serializer:json" json:"content"` // 变更后内容，删除时为删除前内容
+	ChangeSetID string    `gorm:"type:varchar(36);index:idx_history_change_set" json:"changeSetId,omitempty"`
+	CreatedBy   int       `gorm:"not null;default:0" json:"createdBy"`
+	CreatedAt   int       `gorm:"not null" json:"createdAt"`
+}
+
+func (RuleHistory) TableName() string {
+	return "mock_rule_histories"
+}
+
+// OverlayChangeSet 将变更集叠加到现有规则上，返回发布后的规则集合（按 ID 排序）
+func OverlayChangeSet(live []*MockRule, items []*ChangeSetItem) []*MockRule {
+	byID := make(map[string]*MockRule, len(live)+len(items))
+	for _, rule := range live {
+		byID[rule.ID] = rule
+	}
+	for _, item := range items {
+		switch item.Op {
+		case ChangeSetOpDelete:
+			delete(byID, item.RuleID)
+		case ChangeSetOpUpsert:
+			if rule := item.PublishedRule(); rule != nil {
+				byID[item.RuleID] = rule
+			}
+		}
+	}
+
+	rules := make([]*MockRule, 0, len(byID))
+	for _, rule := range byID {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// ChangeSetPreviewSample 单个样例请求在发布前后命中的规则
+type ChangeSetPreviewSample struct {
+	Index      int    `json:"index"`
+	MatchIndex string `json:"matchIndex"`
+	Before     string `json:"before,omitempty"` // 发布前命中的规则 ID
+	After      string `json:"after,omitempty"`  // 发布后命中的规则 ID
+	Changed    bool   `json:"changed"`
+}
+
+// ChangeSetPreview 变更集预览结果
+type ChangeSetPreview struct {
+	ChangeSetID string                    `json:"changeSetId"`
+	Samples     []*ChangeSetPreviewSample `json:"samples"`
+}
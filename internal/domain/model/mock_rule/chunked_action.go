@@ -0,0 +1,220 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChunkedMode 分块响应的编码方式
+type ChunkedMode string
+
+const (
+	ChunkedSSE ChunkedMode = "sse" // text/event-stream，每个 chunk 编码为一个事件
+	ChunkedRaw ChunkedMode = "raw" // chunked transfer encoding，chunk 的 data 原样写出
+)
+
+// ChunkedAction 分块流式响应（SSE 通知流、LLM 式逐 token 输出）
+// chunks：按顺序写出，每个 chunk 写出前等待 delay
+// heartbeat：chunks 写完后按间隔持续写出，count 为 0 时直到客户端断开
+// SSE 请求带 Last-Event-ID 时从该 id 之后的事件继续
+type ChunkedAction struct {
+	StatusCode   int                    `json:"statusCode,omitempty"`
+	Headers      map[string]string      `json:"headers,omitempty"`
+	Mode         ChunkedMode            `json:"mode,omitempty"` // 默认 sse
+	Chunks       []*ResponseChunk       `json:"chunks"`
+	Heartbeat    *Heartbeat             `json:"heartbeat,omitempty"`
+	TemplateData map[string]interface{} `json:"templateData,omitempty"`
+}
+
+// ResponseChunk 一个 SSE 事件或原始分块，id、event、retry 只用于 sse
+type ResponseChunk struct {
+	ID       string        `json:"id,omitempty"`
+	Event    string        `json:"event,omitempty"`
+	Data     string        `json:"data"`
+	Retry    int           `json:"retry,omitempty"` // 客户端重连间隔（毫秒）
+	Template bool          `json:"template,omitempty"`
+	Delay    time.Duration `json:"delay,omitempty"` // 写出前等待
+}
+
+// Heartbeat 心跳，sse 模式下 data 为空时写出注释行 ": heartbeat"
+type Heartbeat struct {
+	Interval time.Duration `json:"interval"`
+	Data     string        `json:"data,omitempty"`
+	Count    int           `json:"count,omitempty"` // 0 表示直到客户端断开
+}
+
+// StreamChunk 编码后的分块
+type StreamChunk struct {
+	Data  []byte
+	Delay time.Duration
+}
+
+// StreamHeartbeat 编码后的心跳
+type StreamHeartbeat struct {
+	Data     []byte
+	Interval time.Duration
+	Count    int
+}
+
+func (a *ChunkedAction) mode() ChunkedMode {
+	if a.Mode == "" {
+		return ChunkedSSE
+	}
+	return a.Mode
+}
+
+func (a *ChunkedAction) Validate() error {
+	if a.StatusCode != 0 && (a.StatusCode < 100 || a.StatusCode > 599) {
+		return errors.New("invalid status code")
+	}
+	mode := a.mode()
+	if mode != ChunkedSSE && mode != ChunkedRaw {
+		return fmt.Errorf("unknown chunked mode %q", a.Mode)
+	}
+	if len(a.Chunks) == 0 && a.Heartbeat == nil {
+		return errors.New("chunked action requires chunks or a heartbeat")
+	}
+	for i, c := range a.Chunks {
+		if c == nil {
+			return fmt.Errorf("chunks[%d] is nil", i)
+		}
+		if c.Delay < 0 {
+			return fmt.Errorf("chunks[%d].delay must not be negative", i)
+		}
+		if c.Retry < 0 {
+			return fmt.Errorf("chunks[%d].retry must not be negative", i)
+		}
+		if mode == ChunkedRaw && (c.ID != "" || c.Event != "" || c.Retry != 0) {
+			return fmt.Errorf("chunks[%d]: id, event and retry are only supported in sse mode", i)
+		}
+		// SSE 字段以换行分隔，id 与 event 中不能出现换行
+		if strings.ContainsAny(c.ID+c.Event, "\r\n") {
+			return fmt.Errorf("chunks[%d]: id and event must not contain line breaks", i)
+		}
+	}
+	if h := a.Heartbeat; h != nil {
+		if h.Interval <= 0 {
+			return errors.New("heartbeat interval must be positive")
+		}
+		if h.Count < 0 {
+			return errors.New("heartbeat count must not be negative")
+		}
+		if mode == ChunkedRaw && h.Data == "" {
+			return errors.New("heartbeat data is required in raw mode")
+		}
+	}
+	return nil
+}
+
+// ValidateProtocol 分块响应只用于 HTTP 规则
+func (a *ChunkedAction) ValidateProtocol(protocol string) error {
+	if p := strings.ToLower(protocol); p != string(ProtocolHTTP) && p != string(ProtocolHTTPS) {
+		return fmt.Errorf("chunked action is only supported for http rules, got protocol %q", protocol)
+	}
+	return nil
+}
+
+func (a *ChunkedAction) Execute(ctx context.Context, req RequestInfo) (ResponseInfo, error) {
+	headers := make(map[string]string, len(a.Headers)+2)
+	if a.mode() == ChunkedSSE {
+		headers["Content-Type"] = "text/event-stream"
+		headers["Cache-Control"] = "no-cache"
+	} else {
+		headers["Content-Type"] = "text/plain; charset=utf-8"
+	}
+	for k, v := range a.Headers {
+		headers[http.CanonicalHeaderKey(k)] = v
+	}
+	resp := &ChunkedResponse{BaseResponse: BaseResponse{status: a.StatusCode, headers: headers}}
+
+	for _, c := range a.resumeFrom(req) {
+		data := c.Data
+		if c.Template {
+			r := &ResponseAction{Body: c.Data, Template: true, TemplateData: a.TemplateData}
+			rendered, err := r.Execute(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			if err := rendered.GetError(); err != nil {
+				return &ChunkedResponse{BaseResponse: BaseResponse{err: err}}, nil
+			}
+			data = string(rendered.GetBody())
+		}
+		resp.chunks = append(resp.chunks, &StreamChunk{Data: a.encode(c, data), Delay: c.Delay})
+	}
+
+	if h := a.Heartbeat; h != nil {
+		data := []byte(h.Data)
+		if a.mode() == ChunkedSSE {
+			data = []byte(": heartbeat\n\n")
+			if h.Data != "" {
+				data = encodeSSEEvent("", "", h.Data, 0)
+			}
+		}
+		resp.heartbeat = &StreamHeartbeat{Data: data, Interval: h.Interval, Count: h.Count}
+	}
+	return resp, nil
+}
+
+// resumeFrom SSE 重连时跳过 Last-Event-ID 及之前的事件，id 不存在时从头发送
+func (a *ChunkedAction) resumeFrom(req RequestInfo) []*ResponseChunk {
+	lastID := req.GetHeaders()["last-event-id"]
+	if a.mode() != ChunkedSSE || lastID == "" {
+		return a.Chunks
+	}
+	for i, c := range a.Chunks {
+		if c.ID == lastID {
+			return a.Chunks[i+1:]
+		}
+	}
+	return a.Chunks
+}
+
+func (a *ChunkedAction) encode(c *ResponseChunk, data string) []byte {
+	if a.mode() == ChunkedRaw {
+		return []byte(data)
+	}
+	return encodeSSEEvent(c.ID, c.Event, data, c.Retry)
+}
+
+// encodeSSEEvent 多行 data 拆成多个 data 字段，事件以空行结束
+func encodeSSEEvent(id, event, data string, retry int) []byte {
+	var b strings.Builder
+	if id != "" {
+		b.WriteString("id: " + id + "\n")
+	}
+	if event != "" {
+		b.WriteString("event: " + event + "\n")
+	}
+	if retry > 0 {
+		b.WriteString("retry: " + strconv.Itoa(retry) + "\n")
+	}
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	for _, line := range strings.Split(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	return []byte(b.String())
+}
+
+// ChunkedResponse 分块响应，body 为空，分块通过 GetChunks 获取
+type ChunkedResponse struct {
+	BaseResponse
+	chunks    []*StreamChunk
+	heartbeat *StreamHeartbeat
+}
+
+var _ ChunkedResponseInfo = (*ChunkedResponse)(nil)
+
+func (r *ChunkedResponse) GetChunks() []*StreamChunk {
+	return r.chunks
+}
+
+func (r *ChunkedResponse) GetHeartbeat() *StreamHeartbeat {
+	return r.heartbeat
+}
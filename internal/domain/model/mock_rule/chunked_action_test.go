@@ -0,0 +1,95 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func chunkData(resp ResponseInfo) []string {
+	var chunks []string
+	for _, c := range resp.(ChunkedResponseInfo).GetChunks() {
+		chunks = append(chunks, string(c.Data))
+	}
+	return chunks
+}
+
+func TestChunkedActionSSE(t *testing.T) {
+	ctx := context.Background()
+	var action ChunkedAction
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"headers": {"x-feed": "notifications"},
+		"chunks": [
+			{"id": "1", "event": "created", "data": "{\"id\": 1}", "retry": 3000},
+			{"id": "2", "data": "line one\nline two", "delay": 1000000},
+			{"id": "3", "data": "hello {{.user}}", "template": true}
+		],
+		"heartbeat": {"interval": 1000000000},
+		"templateData": {"user": "alice"}
+	}`), &action))
+	require.NoError(t, action.Validate())
+	require.NoError(t, action.ValidateProtocol("http"))
+	assert.Error(t, action.ValidateProtocol("grpc"))
+
+	req := NewSyntheticRequest("http", "POST", "/events", nil, nil, []byte(`{}`))
+	resp, err := action.Execute(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.GetStatus())
+	assert.Equal(t, "text/event-stream", resp.GetHeaders()["Content-Type"])
+	assert.Equal(t, "notifications", resp.GetHeaders()["X-Feed"])
+	assert.Equal(t, []string{
+		"id: 1\nevent: created\nretry: 3000\ndata: {\"id\": 1}\n\n",
+		"id: 2\ndata: line one\ndata: line two\n\n",
+		"id: 3\ndata: hello alice\n\n",
+	}, chunkData(resp))
+	hb := resp.(ChunkedResponseInfo).GetHeartbeat()
+	require.NotNil(t, hb)
+	assert.Equal(t, ": heartbeat\n\n", string(hb.Data))
+
+	// 重连时从 Last-Event-ID 之后继续
+	req = NewSyntheticRequest("http", "POST", "/events", map[string]string{"Last-Event-ID": "2"}, nil, []byte(`{}`))
+	resp, err = action.Execute(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id: 3\ndata: hello alice\n\n"}, chunkData(resp))
+}
+
+func TestChunkedActionRaw(t *testing.T) {
+	action := &ChunkedAction{Mode: ChunkedRaw, StatusCode: 201, Chunks: []*ResponseChunk{{Data: "Hel"}, {Data: "lo"}}}
+	require.NoError(t, action.Validate())
+	resp, err := action.Execute(context.Background(), NewSyntheticRequest("http", "GET", "/tokens", nil, nil, nil))
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.GetStatus())
+	assert.Equal(t, "text/plain; charset=utf-8", resp.GetHeaders()["Content-Type"])
+	assert.Equal(t, []string{"Hel", "lo"}, chunkData(resp))
+	assert.Nil(t, resp.(ChunkedResponseInfo).GetHeartbeat())
+}
+
+func TestChunkedActionValidate(t *testing.T) {
+	cases := map[string]*ChunkedAction{
+		"empty":               {},
+		"unknown mode":        {Mode: "ws", Chunks: []*ResponseChunk{{Data: "x"}}},
+		"raw with event":      {Mode: ChunkedRaw, Chunks: []*ResponseChunk{{Event: "e", Data: "x"}}},
+		"newline in id":       {Chunks: []*ResponseChunk{{ID: "a\nb", Data: "x"}}},
+		"negative delay":      {Chunks: []*ResponseChunk{{Data: "x", Delay: -1}}},
+		"zero heartbeat":      {Heartbeat: &Heartbeat{}},
+		"raw heartbeat empty": {Mode: ChunkedRaw, Heartbeat: &Heartbeat{Interval: 1}},
+	}
+	for name, action := range cases {
+		assert.Error(t, action.Validate(), name)
+	}
+	assert.NoError(t, (&ChunkedAction{Heartbeat: &Heartbeat{Interval: 1}}).Validate())
+}
+
+func TestChunkedRuleRejectsEmptyHeartbeat(t *testing.T) {
+	rule := &MockRule{
+		Protocol: "http",
+		MatchConfig: MatchConfig{Logical: "AND", Conditions: []MatchCondition{
+			{Type: MatchPath, Operator: OpEqual, Value: "/events"},
+		}},
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"type": "chunked", "config": {"heartbeat": {}}}`), &rule.ActionConfig))
+	assert.ErrorContains(t, rule.Validate(), "heartbeat interval must be positive")
+}
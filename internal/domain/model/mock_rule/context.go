@@ -0,0 +1,32 @@
+package model
+
+import "context"
+
+type ctxKey string
+
+const (
+	ctxKeyRuleID ctxKey = "mock_rule_id"
+	ctxKeyDryRun ctxKey = "mock_dry_run"
+)
+
+// WithRuleID 在上下文中记录当前执行的规则 ID，供有状态的 Action 使用
+func WithRuleID(ctx context.Context, ruleID string) context.Context {
+	return context.WithValue(ctx, ctxKeyRuleID, ruleID)
+}
+
+// RuleIDFromContext 获取当前执行的规则 ID
+func RuleIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKeyRuleID).(string)
+	return id
+}
+
+// WithDryRun 标记当前为 dry-run 执行，有状态的 Action 不应推进状态
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyDryRun, true)
+}
+
+// IsDryRun 判断当前是否为 dry-run 执行
+func IsDryRun(ctx context.Context) bool {
+	v, _ := ctx.Value(ctxKeyDryRun).(bool)
+	return v
+}
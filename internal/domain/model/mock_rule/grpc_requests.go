@@ -12,13 +12,27 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// GRPCStreamType gRPC 调用类型
+type GRPCStreamType string
+
+const (
+	GRPCUnary        GRPCStreamType = "unary"
+	GRPCServerStream GRPCStreamType = "server_stream"
+	GRPCClientStream GRPCStreamType = "client_stream"
+	GRPCBidiStream   GRPCStreamType = "bidi_stream"
+)
+
 type GRPCRequestInfo struct {
-	method   string        // 完整方法路径 如 /package.Service/Method
-	metadata metadata.MD   // 元数据
-	body     []byte        // 原始二进制数据
-	message  proto.Message // 反序列化后的消息
+	method     string          // 完整方法路径 如 /package.Service/Method
+	metadata   metadata.MD     // 元数据
+	body       []byte          // 原始二进制数据
+	message    proto.Message   // 反序列化后的消息，client streaming 为全部入站消息合并后的结果
+	streamType GRPCStreamType  // 调用类型
+	messages   []proto.Message // 按到达顺序的入站消息
 }
 
+var _ StreamRequestInfo = (*GRPCRequestInfo)(nil)
+
 // 创建 gRPC RequestInfo 的工厂方法
 func NewGRPCRequest(
 	ctx context.Context,
@@ -34,12 +48,43 @@ func NewGRPCRequest(
 		body, _ = proto.Marshal(req)
 	}
 
+	var messages []proto.Message
+	if req != nil {
+		messages = []proto.Message{req}
+	}
 	return &GRPCRequestInfo{
-		method:   fullMethod,
-		metadata: md,
-		body:     body,
-		message:  req,
+		method:     fullMethod,
+		metadata:   md,
+		body:       body,
+		message:    req,
+		streamType: GRPCUnary,
+		messages:   messages,
+	}
+}
+
+// NewGRPCStreamRequest 创建流式调用的 RequestInfo，messages 为目前收到的全部入站消息
+// client streaming 的 body 为全部消息按 proto.Merge 合并后的结果（标量取最后一次的值，repeated 字段拼接）；
+// 其他类型的 body 为最后一条消息
+func NewGRPCStreamRequest(
+	ctx context.Context,
+	fullMethod string,
+	streamType GRPCStreamType,
+	messages []proto.Message,
+) RequestInfo {
+	var current proto.Message
+	if n := len(messages); n > 0 {
+		current = messages[n-1]
+		if streamType == GRPCClientStream {
+			current = proto.Clone(messages[0])
+			for _, m := range messages[1:] {
+				proto.Merge(current, m)
+			}
+		}
 	}
+	req := NewGRPCRequest(ctx, fullMethod, current).(*GRPCRequestInfo)
+	req.streamType = streamType
+	req.messages = messages
+	return req
 }
 
 // 实现接口方法
@@ -79,14 +124,17 @@ func (g *GRPCRequestInfo) GetBodyJSON() (map[string]any, error) {
 	if g.message == nil {
 		return nil, errors.New("空消息体")
 	}
+	return messageToJSON(g.message)
+}
 
-	// 使用 protojson 转换
+// messageToJSON 使用 protojson 转换为 map，字段名保持 proto 原名并输出零值字段
+func messageToJSON(message proto.Message) (map[string]any, error) {
 	marshaler := protojson.MarshalOptions{
 		UseProtoNames:   true,
 		EmitUnpopulated: true,
 	}
 
-	jsonBytes, err := marshaler.Marshal(g.message)
+	jsonBytes, err := marshaler.Marshal(message)
 	if err != nil {
 		return nil, fmt.Errorf("proto转JSON失败: %w", err)
 	}
@@ -98,6 +146,28 @@ func (g *GRPCRequestInfo) GetBodyJSON() (map[string]any, error) {
 	return result, nil
 }
 
+func (g *GRPCRequestInfo) GetStreamType() GRPCStreamType {
+	return g.streamType
+}
+
+func (g *GRPCRequestInfo) GetStreamMessageCount() int {
+	return len(g.messages)
+}
+
+// GetStreamMessagesJSON 按到达顺序返回入站消息的 JSON 表示，供 stream_message 条件使用
+func (g *GRPCRequestInfo) GetStreamMessagesJSON() ([]any, error) {
+	result := make([]any, 0, len(g.messages))
+	for i, m := range g.messages {
+		data, err := messageToJSON(m)
+		if err != nil {
+			return nil, fmt.Errorf("message #%d: %w", i, err)
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+// GetMatchIndex 与规则使用同一个 gRPC 索引策略：grpc_<method>_<package.Service>
 func (g *GRPCRequestInfo) GetMatchIndex() string {
-	return fmt.Sprintf("%s:%s", g.GetPath(), g.GetMethod())
+	return BuildL1MatchIndexKeyFromReq(g)
 }
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	// 注册 google.rpc 错误详情类型（BadRequest、RetryInfo、ErrorInfo 等），details 中的 @type 依赖它们解析
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// GRPCStatus gRPC 响应状态
+// code 可以写名称（如 "NOT_FOUND"）或数字；details 为 google.protobuf.Any 的 JSON 形式，如
+// {"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "1s"}
+type GRPCStatus struct {
+	Code    codes.Code        `json:"code"`
+	Message string            `json:"message,omitempty"`
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+// GRPCResponseInfo 可选接口：gRPC 响应的状态与 trailer，GetGRPCStatus 为 nil 表示 OK
+type GRPCResponseInfo interface {
+	GetGRPCStatus() *GRPCStatus
+	GetTrailers() map[string]string
+}
+
+// ProtocolAwareAction 可选接口：Action 按规则协议校验配置，在 MockRule.Validate 中调用
+type ProtocolAwareAction interface {
+	ValidateProtocol(protocol string) error
+}
+
+func (s *GRPCStatus) Validate() error {
+	_, err := s.Status()
+	return err
+}
+
+// IsOK code 为 OK 时只发送 trailer，不返回错误
+func (s *GRPCStatus) IsOK() bool {
+	return s == nil || s.Code == codes.OK
+}
+
+// Status 转换为 grpc status，details 无法解析时返回错误
+func (s *GRPCStatus) Status() (*status.Status, error) {
+	if s.Code > codes.Unauthenticated {
+		return nil, fmt.Errorf("invalid grpc status code %d", s.Code)
+	}
+	if s.Code == codes.OK && len(s.Details) > 0 {
+		return nil, fmt.Errorf("grpc status details require a non-OK code")
+	}
+	pb := &spb.Status{Code: int32(s.Code), Message: s.Message}
+	for i, raw := range s.Details {
+		detail := &anypb.Any{}
+		if err := protojson.Unmarshal(raw, detail); err != nil {
+			return nil, fmt.Errorf("grpc status details[%d]: %w", i, err)
+		}
+		pb.Details = append(pb.Details, detail)
+	}
+	return status.FromProto(pb), nil
+}
+
+// validateGRPCFields gRPC 状态与 trailer 只对 grpc 规则有效
+func validateGRPCFields(protocol string, st *GRPCStatus, trailers map[string]string) error {
+	if st == nil && len(trailers) == 0 {
+		return nil
+	}
+	if !strings.EqualFold(protocol, string(ProtocolGRPC)) {
+		return fmt.Errorf("grpcStatus and trailers are only supported for grpc rules, got protocol %q", protocol)
+	}
+	if st != nil {
+		return st.Validate()
+	}
+	return nil
+}
+
+// ValidateProtocol grpc 规则不使用 HTTP 状态码，statusCode 可以省略；其他协议必须设置
+func (r *ResponseAction) ValidateProtocol(protocol string) error {
+	if r.StatusCode == 0 && !strings.EqualFold(protocol, string(ProtocolGRPC)) {
+		return errors.New("statusCode is required")
+	}
+	return validateGRPCFields(protocol, r.GRPCStatus, r.Trailers)
+}
+
+func (a *SequenceAction) ValidateProtocol(protocol string) error {
+	for i, r := range a.Responses {
+		if r == nil {
+			continue
+		}
+		if err := r.ValidateProtocol(protocol); err != nil {
+			return fmt.Errorf("sequence responses[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (a *StreamAction) ValidateProtocol(protocol string) error {
+	if err := validateGRPCFields(protocol, a.GRPCStatus, a.Trailers); err != nil {
+		return err
+	}
+	for i, r := range a.Replies {
+		if r == nil {
+			continue
+		}
+		if err := validateGRPCFields(protocol, r.GRPCStatus, nil); err != nil {
+			return fmt.Errorf("stream replies[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *BaseResponse) GetGRPCStatus() *GRPCStatus {
+	return r.grpcStatus
+}
+
+func (r *BaseResponse) GetTrailers() map[string]string {
+	return r.trailers
+}
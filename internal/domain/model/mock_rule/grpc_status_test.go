@@ -0,0 +1,59 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGRPCStatus(t *testing.T) {
+	var st GRPCStatus
+	require.NoError(t, json.Unmarshal([]byte(`{"code": "NOT_FOUND", "message": "no user",
+		"details": [{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "USER_MISSING"}]}`), &st))
+	assert.Equal(t, codes.NotFound, st.Code)
+	s, err := st.Status()
+	require.NoError(t, err)
+	assert.Len(t, s.Proto().GetDetails(), 1)
+
+	// 数字形式的 code
+	require.NoError(t, json.Unmarshal([]byte(`{"code": 14}`), &st))
+	assert.Equal(t, codes.Unavailable, st.Code)
+	assert.Error(t, json.Unmarshal([]byte(`{"code": "NO_SUCH_CODE"}`), &st))
+
+	bad := &GRPCStatus{Code: codes.Internal, Details: []json.RawMessage{[]byte(`{"@type": "type.googleapis.com/unknown.Detail"}`)}}
+	assert.Error(t, bad.Validate())
+	assert.Error(t, (&GRPCStatus{Code: codes.OK, Details: bad.Details}).Validate())
+	assert.Error(t, (&GRPCStatus{Code: 42}).Validate())
+}
+
+func TestGRPCStatusValidatedPerProtocol(t *testing.T) {
+	rule := newScheduleTestRule()
+	rule.ActionConfig = ActionConfigWrapper{AType: ActionTypeResponse, Config: &ResponseAction{
+		StatusCode: 200,
+		GRPCStatus: &GRPCStatus{Code: codes.NotFound},
+	}}
+	assert.ErrorContains(t, rule.Validate(), "only supported for grpc rules")
+
+	rule.Protocol = "grpc"
+	assert.NoError(t, rule.Validate())
+
+	rule.ActionConfig = ActionConfigWrapper{AType: ActionTypeSequence, Config: &SequenceAction{Responses: []*ResponseAction{
+		{Body: `{}`},
+		{GRPCStatus: &GRPCStatus{Code: codes.Internal, Details: []json.RawMessage{[]byte(`{"@type": "type.googleapis.com/nope.Detail"}`)}}},
+	}}}
+	assert.ErrorContains(t, rule.Validate(), "sequence responses[1]")
+}
+
+func TestResponseStatusCodeOptionalForGRPC(t *testing.T) {
+	rule := newScheduleTestRule()
+	rule.Protocol = "grpc"
+	rule.ActionConfig = ActionConfigWrapper{AType: ActionTypeResponse, Config: &ResponseAction{Body: `{"id": 1}`}}
+	assert.NoError(t, rule.Validate())
+
+	rule.Protocol = "http"
+	assert.ErrorContains(t, rule.Validate(), "statusCode is required")
+	assert.Error(t, (&ResponseAction{StatusCode: 42}).Validate())
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"regexp"
 	"strings"
 )
@@ -31,14 +33,17 @@ func NormalizePath(path string) string {
 	return path
 }
 
+// BuildL1MatchIndexKeyFromRule 按规则协议的索引策略构建索引键
 func BuildL1MatchIndexKeyFromRule(rule *MockRule) string {
-	return BuildL1MatchIndexKey(rule.Protocol, rule.Method, rule.PathPattern)
+	return MatchIndexStrategyFor(rule.Protocol).RuleKey(rule)
 }
 
+// BuildL1MatchIndexKeyFromReq 按请求协议的索引策略构建索引键
 func BuildL1MatchIndexKeyFromReq(req RequestInfo) string {
-	return BuildL1MatchIndexKey(req.GetProtocol(), req.GetMethod(), req.GetPath())
+	return MatchIndexStrategyFor(req.GetProtocol()).RequestKey(req)
 }
 
+// BuildL1MatchIndexKey HTTP 类协议的索引键：<schema>_<method>_<标准化路径>
 func BuildL1MatchIndexKey(schema string, method string, path string) string {
 	if method == "" {
 		method = "*"
@@ -47,3 +52,13 @@ func BuildL1MatchIndexKey(schema string, method string, path string) string {
 	methodLower := strings.ToLower(method)
 	return strings.Join([]string{schema, methodLower, normalizedPath}, "_")
 }
+
+// GenerateRuleID 根据前缀和若干字段生成确定性的规则 ID（最长 36 位）
+func GenerateRuleID(prefix string, parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "|")))
+	id := prefix + "_" + hex.EncodeToString(sum[:])
+	if len(id) > 36 {
+		id = id[:36]
+	}
+	return id
+}
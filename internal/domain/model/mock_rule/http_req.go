@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -35,6 +36,14 @@ func (h *HTTPRequestInfo) GetProtocol() string {
 	return "http"
 }
 
+// GetServerName TLS 握手中的 SNI 主机名，非 TLS 请求返回空串
+func (h *HTTPRequestInfo) GetServerName() string {
+	if h.req.TLS == nil {
+		return ""
+	}
+	return h.req.TLS.ServerName
+}
+
 func (h *HTTPRequestInfo) GetMethod() string {
 	return h.req.Method
 }
@@ -51,6 +60,11 @@ func (h *HTTPRequestInfo) GetHeaders() map[string]string {
 	return headers
 }
 
+// GetQuery 返回 URL 查询参数
+func (h *HTTPRequestInfo) GetQuery() url.Values {
+	return h.req.URL.Query()
+}
+
 func (h *HTTPRequestInfo) GetBody() []byte {
 	return h.bodyCache
 }
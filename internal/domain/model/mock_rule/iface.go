@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"net/url"
+	"time"
+)
 
 type RequestInfo interface {
 	GetProtocol() string           // 获取协议类型 (例如 "http", "grpc", "tcp")
@@ -13,6 +16,22 @@ type RequestInfo interface {
 	//  可以根据需要添加更多通用方法，例如获取查询参数、客户端地址等
 }
 
+// QueryProvider 可选接口：支持查询参数的请求实现该接口 (例如 HTTP)
+type QueryProvider interface {
+	GetQuery() url.Values
+}
+
+// TLSRequestInfo 可选接口：TLS 连接上的请求提供握手中的 SNI 主机名，未发送 SNI 时为空串
+type TLSRequestInfo interface {
+	GetServerName() string
+}
+
+// StreamRequestInfo 可选接口：流式请求按到达顺序提供入站消息 (例如 gRPC client / bidi streaming)
+type StreamRequestInfo interface {
+	GetStreamMessageCount() int
+	GetStreamMessagesJSON() ([]any, error)
+}
+
 type ResponseInfo interface {
 	GetStatus() int                       // Get response status code (e.g., HTTP status code, gRPC status code)
 	GetHeaders() map[string]string        // Get response headers
@@ -21,3 +40,27 @@ type ResponseInfo interface {
 	GetDelay() time.Duration              // Get configured response delay
 	GetError() error                      // Get any error associated with the response
 }
+
+// StreamResponseInfo 可选接口：流式响应按顺序提供多条消息，每条消息的 GetDelay 为发送前的等待时间
+type StreamResponseInfo interface {
+	GetMessages() []ResponseInfo
+}
+
+// WebSocketFrameInfo 可选接口：WebSocket 请求的入站帧类型，握手请求返回空串
+type WebSocketFrameInfo interface {
+	GetFrameType() WebSocketFrameType
+}
+
+// WebSocketResponseInfo 可选接口：WebSocket 脚本的执行结果
+// 握手请求返回 onConnect 消息、周期推送与主动关闭；入站帧返回匹配到的回复与关闭
+type WebSocketResponseInfo interface {
+	GetFrames() []*WebSocketFrame
+	GetPushes() []*WebSocketPushFrame
+	GetClose() *WebSocketClose
+}
+
+// ChunkedResponseInfo 可选接口：分块写出的响应（SSE、chunked transfer encoding），每个分块写出后立即 flush
+type ChunkedResponseInfo interface {
+	GetChunks() []*StreamChunk
+	GetHeartbeat() *StreamHeartbeat // 为 nil 表示分块写完后结束响应
+}
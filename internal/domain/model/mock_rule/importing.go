@@ -0,0 +1,50 @@
+package model
+
+// OpenAPIImportOptions 控制 OpenAPI 导入行为
+type OpenAPIImportOptions struct {
+	DefaultStatus string `json:"defaultStatus,omitempty"` // 默认返回的响应码，为空时取最小的 2xx
+	Tag           string `json:"tag,omitempty"`           // 导入规则的标签，为空时使用 info.title
+	Priority      int    `json:"priority,omitempty"`      // 默认规则的优先级，可选择的规则在此基础上递增
+}
+
+// ImportFailure 导入失败的规则
+type ImportFailure struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// ImportResult 规则导入结果
+type ImportResult struct {
+	Total   int               `json:"total"`
+	Created []string          `json:"created"`
+	Failed  []ImportFailure   `json:"failed,omitempty"`
+	Report  *ConversionReport `json:"report,omitempty"` // 外部格式转换报告
+}
+
+// HARImportOptions 控制 HAR 导入行为
+type HARImportOptions struct {
+	MatchQuery   []string `json:"matchQuery,omitempty"`   // 需要匹配的查询参数，为空时匹配全部查询参数
+	MatchHeaders []string `json:"matchHeaders,omitempty"` // 需要匹配的请求头
+	Hosts        []string `json:"hosts,omitempty"`        // 只导入这些 host 的请求，为空时导入全部
+	Tag          string   `json:"tag,omitempty"`          // 导入规则的标签
+	Priority     int      `json:"priority,omitempty"`
+}
+
+// ConversionIssue 转换过程中无法翻译的特性
+type ConversionIssue struct {
+	Item    string `json:"item"`    // 对应的规则 / stub / 请求名称
+	Feature string `json:"feature"` // 无法翻译的特性
+	Detail  string `json:"detail,omitempty"`
+	Skipped bool   `json:"skipped"` // 是否因此跳过了整个条目
+}
+
+// ConversionReport 格式转换报告
+type ConversionReport struct {
+	Converted   int               `json:"converted"`
+	Unsupported []ConversionIssue `json:"unsupported,omitempty"`
+}
+
+// Add 记录一个无法翻译的特性
+func (r *ConversionReport) Add(item, feature, detail string, skipped bool) {
+	r.Unsupported = append(r.Unsupported, ConversionIssue{Item: item, Feature: feature, Detail: detail, Skipped: skipped})
+}
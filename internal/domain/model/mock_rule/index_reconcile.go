@@ -0,0 +1,50 @@
+package model
+
+// 索引修复类型
+const (
+	RepairCacheRefreshed   = "cache_refreshed"   // 规则缓存缺失或与数据库不一致
+	RepairIndexAdded       = "index_added"       // 索引缺少规则
+	RepairIndexScoreFixed  = "index_score_fixed" // 索引分数与优先级不一致
+	RepairIndexOrphan      = "index_orphan"      // 索引中存在已删除或已迁移的规则
+	RepairCacheOrphan      = "cache_orphan"      // 缓存中存在已删除的规则
+	maxReconcileRepairList = 200
+)
+
+// IndexRepair 一条修复记录
+type IndexRepair struct {
+	Kind     string `json:"kind"`
+	IndexKey string `json:"indexKey,omitempty"`
+	RuleID   string `json:"ruleId"`
+}
+
+// IndexReconcileReport 索引一致性检查报告
+type IndexReconcileReport struct {
+	DryRun       bool           `json:"dryRun"`
+	StartedAt    int64          `json:"startedAt"`
+	DurationMs   int64          `json:"durationMs"`
+	RulesScanned int            `json:"rulesScanned"`
+	KeysScanned  int            `json:"keysScanned"`
+	Repaired     map[string]int `json:"repaired"`          // 按修复类型计数
+	Repairs      []IndexRepair  `json:"repairs,omitempty"` // 修复明细，最多保留 200 条
+	Errors       []string       `json:"errors,omitempty"`
+}
+
+// AddRepair 记录一次修复
+func (r *IndexReconcileReport) AddRepair(kind, indexKey, ruleID string) {
+	if r.Repaired == nil {
+		r.Repaired = make(map[string]int)
+	}
+	r.Repaired[kind]++
+	if len(r.Repairs) < maxReconcileRepairList {
+		r.Repairs = append(r.Repairs, IndexRepair{Kind: kind, IndexKey: indexKey, RuleID: ruleID})
+	}
+}
+
+// TotalRepaired 修复总数
+func (r *IndexReconcileReport) TotalRepaired() int {
+	total := 0
+	for _, n := range r.Repaired {
+		total += n
+	}
+	return total
+}
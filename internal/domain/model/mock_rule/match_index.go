@@ -0,0 +1,108 @@
+package model
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// MatchIndexStrategy 按协议构建 L1 匹配索引键
+// 规则保存（BuildL1MatchIndexKeyFromRule）与请求匹配（BuildL1MatchIndexKeyFromReq）使用同一个策略，保证两侧的键一致
+type MatchIndexStrategy interface {
+	// RuleKey 规则写入索引时使用的键
+	RuleKey(rule *MockRule) string
+	// RequestKey 请求查找候选规则时使用的键
+	RequestKey(req RequestInfo) string
+	// Validate 检查规则能否通过索引被请求找到
+	Validate(rule *MockRule) error
+}
+
+var indexStrategies = make(map[string]MatchIndexStrategy)
+
+// RegisterMatchIndexStrategy 为协议注册索引策略，协议名不区分大小写
+func RegisterMatchIndexStrategy(protocol string, strategy MatchIndexStrategy) {
+	indexStrategies[strings.ToLower(protocol)] = strategy
+}
+
+// MatchIndexStrategyFor 返回协议的索引策略，未注册的协议按 HTTP 方式处理
+func MatchIndexStrategyFor(protocol string) MatchIndexStrategy {
+	if s, ok := indexStrategies[strings.ToLower(protocol)]; ok {
+		return s
+	}
+	return httpIndexStrategy{}
+}
+
+func init() {
+	RegisterMatchIndexStrategy(string(ProtocolHTTP), httpIndexStrategy{})
+	RegisterMatchIndexStrategy(string(ProtocolHTTPS), httpIndexStrategy{})
+	RegisterMatchIndexStrategy(string(ProtocolGRPC), grpcIndexStrategy{})
+	RegisterMatchIndexStrategy(string(ProtocolWebSocket), websocketIndexStrategy{})
+}
+
+// httpIndexStrategy <protocol>_<method>_<标准化路径>，路径中的动态段替换为 *
+type httpIndexStrategy struct{}
+
+func (httpIndexStrategy) RuleKey(rule *MockRule) string {
+	return BuildL1MatchIndexKey(rule.Protocol, rule.Method, rule.PathPattern)
+}
+
+func (httpIndexStrategy) RequestKey(req RequestInfo) string {
+	return BuildL1MatchIndexKey(req.GetProtocol(), req.GetMethod(), req.GetPath())
+}
+
+func (httpIndexStrategy) Validate(*MockRule) error {
+	return nil
+}
+
+// grpcIndexStrategy grpc_<method 小写>_<package.Service>
+// 服务名按原样使用，不做路径标准化；服务与方法都必须是 eq 条件，否则请求无法定位到规则
+type grpcIndexStrategy struct{}
+
+var errGRPCIndex = errors.New("grpc rules require eq conditions on path (package.Service) and method")
+
+func (grpcIndexStrategy) RuleKey(rule *MockRule) string {
+	return grpcIndexKey(rule.MatchConfig.ExactConditionValue(MatchPath), rule.MatchConfig.ExactConditionValue(MatchMethod))
+}
+
+func (grpcIndexStrategy) RequestKey(req RequestInfo) string {
+	return grpcIndexKey(req.GetPath(), req.GetMethod())
+}
+
+func (grpcIndexStrategy) Validate(rule *MockRule) error {
+	if rule.MatchConfig.ExactConditionValue(MatchPath) == "" || rule.MatchConfig.ExactConditionValue(MatchMethod) == "" {
+		return errGRPCIndex
+	}
+	return nil
+}
+
+func grpcIndexKey(service, method string) string {
+	if service == "" {
+		service = "*"
+	}
+	if method == "" {
+		method = "*"
+	}
+	return strings.Join([]string{string(ProtocolGRPC), strings.ToLower(method), service}, "_")
+}
+
+// websocketIndexStrategy websocket_get_<标准化路径>，握手请求总是 GET，规则可以省略 method 条件
+type websocketIndexStrategy struct{}
+
+var errWebSocketMethod = errors.New("websocket rules only match GET upgrade requests")
+
+func (websocketIndexStrategy) RuleKey(rule *MockRule) string {
+	return BuildL1MatchIndexKey(string(ProtocolWebSocket), http.MethodGet, rule.PathPattern)
+}
+
+func (websocketIndexStrategy) RequestKey(req RequestInfo) string {
+	return BuildL1MatchIndexKey(string(ProtocolWebSocket), http.MethodGet, req.GetPath())
+}
+
+func (websocketIndexStrategy) Validate(rule *MockRule) error {
+	for _, m := range rule.MatchConfig.GetMethods() {
+		if m != http.MethodGet {
+			return errWebSocketMethod
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newIndexTestRule(protocol string, conds ...MatchCondition) *MockRule {
+	return &MockRule{ID: protocol, Protocol: protocol, Status: RuleStatusActive,
+		MatchConfig:  MatchConfig{Logical: "AND", Conditions: conds},
+		ActionConfig: ActionConfigWrapper{AType: ActionTypeResponse, Config: &ResponseAction{StatusCode: 200}}}
+}
+
+func TestMatchIndexStrategyPerProtocol(t *testing.T) {
+	grpcReq, err := structpb.NewStruct(map[string]any{"id": "1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		rule *MockRule
+		req  RequestInfo
+		key  string
+	}{
+		{
+			name: "http normalizes dynamic segments",
+			rule: newIndexTestRule("http",
+				MatchCondition{Type: MatchMethod, Operator: OpEqual, Value: "GET"},
+				MatchCondition{Type: MatchPath, Operator: OpEqual, Value: "/api/users/{id}"}),
+			req: NewSyntheticRequest("http", "GET", "/api/users/42", nil, nil, nil),
+			key: "http_get_/api/users/*",
+		},
+		{
+			name: "https",
+			rule: newIndexTestRule("https",
+				MatchCondition{Type: MatchMethod, Operator: OpEqual, Value: "POST"},
+				MatchCondition{Type: MatchPath, Operator: OpEqual, Value: "/login"}),
+			req: NewSyntheticRequest("https", "POST", "/login", nil, nil, nil),
+			key: "https_post_/login",
+		},
+		{
+			name: "grpc keeps service name as is",
+			rule: newIndexTestRule("grpc",
+				MatchCondition{Type: MatchPath, Operator: OpEqual, Value: "user.v1.UserService"},
+				MatchCondition{Type: MatchMethod, Operator: OpEqual, Value: "GetUser"}),
+			req: NewGRPCRequest(context.Background(), "/user.v1.UserService/GetUser", grpcReq),
+			key: "grpc_getuser_user.v1.UserService",
+		},
+		{
+			name: "websocket indexes the upgrade request",
+			rule: newIndexTestRule("websocket",
+				MatchCondition{Type: MatchMethod, Operator: OpEqual, Value: "GET"},
+				MatchCondition{Type: MatchPath, Operator: OpEqual, Value: "/ws"}),
+			req: NewSyntheticRequest("websocket", "GET", "/ws", nil, nil, nil),
+			key: "websocket_get_/ws",
+		},
+		{
+			name: "unregistered protocol falls back to http",
+			rule: newIndexTestRule("graphql",
+				MatchCondition{Type: MatchMethod, Operator: OpEqual, Value: "POST"},
+				MatchCondition{Type: MatchPath, Operator: OpEqual, Value: "/graphql/42"}),
+			req: NewSyntheticRequest("graphql", "POST", "/graphql/42", nil, nil, nil),
+			key: "graphql_post_/graphql/*",
+		},
+	}
+	_, registered := indexStrategies["graphql"]
+	require.False(t, registered)
+	assert.IsType(t, httpIndexStrategy{}, MatchIndexStrategyFor("graphql"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.rule.Validate())
+			assert.Equal(t, tt.key, tt.rule.L1MatchIndex)
+			assert.Equal(t, tt.key, BuildL1MatchIndexKeyFromRule(tt.rule))
+			assert.Equal(t, tt.key, tt.req.GetMatchIndex())
+		})
+	}
+}
+
+func TestGRPCRuleRequiresIndexableConditions(t *testing.T) {
+	rule := newIndexTestRule("grpc",
+		MatchCondition{Type: MatchPath, Operator: OpRegex, Value: "user\\..*"},
+		MatchCondition{Type: MatchMethod, Operator: OpEqual, Value: "GetUser"})
+	assert.ErrorIs(t, rule.Validate(), errGRPCIndex)
+
+	rule = newIndexTestRule("grpc", MatchCondition{Type: MatchPath, Operator: OpEqual, Value: "user.UserService"})
+	assert.ErrorIs(t, rule.Validate(), errGRPCIndex)
+}
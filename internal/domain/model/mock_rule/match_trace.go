@@ -0,0 +1,156 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// ConditionTrace 记录单个匹配条件的评估结果
+type ConditionTrace struct {
+	Index    int    `json:"index"`
+	Type     string `json:"type"`
+	Operator string `json:"operator"`
+	Key      any    `json:"key,omitempty"`
+	Expected any    `json:"expected"`
+	Actual   any    `json:"actual,omitempty"`
+	Matched  bool   `json:"matched"`
+}
+
+// RuleMatchTrace 记录一条规则对请求的完整评估过程，结果与 IsMatch 保持一致
+type RuleMatchTrace struct {
+	RuleID     string           `json:"ruleId"`
+	RuleName   string           `json:"ruleName"`
+	Priority   int              `json:"priority"`
+	Status     RuleStatus       `json:"status"`
+	Logical    string           `json:"logical"`
+	Matched    bool             `json:"matched"`
+	Reason     string           `json:"reason,omitempty"` // 未匹配原因
+	Conditions []ConditionTrace `json:"conditions,omitempty"`
+}
+
+// DryRunResult 描述一次 dry-run 匹配的结果
+type DryRunResult struct {
+	MatchIndex  string            `json:"matchIndex"`
+	MatchedRule *MockRule         `json:"matchedRule,omitempty"`
+	Traces      []*RuleMatchTrace `json:"traces"`
+	Response    ResponseInfo      `json:"-"`
+	ActionError error             `json:"-"`
+}
+
+// TraceMatch 与 IsMatch 逻辑相同，但会记录每一步的评估结果
+func (m *MockRule) TraceMatch(ctx context.Context, requestInfo RequestInfo) *RuleMatchTrace {
+	trace := &RuleMatchTrace{
+		RuleID:   m.ID,
+		RuleName: m.Name,
+		Priority: m.Priority,
+		Status:   m.Status,
+		Logical:  m.MatchConfig.Logical,
+	}
+
+	if m.Status != RuleStatusActive {
+		trace.Reason = "rule status is " + m.Status.String()
+		return trace
+	}
+
+	if !m.IsActiveAt(time.Now().Unix()) {
+		trace.Reason = "outside active window"
+		return trace
+	}
+
+	if strings.ToLower(m.Protocol) != strings.ToLower(requestInfo.GetProtocol()) {
+		trace.Reason = "protocol mismatch: rule " + m.Protocol + ", request " + requestInfo.GetProtocol()
+		return trace
+	}
+
+	trace.Matched, trace.Conditions = m.MatchConfig.Trace(ctx, requestInfo)
+	if !trace.Matched {
+		trace.Reason = "conditions not satisfied"
+	}
+	return trace
+}
+
+// Trace 评估全部条件（不短路），返回整体结果与每个条件的评估明细
+func (m *MatchConfig) Trace(ctx context.Context, reqInfo RequestInfo) (bool, []ConditionTrace) {
+	traces := make([]ConditionTrace, 0, len(m.Conditions))
+	if len(m.Conditions) == 0 {
+		return false, traces
+	}
+
+	isAnd := strings.ToUpper(m.Logical) == "AND"
+	result := isAnd
+	for i, cond := range m.Conditions {
+		matched := m.matchCondition(ctx, reqInfo, cond)
+		traces = append(traces, ConditionTrace{
+			Index:    i,
+			Type:     cond.Type,
+			Operator: cond.Operator,
+			Key:      cond.Key,
+			Expected: cond.Value,
+			Actual:   m.actualValue(reqInfo, cond),
+			Matched:  matched,
+		})
+		if isAnd && !matched {
+			result = false
+		}
+		if !isAnd && matched {
+			result = true
+		}
+	}
+	return result, traces
+}
+
+// actualValue 取出请求中与条件对应的实际值，仅用于展示
+func (m *MatchConfig) actualValue(reqInfo RequestInfo, cond MatchCondition) any {
+	switch strings.ToLower(cond.Type) {
+	case "method":
+		return reqInfo.GetMethod()
+	case "path":
+		return reqInfo.GetPath()
+	case "header":
+		key, ok := cond.Key.(string)
+		if !ok {
+			return nil
+		}
+		if v, ok := reqInfo.GetHeaders()[key]; ok {
+			return v
+		}
+		return nil
+	case MatchQueryParam:
+		key, ok := cond.Key.(string)
+		qp, isQP := reqInfo.(QueryProvider)
+		if !ok || !isQP {
+			return nil
+		}
+		if v, ok := qp.GetQuery()[key]; ok && len(v) > 0 {
+			return v[0]
+		}
+		return nil
+	case "body_json":
+		key, ok := cond.Key.(string)
+		if !ok {
+			return nil
+		}
+		body, err := reqInfo.GetBodyJSON()
+		if err != nil || body == nil {
+			return nil
+		}
+		res, err := JsonPathLookup(body, key)
+		if err != nil {
+			return nil
+		}
+		return res
+	case MatchStreamMessage:
+		res, _ := streamMessageLookup(reqInfo, cond)
+		return res
+	case MatchBodyRaw:
+		return string(reqInfo.GetBody())
+	case MatchSNI:
+		if tr, ok := reqInfo.(TLSRequestInfo); ok && tr.GetServerName() != "" {
+			return tr.GetServerName()
+		}
+		return nil
+	default:
+		return nil
+	}
+}
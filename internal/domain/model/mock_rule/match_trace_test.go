@@ -0,0 +1,75 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceMatchConsistentWithIsMatch(t *testing.T) {
+	rule := &MockRule{
+		ID:       "rule-1",
+		Name:     "users",
+		Protocol: "http",
+		Status:   RuleStatusActive,
+		MatchConfig: MatchConfig{
+			Logical: "AND",
+			Conditions: []MatchCondition{
+				{Type: "method", Operator: "eq", Value: "POST"},
+				{Type: "path", Operator: "regex", Value: "^/api/v1/users"},
+				{Type: "header", Operator: "eq", Key: "x-env", Value: "test"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		req        RequestInfo
+		status     RuleStatus
+		expected   bool
+		conditions int
+	}{
+		{
+			name:       "all conditions match",
+			req:        NewSyntheticRequest("http", "POST", "/api/v1/users", map[string]string{"X-Env": "test"}, nil, nil),
+			status:     RuleStatusActive,
+			expected:   true,
+			conditions: 3,
+		},
+		{
+			name:       "header mismatch",
+			req:        NewSyntheticRequest("http", "POST", "/api/v1/users", map[string]string{"X-Env": "prod"}, nil, nil),
+			status:     RuleStatusActive,
+			expected:   false,
+			conditions: 3,
+		},
+		{
+			name:       "protocol mismatch",
+			req:        NewSyntheticRequest("grpc", "POST", "/api/v1/users", nil, nil, nil),
+			status:     RuleStatusActive,
+			expected:   false,
+			conditions: 0,
+		},
+		{
+			name:       "inactive rule",
+			req:        NewSyntheticRequest("http", "POST", "/api/v1/users", map[string]string{"X-Env": "test"}, nil, nil),
+			status:     RuleStatusInactive,
+			expected:   false,
+			conditions: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule.Status = tt.status
+			trace := rule.TraceMatch(context.Background(), tt.req)
+			assert.Equal(t, rule.IsMatch(context.Background(), tt.req), trace.Matched)
+			assert.Equal(t, tt.expected, trace.Matched)
+			assert.Len(t, trace.Conditions, tt.conditions)
+			if !tt.expected {
+				assert.NotEmpty(t, trace.Reason)
+			}
+		})
+	}
+}
@@ -31,11 +31,15 @@ type MatchCondition struct {
 // 2. 为 MatchConfig 实现 GORM 的 Scanner/Valuer 接口
 func (mc *MatchConfig) Scan(value interface{}) error {
 	// 处理数据库读取时的反序列化
-	bytes, ok := value.([]byte)
-	if !ok {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, mc)
+	case string:
+		// SQLite 等驱动会以字符串返回 JSON 列
+		return json.Unmarshal([]byte(v), mc)
+	default:
 		return errors.New("类型转换失败")
 	}
-	return json.Unmarshal(bytes, mc)
 }
 
 func (mc MatchConfig) Value() (driver.Value, error) {
@@ -92,6 +96,19 @@ func (m *MatchConfig) GetMethods() []string {
 	return methods
 }
 
+// ExactConditionValue 返回指定类型的第一个 eq 条件的原始值（不做大小写转换），没有时返回空串
+func (m *MatchConfig) ExactConditionValue(condType string) string {
+	for _, cond := range m.Conditions {
+		if !strings.EqualFold(cond.Type, condType) || !strings.EqualFold(cond.Operator, "eq") {
+			continue
+		}
+		if v, ok := cond.Value.(string); ok {
+			return v
+		}
+	}
+	return ""
+}
+
 func (m *MatchConfig) Match(ctx context.Context, reqInfo RequestInfo) bool { //  参数类型改为 RequestInfo
 	if len(m.Conditions) == 0 {
 		return false
@@ -121,6 +138,14 @@ func (m *MatchConfig) matchCondition(ctx context.Context, reqInfo RequestInfo, c
 		return m.matchHeader(reqInfo, cond)
 	case "body_json":
 		return m.matchBodyJSON(ctx, reqInfo, cond)
+	case MatchQueryParam:
+		return m.matchQueryParam(reqInfo, cond)
+	case MatchStreamMessage:
+		return m.matchStreamMessage(reqInfo, cond)
+	case MatchBodyRaw:
+		return m.matchBodyRaw(reqInfo, cond)
+	case MatchSNI:
+		return m.matchSNI(reqInfo, cond)
 	default:
 		fmt.Printf("Warning: Unknown match type: %s\n", cond.Type)
 		return false // Unknown match type, default to not match
@@ -181,15 +206,54 @@ func (m *MatchConfig) matchHeader(reqInfo RequestInfo, cond MatchCondition) bool
 	case "eq":
 		return reqHeaderValue == ruleHeaderValue
 	case "regex":
-		matched, _ := regexp.MatchString(ruleHeaderValue, ruleHeaderValue) // 忽略错误，正则表达式错误在规则加载时已验证
+		matched, _ := regexp.MatchString(ruleHeaderValue, reqHeaderValue) // 忽略错误，正则表达式错误在规则加载时已验证
 		return matched
 	case "exists":
 		return ok // Header 存在即匹配 (忽略 Value)
+	case OpContains:
+		return strings.Contains(reqHeaderValue, ruleHeaderValue)
 	default: // 默认 Exact 匹配
 		return reqHeaderValue == ruleHeaderValue
 	}
 }
 
+func (m *MatchConfig) matchQueryParam(reqInfo RequestInfo, cond MatchCondition) bool {
+	log := utils.GetLogger()
+	qp, ok := reqInfo.(QueryProvider)
+	if !ok {
+		return false // 协议不支持查询参数
+	}
+	ruleKey, ok := cond.Key.(string)
+	if !ok {
+		log.Warnf("Warning: Invalid rule query_param key type, expect string, got: %T\n", cond.Key)
+		return false
+	}
+
+	values, ok := qp.GetQuery()[ruleKey]
+	if !ok || len(values) == 0 {
+		return false // 参数不存在
+	}
+
+	operator := strings.ToLower(cond.Operator)
+	if operator == OpExists {
+		return true
+	}
+	ruleValue, ok := cond.Value.(string)
+	if !ok {
+		log.Warnf("Warning: Invalid rule query_param value type, expect string, got: %T\n", cond.Value)
+		return false
+	}
+	switch operator {
+	case OpRegex:
+		matched, _ := regexp.MatchString(ruleValue, values[0])
+		return matched
+	case OpContains:
+		return strings.Contains(values[0], ruleValue)
+	default: // 默认 Exact 匹配
+		return values[0] == ruleValue
+	}
+}
+
 func (m *MatchConfig) matchBodyJSON(ctx context.Context, reqInfo RequestInfo, cond MatchCondition) bool {
 	reqBodyJSON, err := reqInfo.GetBodyJSON()
 	if err != nil {
@@ -248,6 +312,110 @@ func (m *MatchConfig) matchBodyJSON(ctx context.Context, reqInfo RequestInfo, co
 	}
 }
 
+// matchBodyRaw 按文本匹配原始请求体，用于非 JSON 内容（如 WebSocket 文本帧），exists 要求请求体非空
+func (m *MatchConfig) matchBodyRaw(reqInfo RequestInfo, cond MatchCondition) bool {
+	body := string(reqInfo.GetBody())
+	operator := strings.ToLower(cond.Operator)
+	if operator == OpExists {
+		return body != ""
+	}
+	ruleValue, ok := cond.Value.(string)
+	if !ok {
+		utils.GetLogger().Warnf("Warning: Invalid rule body_raw value type, expect string, got: %T\n", cond.Value)
+		return false
+	}
+	switch operator {
+	case OpRegex:
+		matched, _ := regexp.MatchString(ruleValue, body)
+		return matched
+	case OpContains:
+		return strings.Contains(body, ruleValue)
+	default: // 默认 Exact 匹配
+		return body == ruleValue
+	}
+}
+
+// matchSNI 按 TLS SNI 主机名匹配，主机名不区分大小写；exists 要求客户端发送了 SNI
+func (m *MatchConfig) matchSNI(reqInfo RequestInfo, cond MatchCondition) bool {
+	tr, ok := reqInfo.(TLSRequestInfo)
+	if !ok || tr.GetServerName() == "" {
+		return false // 非 TLS 请求或未发送 SNI
+	}
+	serverName := strings.ToLower(tr.GetServerName())
+	operator := strings.ToLower(cond.Operator)
+	if operator == OpExists {
+		return true
+	}
+	ruleValue, ok := cond.Value.(string)
+	if !ok {
+		utils.GetLogger().Warnf("Warning: Invalid rule sni value type, expect string, got: %T\n", cond.Value)
+		return false
+	}
+	switch operator {
+	case OpRegex:
+		matched, _ := regexp.MatchString(ruleValue, serverName)
+		return matched
+	case OpContains:
+		return strings.Contains(serverName, strings.ToLower(ruleValue))
+	default: // 默认 Exact 匹配
+		return serverName == strings.ToLower(ruleValue)
+	}
+}
+
+// matchStreamMessage 在入站消息数组上执行 JSONPath，用于按序号匹配流中的第 N 条消息
+// 聚合后的消息体仍使用 body_json 匹配
+func (m *MatchConfig) matchStreamMessage(reqInfo RequestInfo, cond MatchCondition) bool {
+	res, ok := streamMessageLookup(reqInfo, cond)
+	if !ok {
+		return false
+	}
+
+	operator := strings.ToLower(cond.Operator)
+	if operator == OpExists {
+		return true
+	}
+	ruleValue, ok := cond.Value.(string)
+	if !ok {
+		utils.GetLogger().Warnf("Warning: Invalid rule stream_message value type, expect string, got: %T\n", cond.Value)
+		return false
+	}
+	resStr := fmt.Sprint(res)
+	switch operator {
+	case OpRegex:
+		matched, _ := regexp.MatchString(ruleValue, resStr)
+		return matched
+	case OpContains:
+		return strings.Contains(resStr, ruleValue)
+	default: // 默认 Exact 匹配
+		return resStr == ruleValue
+	}
+}
+
+// streamMessageLookup 取出条件 key 指向的值，请求不是流式请求或路径不存在时返回 false
+func streamMessageLookup(reqInfo RequestInfo, cond MatchCondition) (any, bool) {
+	sr, ok := reqInfo.(StreamRequestInfo)
+	if !ok {
+		return nil, false // 非流式请求
+	}
+	path, ok := cond.Key.(string)
+	if !ok {
+		utils.GetLogger().Warnf("Warning: Invalid rule stream_message key type, expect string (JSONPath), got: %T\n", cond.Key)
+		return nil, false
+	}
+	messages, err := sr.GetStreamMessagesJSON()
+	if err != nil {
+		return nil, false
+	}
+	if !strings.HasPrefix(path, "$") {
+		path = "$" + path
+	}
+	res, err := jsonpath.Get(path, messages)
+	if err != nil || res == nil {
+		return nil, false
+	}
+	return res, true
+}
+
 // JsonPathLookup executes a JSONPath query on JSON data
 func JsonPathLookup(jsonData map[string]any, path string) (interface{}, error) {
 	// Ensure path starts with $ root indicator
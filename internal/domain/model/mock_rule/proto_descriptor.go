@@ -0,0 +1,103 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DescriptorSource 描述符的上传形式
+type DescriptorSource string
+
+const (
+	DescriptorSourceSet   DescriptorSource = "descriptor_set" // 二进制 FileDescriptorSet
+	DescriptorSourceProto DescriptorSource = "proto"          // .proto 源文件，服务端编译
+)
+
+var (
+	ErrDescriptorNotFound = errors.New("descriptor not found")
+	ErrDescriptorLink     = errors.New("failed to link descriptors")
+)
+
+// ProtoDescriptorSet gRPC mock 使用的 protobuf 描述符，同名重复上传时版本号递增，最新版本生效
+type ProtoDescriptorSet struct {
+	ID        int64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string           `gorm:"type:varchar(100);not null;uniqueIndex:uk_descriptor_version,priority:1" json:"name"`
+	Version   int              `gorm:"not null;uniqueIndex:uk_descriptor_version,priority:2" json:"version"`
+	Source    DescriptorSource `gorm:"type:varchar(20);not null" json:"source"`
+	Content   []byte           `gorm:"type:longblob;not null" json:"-"` // 序列化的 FileDescriptorSet，.proto 上传时为编译结果
+	Size      int              `gorm:"not null;default:0" json:"size"`
+	Services  []string         `gorm:"type:json;serializer:json" json:"services"` // 定义的服务全名
+	CreatedAt int64            `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (ProtoDescriptorSet) TableName() string {
+	return "mock_proto_descriptors"
+}
+
+// ParseFileDescriptorSet 解析序列化的 FileDescriptorSet，并返回其中定义的服务全名（已排序）
+func ParseFileDescriptorSet(data []byte) (*descriptorpb.FileDescriptorSet, []string, error) {
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, nil, fmt.Errorf("invalid FileDescriptorSet: %w", err)
+	}
+	if len(set.GetFile()) == 0 {
+		return nil, nil, errors.New("FileDescriptorSet contains no files")
+	}
+
+	var services []string
+	for _, fd := range set.GetFile() {
+		for _, svc := range fd.GetService() {
+			name := svc.GetName()
+			if pkg := fd.GetPackage(); pkg != "" {
+				name = pkg + "." + name
+			}
+			services = append(services, name)
+		}
+	}
+	sort.Strings(services)
+	return &set, services, nil
+}
+
+// ResponseValidator 保存规则前校验响应内容是否符合协议约束（如 gRPC 方法的输出消息类型）
+type ResponseValidator interface {
+	ValidateResponse(ctx context.Context, rule *MockRule) error
+}
+
+// StaticResponseBodies 返回规则中可以在保存时校验的响应体（含流式消息）：跳过模板、二进制与返回 gRPC 错误状态的响应
+func StaticResponseBodies(action ActionConfigWrapper) []string {
+	var responses []*ResponseAction
+	switch cfg := action.Config.(type) {
+	case *ResponseAction:
+		responses = append(responses, cfg)
+	case *SequenceAction:
+		responses = append(responses, cfg.Responses...)
+	case *StreamAction:
+		add := func(messages []*StreamMessage) {
+			for _, m := range messages {
+				if m != nil {
+					responses = append(responses, &ResponseAction{Body: m.Body, Template: m.Template})
+				}
+			}
+		}
+		add(cfg.Messages)
+		for _, r := range cfg.Replies {
+			if r != nil {
+				add(r.Messages)
+			}
+		}
+	}
+
+	var bodies []string
+	for _, r := range responses {
+		if r == nil || r.Template || len(r.BodyBytes) > 0 || r.Body == "" || !r.GRPCStatus.IsOK() {
+			continue
+		}
+		bodies = append(bodies, r.Body)
+	}
+	return bodies
+}
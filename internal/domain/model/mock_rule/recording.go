@@ -0,0 +1,177 @@
+package model
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// RecordedExchange 录制模式下捕获的一次请求/响应
+type RecordedExchange struct {
+	Protocol        string            `json:"protocol"`
+	Host            string            `json:"host"`
+	Method          string            `json:"method"`
+	Path            string            `json:"path"`
+	Query           url.Values        `json:"query,omitempty"`
+	RequestHeaders  map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody     []byte            `json:"-"`
+	StatusCode      int               `json:"statusCode"`
+	ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+	ResponseBody    []byte            `json:"-"`
+}
+
+// RecordOptions 控制如何从录制数据生成规则
+type RecordOptions struct {
+	MatchHeaders []string // 需要生成 header 匹配条件的请求头（小写）
+	MatchBody    bool     // 是否为 JSON 请求体的顶层字符串字段生成 body_json 条件
+	Priority     int      // 生成规则的优先级
+}
+
+// 不应被录制到响应中的头
+var skippedResponseHeaders = map[string]bool{
+	"content-length":    true,
+	"content-encoding":  true,
+	"transfer-encoding": true,
+	"connection":        true,
+	"keep-alive":        true,
+	"date":              true,
+}
+
+// BuildRuleFromExchange 将录制的请求/响应转换为 MockRule
+func BuildRuleFromExchange(ex *RecordedExchange, opts RecordOptions) *MockRule {
+	conditions := []MatchCondition{
+		{Type: MatchMethod, Operator: OpEqual, Value: strings.ToUpper(ex.Method)},
+		{Type: MatchPath, Operator: OpEqual, Value: ex.Path},
+	}
+	conditions = append(conditions, queryConditions(ex.Query)...)
+	for _, name := range opts.MatchHeaders {
+		name = strings.ToLower(name)
+		if v, ok := ex.RequestHeaders[name]; ok {
+			conditions = append(conditions, MatchCondition{Type: MatchHeader, Operator: OpEqual, Key: name, Value: v})
+		}
+	}
+	if opts.MatchBody {
+		conditions = append(conditions, bodyConditions(ex.RequestBody)...)
+	}
+
+	headers := make(map[string]string)
+	for k, v := range ex.ResponseHeaders {
+		if skippedResponseHeaders[strings.ToLower(k)] {
+			continue
+		}
+		headers[http.CanonicalHeaderKey(k)] = v
+	}
+	action := &ResponseAction{
+		StatusCode: ex.StatusCode,
+		Headers:    headers,
+	}
+	if utf8.Valid(ex.ResponseBody) {
+		action.Body = string(ex.ResponseBody)
+	} else {
+		action.BodyBytes = ex.ResponseBody
+	}
+
+	protocol := ex.Protocol
+	if protocol == "" {
+		protocol = string(ProtocolHTTP)
+	}
+	var tags RuleTags
+	if ex.Host != "" {
+		// 不同上游的同一接口生成不同的规则，通过标签区分来源
+		tags = RuleTags{strings.ToLower(ex.Host)}
+	}
+	return &MockRule{
+		Name:         truncateName(fmt.Sprintf("rec_%s_%s", strings.ToLower(ex.Method), ex.Path)),
+		Protocol:     protocol,
+		MatchConfig:  MatchConfig{Logical: "AND", Conditions: conditions},
+		ActionConfig: ActionConfigWrapper{AType: ActionTypeResponse, Config: action},
+		Priority:     opts.Priority,
+		Status:       RuleStatusActive,
+		Version:      1,
+		Tags:         tags,
+	}
+}
+
+// Fingerprint 生成用于去重的指纹：相同指纹的录制只会生成一条规则
+// 包含上游 host 与生成匹配条件的查询参数，不同上游或不同查询参数的录制不会合并
+func (ex *RecordedExchange) Fingerprint(opts RecordOptions) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s|%s|%s|%s", ex.Protocol, strings.ToLower(ex.Host), strings.ToUpper(ex.Method), ex.Path)
+	for _, cond := range queryConditions(ex.Query) {
+		fmt.Fprintf(h, "|?%s=%s", cond.Key, cond.Value)
+	}
+
+	names := append([]string(nil), opts.MatchHeaders...)
+	sort.Strings(names)
+	for _, name := range names {
+		name = strings.ToLower(name)
+		fmt.Fprintf(h, "|%s=%s", name, ex.RequestHeaders[name])
+	}
+	if opts.MatchBody {
+		h.Write(ex.RequestBody)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// queryConditions 按参数名排序为每个查询参数的第一个值生成匹配条件
+func queryConditions(query url.Values) []MatchCondition {
+	names := make([]string, 0, len(query))
+	for name, values := range query {
+		if len(values) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	conditions := make([]MatchCondition, 0, len(names))
+	for _, name := range names {
+		conditions = append(conditions, MatchCondition{Type: MatchQueryParam, Operator: OpEqual, Key: name, Value: query.Get(name)})
+	}
+	return conditions
+}
+
+// bodyConditions 为 JSON 请求体中顶层字符串字段生成匹配条件
+func bodyConditions(body []byte) []MatchCondition {
+	req := &SyntheticRequestInfo{body: body}
+	data, err := req.GetBodyJSON()
+	if err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	conditions := make([]MatchCondition, 0, len(keys))
+	for _, k := range keys {
+		if v, ok := data[k].(string); ok {
+			conditions = append(conditions, MatchCondition{Type: "body_json", Operator: OpJsonPath, Key: "$." + k, Value: v})
+		}
+	}
+	return conditions
+}
+
+// truncateName 规则名称最长 50 个字符
+func truncateName(name string) string {
+	if len(name) <= 50 {
+		return name
+	}
+	return name[:50]
+}
+
+// RecordedRule 录制生成的规则（待审核或已保存）
+type RecordedRule struct {
+	ID          string    `json:"id"`
+	Fingerprint string    `json:"fingerprint"`
+	Rule        *MockRule `json:"rule"`
+	Hits        int       `json:"hits"`  // 相同指纹的录制次数
+	Saved       bool      `json:"saved"` // 是否已保存到规则库
+	RecordedAt  int64     `json:"recordedAt"`
+}
@@ -2,9 +2,13 @@ package model
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 )
 
 type MockRuleIface interface {
@@ -22,32 +26,129 @@ type MockRule struct {
 	Protocol     string              `gorm:"type:varchar(20);index:idx_protocol" json:"protocol" redis:"protocol" validate:"required"` // 协议类型标识
 	MatchConfig  MatchConfig         `gorm:"type:json" json:"match" redis:"match" validate:"required"`                                 // 复合匹配条件
 	ActionConfig ActionConfigWrapper `gorm:"type:json" json:"action" redis:"action" validate:"required"`                               // 响应配置
-	Priority     int                 `gorm:"default:0" json:"priority" redis:"priority"`                                               // 匹配优先级
-	Status       RuleStatus          `gorm:"type:varchar(20);index" json:"status" redis:"status"`                                      // 规则状态
+	Priority     int                 `gorm:"default:0;index:idx_priority" json:"priority" redis:"priority"`                            // 匹配优先级
+	Status       RuleStatus          `gorm:"type:varchar(20);index:idx_status" json:"status" redis:"status"`                           // 规则状态
 	Version      int                 `gorm:"default:1" json:"version" redis:"version"`                                                 // 版本控制
-	CreatedAt    int                 `gorm:"createdAt" json:"createdAt" redis:"created_at"`
+	CreatedAt    int                 `gorm:"createdAt;index:idx_created_at" json:"createdAt" redis:"created_at"`
 	UpdatedAt    int                 `gorm:"updatedAt" json:"updatedAt" redis:"updated_at"`
-	Method       string              `gorm:"type:varchar(20)" json:"method"`                       // 请求方法
-	OriginalPath string              `gorm:"type:varchar(255)" json:"original_path"`               // 原始路径（用于展示）
-	PathPattern  string              `gorm:"type:varchar(255)" json:"path_pattern"`                // 路径匹配模式（如 /api/user/:id）
-	L1MatchIndex string              `gorm:"type:varchar(255);index:idx_l1" json:"l1_match_index"` // 标准化后的路径（如 /api/user/*）
-	L2MatchIndex string              `gorm:"type:varchar(255);index:idx_l2" json:"l2_match_index"` // 更宽泛的匹配索引，其他高频条件（如 method+protocol）
+	Method       string              `gorm:"type:varchar(20)" json:"method"`                                // 请求方法
+	OriginalPath string              `gorm:"type:varchar(255)" json:"original_path"`                        // 原始路径（用于展示）
+	PathPattern  string              `gorm:"type:varchar(255)" json:"path_pattern"`                         // 路径匹配模式（如 /api/user/:id）
+	L1MatchIndex string              `gorm:"type:varchar(255);index:idx_l1" json:"l1_match_index"`          // 标准化后的路径（如 /api/user/*）
+	L2MatchIndex string              `gorm:"type:varchar(255);index:idx_l2" json:"l2_match_index"`          // 更宽泛的匹配索引，其他高频条件（如 method+protocol）
+	Tags         RuleTags            `gorm:"type:json" json:"tags,omitempty"`                               // 规则标签（如导入来源）
+	ActiveFrom   int64               `gorm:"default:0" json:"activeFrom,omitempty"`                         // 生效开始时间（Unix 秒），0 表示不限
+	ActiveUntil  int64               `gorm:"default:0;index:idx_active_until" json:"activeUntil,omitempty"` // 生效结束时间（Unix 秒），0 表示不限
+	TTL          int64               `gorm:"column:ttl;default:0" json:"ttl,omitempty"`                     // 存活时长（秒），保存时换算为 activeUntil
+}
+
+// RuleTags 规则标签列表，以 JSON 数组存储
+type RuleTags []string
+
+func (t *RuleTags) Scan(value interface{}) error {
+	var bytes []byte
+	switch v := value.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New("类型转换失败")
+	}
+	if len(bytes) == 0 {
+		*t = nil
+		return nil
+	}
+	return json.Unmarshal(bytes, t)
+}
+
+func (t RuleTags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// HasTag 判断规则是否包含指定标签
+func (t RuleTags) HasTag(tag string) bool {
+	for _, v := range t {
+		if v == tag {
+			return true
+		}
+	}
+	return false
 }
 
 // RuleFilter 定义规则查询的过滤器
 type RuleFilter struct {
-	RuleID          *string // 规则 ID 精确匹配
-	Protocol        *string // 协议类型精确匹配
-	CreatedByUserID *int    // 创建者用户 ID 精确匹配
-	TagIDs          []int   // 标签 ID 列表 (规则需要包含任一标签 ID)
-	IsEnabled       *bool   // 是否启用状态精确匹配
-	PathContains    *string // Path 包含指定字符串 (模糊匹配)
-	L1MatchIndex    *string // L1MatchIndex 精确匹配
+	RuleID          *string     // 规则 ID 精确匹配
+	Protocol        *string     // 协议类型精确匹配
+	CreatedByUserID *int        // 创建者用户 ID 精确匹配
+	TagIDs          []int       // 标签 ID 列表 (规则需要包含任一标签 ID)
+	IsEnabled       *bool       // 是否启用（status 为 active）
+	Status          *RuleStatus // 生命周期状态精确匹配
+	PathContains    *string     // Path 包含指定字符串 (模糊匹配)
+	L1MatchIndex    *string     // L1MatchIndex 精确匹配
+	ExpiredBefore   *int64      // activeUntil 非 0 且不晚于该时间（Unix 秒）
 	// ... 可以根据需求添加更多 Filter 字段 ...
 }
 
+// Accept 判断规则是否满足过滤条件，供内存 / 文件等非 SQL 存储使用
+func (f *RuleFilter) Accept(rule *MockRule) bool {
+	if f == nil {
+		return true
+	}
+	if f.RuleID != nil && rule.ID != *f.RuleID {
+		return false
+	}
+	if f.Protocol != nil && rule.Protocol != *f.Protocol {
+		return false
+	}
+	if f.IsEnabled != nil && (rule.Status == RuleStatusActive) != *f.IsEnabled {
+		return false
+	}
+	if f.Status != nil && rule.Status != *f.Status {
+		return false
+	}
+	if f.PathContains != nil && !strings.Contains(rule.OriginalPath, *f.PathContains) {
+		return false
+	}
+	if f.L1MatchIndex != nil && rule.L1MatchIndex != *f.L1MatchIndex {
+		return false
+	}
+	if f.ExpiredBefore != nil && (rule.ActiveUntil == 0 || rule.ActiveUntil > *f.ExpiredBefore) {
+		return false
+	}
+	return true
+}
+
 func (m *MockRule) Validate() error {
-	// Validate match config
+	if m.ActionConfig.Config == nil {
+		return errors.New("invalid action config: action configuration is missing")
+	}
+	if err := m.ActionConfig.Config.Validate(); err != nil {
+		return fmt.Errorf("invalid action config: %w", err)
+	}
+	if pa, ok := m.ActionConfig.Config.(ProtocolAwareAction); ok {
+		if err := pa.ValidateProtocol(m.Protocol); err != nil {
+			return fmt.Errorf("invalid action config: %w", err)
+		}
+	}
+	if err := m.ResolveMatchIndex(); err != nil {
+		return err
+	}
+	return m.resolveSchedule(time.Now().Unix())
+}
+
+// ResolveMatchIndex 校验匹配条件并计算 method、path 与 L1 索引等派生字段，不涉及动作配置
+func (m *MockRule) ResolveMatchIndex() error {
 	if err := m.MatchConfig.Validate(); err != nil {
 		return fmt.Errorf("invalid match config: %w", err)
 	}
@@ -61,17 +162,60 @@ func (m *MockRule) Validate() error {
 		m.PathPattern = NormalizePath(m.OriginalPath)
 	}
 
-	m.L1MatchIndex = BuildL1MatchIndexKeyFromRule(m)
+	strategy := MatchIndexStrategyFor(m.Protocol)
+	if err := strategy.Validate(m); err != nil {
+		return err
+	}
+	m.L1MatchIndex = strategy.RuleKey(m)
+	return nil
+}
 
+// resolveSchedule 校验生效时间窗口，并将 ttl 换算为 activeUntil（从 activeFrom 或当前时间起算）
+// activeUntil 已设置时不再重新计算，重复校验结果不变
+func (m *MockRule) resolveSchedule(now int64) error {
+	if m.ActiveFrom < 0 || m.ActiveUntil < 0 || m.TTL < 0 {
+		return errors.New("activeFrom, activeUntil and ttl must not be negative")
+	}
+	if m.TTL > 0 && m.ActiveUntil == 0 {
+		start := m.ActiveFrom
+		if start < now {
+			start = now
+		}
+		m.ActiveUntil = start + m.TTL
+	}
+	if m.ActiveUntil > 0 && m.ActiveUntil <= m.ActiveFrom {
+		return fmt.Errorf("activeUntil %d must be after activeFrom %d", m.ActiveUntil, m.ActiveFrom)
+	}
 	return nil
 }
 
+// IsActiveAt 判断规则在 now（Unix 秒）时是否处于生效时间窗口内
+func (m *MockRule) IsActiveAt(now int64) bool {
+	if m.ActiveFrom > 0 && now < m.ActiveFrom {
+		return false
+	}
+	if m.ActiveUntil > 0 && now >= m.ActiveUntil {
+		return false
+	}
+	return true
+}
+
+// IsExpiredAt 判断规则在 now 时是否已过期，过期规则会被清理任务归档
+func (m *MockRule) IsExpiredAt(now int64) bool {
+	return m.ActiveUntil > 0 && now >= m.ActiveUntil
+}
+
 func (m *MockRule) IsMatch(ctx context.Context, requestInfo RequestInfo) bool {
 	// Check if the rule is enabled
 	if m.Status != RuleStatusActive {
 		return false
 	}
 
+	// Check active time window
+	if !m.IsActiveAt(time.Now().Unix()) {
+		return false
+	}
+
 	// Validate protocol match
 	if strings.ToLower(m.Protocol) != strings.ToLower(requestInfo.GetProtocol()) {
 		return false
@@ -97,7 +241,7 @@ func (m *MockRule) ExecuteAction(ctx context.Context, req RequestInfo) (Response
 
 	// 执行具体 Action
 	// start := time.Now()
-	resp, err := m.ActionConfig.Config.Execute(ctx, req)
+	resp, err := m.ActionConfig.Config.Execute(WithRuleID(ctx, m.ID), req)
 	// duration := time.Since(start)
 
 	// // 埋点监控
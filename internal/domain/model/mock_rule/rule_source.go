@@ -0,0 +1,16 @@
+package model
+
+// RuleFileError 规则文件加载失败信息，失败文件保留上一次成功加载的规则
+type RuleFileError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// RuleSourceStatus 文件规则来源的加载状态
+type RuleSourceStatus struct {
+	Dir      string          `json:"dir"`
+	Files    int             `json:"files"`
+	Rules    int             `json:"rules"`
+	LoadedAt int64           `json:"loadedAt"`
+	Errors   []RuleFileError `json:"errors,omitempty"`
+}
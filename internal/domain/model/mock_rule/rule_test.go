@@ -0,0 +1,103 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScheduleTestRule() *MockRule {
+	return &MockRule{
+		ID:       "r1",
+		Protocol: "http",
+		Status:   RuleStatusActive,
+		MatchConfig: MatchConfig{
+			Logical: "AND",
+			Conditions: []MatchCondition{
+				{Type: "method", Operator: "eq", Value: "GET"},
+				{Type: "path", Operator: "eq", Value: "/api/users"},
+			},
+		},
+	}
+}
+
+func TestRuleResolveSchedule(t *testing.T) {
+	rule := newScheduleTestRule()
+	rule.TTL = 60
+	require.NoError(t, rule.resolveSchedule(1000))
+	assert.Equal(t, int64(1060), rule.ActiveUntil)
+	// 已换算后重复校验结果不变
+	require.NoError(t, rule.resolveSchedule(2000))
+	assert.Equal(t, int64(1060), rule.ActiveUntil)
+
+	// ttl 从 activeFrom 起算
+	rule = newScheduleTestRule()
+	rule.ActiveFrom, rule.TTL = 5000, 60
+	require.NoError(t, rule.resolveSchedule(1000))
+	assert.Equal(t, int64(5060), rule.ActiveUntil)
+
+	rule = newScheduleTestRule()
+	rule.ActiveFrom, rule.ActiveUntil = 2000, 1000
+	assert.Error(t, rule.resolveSchedule(0))
+
+	rule = newScheduleTestRule()
+	rule.TTL = -1
+	assert.Error(t, rule.resolveSchedule(0))
+}
+
+func TestRuleActiveWindow(t *testing.T) {
+	rule := newScheduleTestRule()
+	rule.ActiveFrom, rule.ActiveUntil = 100, 200
+	assert.False(t, rule.IsActiveAt(99))
+	assert.True(t, rule.IsActiveAt(100))
+	assert.True(t, rule.IsActiveAt(199))
+	assert.False(t, rule.IsActiveAt(200))
+	assert.True(t, rule.IsExpiredAt(200))
+	assert.False(t, rule.IsExpiredAt(199))
+
+	ctx := context.Background()
+	req := NewSyntheticRequest("http", "GET", "/api/users", nil, nil, nil)
+	now := time.Now().Unix()
+
+	rule = newScheduleTestRule()
+	assert.True(t, rule.IsMatch(ctx, req))
+	rule.ActiveUntil = now - 1
+	assert.False(t, rule.IsMatch(ctx, req))
+	assert.Equal(t, "outside active window", rule.TraceMatch(ctx, req).Reason)
+	rule.ActiveFrom, rule.ActiveUntil = now+3600, 0
+	assert.False(t, rule.IsMatch(ctx, req))
+}
+
+func TestRuleValidateChecksAction(t *testing.T) {
+	grpcRule := func(action Action, aType ActionType) *MockRule {
+		return &MockRule{ID: "g1", Protocol: "grpc", Status: RuleStatusActive,
+			MatchConfig: MatchConfig{Logical: "AND", Conditions: []MatchCondition{
+				{Type: MatchPath, Operator: OpEqual, Value: "user.v1.UserService"},
+				{Type: MatchMethod, Operator: OpEqual, Value: "GetUser"},
+			}},
+			ActionConfig: ActionConfigWrapper{AType: aType, Config: action}}
+	}
+	httpRule := func(action Action, aType ActionType) *MockRule {
+		rule := newScheduleTestRule()
+		rule.ActionConfig = ActionConfigWrapper{AType: aType, Config: action}
+		return rule
+	}
+
+	cases := map[string]*MockRule{
+		"missing action":          newScheduleTestRule(),
+		"http status code":        httpRule(&ResponseAction{StatusCode: 700}, ActionTypeResponse),
+		"http empty sequence":     httpRule(&SequenceAction{}, ActionTypeSequence),
+		"http heartbeat interval": httpRule(&ChunkedAction{Heartbeat: &Heartbeat{}}, ActionTypeChunked),
+		"grpc negative delay":     grpcRule(&StreamAction{Messages: []*StreamMessage{{Delay: -time.Second}}}, ActionTypeStream),
+		"grpc invalid status":     grpcRule(&ResponseAction{GRPCStatus: &GRPCStatus{Code: 99}}, ActionTypeResponse),
+	}
+	for name, rule := range cases {
+		assert.ErrorContains(t, rule.Validate(), "invalid action config", name)
+	}
+
+	assert.NoError(t, httpRule(&ResponseAction{StatusCode: 200}, ActionTypeResponse).Validate())
+	assert.NoError(t, grpcRule(&StreamAction{Messages: []*StreamMessage{{Body: "{}"}}}, ActionTypeStream).Validate())
+}
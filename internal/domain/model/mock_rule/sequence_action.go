@@ -0,0 +1,99 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SequenceAction 按调用次数依次返回不同的响应
+type SequenceAction struct {
+	Responses []*ResponseAction `json:"responses"`
+	Loop      bool              `json:"loop,omitempty"` // 响应用完后是否从头循环，否则一直返回最后一个
+}
+
+// SequenceStore 保存每条规则的调用计数
+type SequenceStore interface {
+	// Next 返回当前计数并加一
+	Next(ruleID string) int64
+	// Peek 返回当前计数，不修改
+	Peek(ruleID string) int64
+	// Reset 清空计数
+	Reset(ruleID string)
+}
+
+var sequenceStore SequenceStore = newMemorySequenceStore()
+
+// SetSequenceStore 替换默认的内存计数存储（例如多实例部署时使用 Redis）
+func SetSequenceStore(store SequenceStore) {
+	sequenceStore = store
+}
+
+func (a *SequenceAction) Validate() error {
+	if len(a.Responses) == 0 {
+		return errors.New("sequence responses cannot be empty")
+	}
+	for i, r := range a.Responses {
+		if r == nil {
+			return fmt.Errorf("sequence responses[%d] is nil", i)
+		}
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("sequence responses[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (a *SequenceAction) Execute(ctx context.Context, req RequestInfo) (ResponseInfo, error) {
+	if len(a.Responses) == 0 {
+		return nil, errors.New("sequence responses cannot be empty")
+	}
+
+	ruleID := RuleIDFromContext(ctx)
+	var count int64
+	if IsDryRun(ctx) {
+		count = sequenceStore.Peek(ruleID)
+	} else {
+		count = sequenceStore.Next(ruleID)
+	}
+
+	idx := int(count)
+	if idx >= len(a.Responses) {
+		if a.Loop {
+			idx %= len(a.Responses)
+		} else {
+			idx = len(a.Responses) - 1
+		}
+	}
+	return a.Responses[idx].Execute(ctx, req)
+}
+
+type memorySequenceStore struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newMemorySequenceStore() *memorySequenceStore {
+	return &memorySequenceStore{counts: make(map[string]int64)}
+}
+
+func (s *memorySequenceStore) Next(ruleID string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := s.counts[ruleID]
+	s.counts[ruleID] = n + 1
+	return n
+}
+
+func (s *memorySequenceStore) Peek(ruleID string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counts[ruleID]
+}
+
+func (s *memorySequenceStore) Reset(ruleID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.counts, ruleID)
+}
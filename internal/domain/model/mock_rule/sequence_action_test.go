@@ -0,0 +1,65 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sequenceBodies(t *testing.T, ctx context.Context, action *SequenceAction, n int) []string {
+	req := NewSyntheticRequest("http", "GET", "/jobs/1", nil, nil, nil)
+	bodies := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		resp, err := action.Execute(ctx, req)
+		require.NoError(t, err)
+		bodies = append(bodies, string(resp.GetBody()))
+	}
+	return bodies
+}
+
+func TestSequenceAction(t *testing.T) {
+	responses := []*ResponseAction{
+		{StatusCode: 202, Body: "pending"},
+		{StatusCode: 200, Body: "done"},
+	}
+
+	t.Run("stays on last response", func(t *testing.T) {
+		ctx := WithRuleID(context.Background(), "seq-last")
+		t.Cleanup(func() { sequenceStore.Reset("seq-last") })
+		action := &SequenceAction{Responses: responses}
+		require.NoError(t, action.Validate())
+		assert.Equal(t, []string{"pending", "done", "done"}, sequenceBodies(t, ctx, action, 3))
+	})
+
+	t.Run("loop", func(t *testing.T) {
+		ctx := WithRuleID(context.Background(), "seq-loop")
+		t.Cleanup(func() { sequenceStore.Reset("seq-loop") })
+		action := &SequenceAction{Responses: responses, Loop: true}
+		assert.Equal(t, []string{"pending", "done", "pending", "done"}, sequenceBodies(t, ctx, action, 4))
+	})
+
+	t.Run("dry run does not advance", func(t *testing.T) {
+		ctx := WithRuleID(context.Background(), "seq-dry")
+		t.Cleanup(func() { sequenceStore.Reset("seq-dry") })
+		action := &SequenceAction{Responses: responses}
+		assert.Equal(t, []string{"pending", "pending"}, sequenceBodies(t, WithDryRun(ctx), action, 2))
+		assert.Equal(t, []string{"pending"}, sequenceBodies(t, ctx, action, 1))
+		assert.Equal(t, []string{"done"}, sequenceBodies(t, WithDryRun(ctx), action, 1))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		ctx := WithRuleID(context.Background(), "seq-reset")
+		t.Cleanup(func() { sequenceStore.Reset("seq-reset") })
+		action := &SequenceAction{Responses: responses}
+		assert.Equal(t, []string{"pending", "done"}, sequenceBodies(t, ctx, action, 2))
+		sequenceStore.Reset("seq-reset")
+		assert.Equal(t, []string{"pending"}, sequenceBodies(t, ctx, action, 1))
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		assert.Error(t, (&SequenceAction{}).Validate())
+		assert.Error(t, (&SequenceAction{Responses: []*ResponseAction{nil}}).Validate())
+	})
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// StreamAction 流式响应（gRPC server / bidi streaming）
+// messages：按顺序发送的消息，每条消息可以单独设置发送前的延迟
+// replies：bidi streaming 的回复脚本，第 i 条入站消息触发 replies[i] 中的消息
+// grpcStatus：server streaming 在消息发送完后以该状态结束流；bidi streaming 在脚本用完（未设置 loop）时结束流
+type StreamAction struct {
+	Headers      map[string]string      `json:"headers,omitempty"`
+	Messages     []*StreamMessage       `json:"messages,omitempty"`
+	Replies      []*StreamReply         `json:"replies,omitempty"`
+	Loop         bool                   `json:"loop,omitempty"` // 入站消息多于 replies 时从头循环，否则不再回复
+	TemplateData map[string]interface{} `json:"templateData,omitempty"`
+	GRPCStatus   *GRPCStatus            `json:"grpcStatus,omitempty"`
+	Trailers     map[string]string      `json:"trailers,omitempty"`
+}
+
+// StreamMessage 流中的单条消息
+type StreamMessage struct {
+	Body     string        `json:"body,omitempty"`
+	Template bool          `json:"template,omitempty"` // 使用当前入站消息渲染模板
+	Delay    time.Duration `json:"delay,omitempty"`    // 发送前等待
+}
+
+// StreamReply 对一条入站消息的回复，messages 为空表示不回复
+type StreamReply struct {
+	Messages   []*StreamMessage `json:"messages"`
+	GRPCStatus *GRPCStatus      `json:"grpcStatus,omitempty"` // 回复后以该状态结束流
+}
+
+func (a *StreamAction) Validate() error {
+	if len(a.Messages) > 0 && len(a.Replies) > 0 {
+		return errors.New("stream messages and replies cannot be used together")
+	}
+	check := func(field string, messages []*StreamMessage) error {
+		for i, m := range messages {
+			if m == nil {
+				return fmt.Errorf("%s[%d] is nil", field, i)
+			}
+			if m.Delay < 0 {
+				return fmt.Errorf("%s[%d].delay must not be negative", field, i)
+			}
+		}
+		return nil
+	}
+	if err := check("stream messages", a.Messages); err != nil {
+		return err
+	}
+	for i, r := range a.Replies {
+		if r == nil {
+			return fmt.Errorf("stream replies[%d] is nil", i)
+		}
+		if err := check(fmt.Sprintf("stream replies[%d].messages", i), r.Messages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute 配置了 replies 时按入站消息序号选择回复，否则返回全部 messages
+func (a *StreamAction) Execute(ctx context.Context, req RequestInfo) (ResponseInfo, error) {
+	messages, st := a.Messages, a.GRPCStatus
+	if len(a.Replies) > 0 {
+		idx := 0
+		if s, ok := req.(StreamRequestInfo); ok && s.GetStreamMessageCount() > 0 {
+			idx = s.GetStreamMessageCount() - 1
+		}
+		if idx >= len(a.Replies) && a.Loop {
+			idx %= len(a.Replies)
+		}
+		messages = nil
+		if idx < len(a.Replies) {
+			messages, st = a.Replies[idx].Messages, a.Replies[idx].GRPCStatus
+		}
+	}
+
+	resp := &StreamResponse{BaseResponse: BaseResponse{headers: a.Headers, grpcStatus: st, trailers: a.Trailers}}
+	for _, m := range messages {
+		r := &ResponseAction{Body: m.Body, Template: m.Template, TemplateData: a.TemplateData, Delay: m.Delay}
+		msg, err := r.Execute(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := msg.GetError(); err != nil {
+			return &StreamResponse{BaseResponse: BaseResponse{err: err}}, nil
+		}
+		resp.messages = append(resp.messages, msg)
+	}
+	return resp, nil
+}
+
+// StreamResponse 流式响应，body 为空，消息通过 GetMessages 获取
+type StreamResponse struct {
+	BaseResponse
+	messages []ResponseInfo
+}
+
+var _ StreamResponseInfo = (*StreamResponse)(nil)
+
+func (r *StreamResponse) GetMessages() []ResponseInfo {
+	return r.messages
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newStreamTestRequest(t *testing.T, streamType GRPCStreamType, names ...string) RequestInfo {
+	var messages []proto.Message
+	for _, name := range names {
+		m, err := structpb.NewStruct(map[string]any{"name": name, "seen_" + name: true})
+		require.NoError(t, err)
+		messages = append(messages, m)
+	}
+	return NewGRPCStreamRequest(context.Background(), "/mock.test.Greeter/Chat", streamType, messages)
+}
+
+func streamBodies(t *testing.T, resp ResponseInfo) []string {
+	var bodies []string
+	for _, m := range resp.(StreamResponseInfo).GetMessages() {
+		bodies = append(bodies, string(m.GetBody()))
+	}
+	return bodies
+}
+
+func TestGRPCStreamRequest(t *testing.T) {
+	// client streaming 的 body 为全部消息合并的结果，同名字段取最后一次的值
+	req := newStreamTestRequest(t, GRPCClientStream, "a", "b")
+	body, err := req.GetBodyJSON()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "b", "seen_a": true, "seen_b": true}, body)
+
+	cond := MatchConfig{Logical: "AND", Conditions: []MatchCondition{
+		{Type: MatchStreamMessage, Operator: OpEqual, Key: "$[0].name", Value: "a"},
+	}}
+	assert.True(t, cond.Match(context.Background(), req))
+	cond.Conditions[0].Key = "$[1].name"
+	assert.False(t, cond.Match(context.Background(), req))
+	cond.Conditions[0].Key = "$[5].name"
+	assert.False(t, cond.Match(context.Background(), req))
+
+	// bidi 的 body 为最后一条消息
+	req = newStreamTestRequest(t, GRPCBidiStream, "a", "b")
+	body, err = req.GetBodyJSON()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "b", "seen_b": true}, body)
+
+	// 非流式请求不匹配 stream_message 条件
+	assert.False(t, cond.Match(context.Background(), &HTTPRequestInfo{}))
+}
+
+func TestStreamActionExecute(t *testing.T) {
+	ctx := context.Background()
+	action := &StreamAction{Replies: []*StreamReply{
+		{Messages: []*StreamMessage{{Body: `{"message": "hi {{.name}}"}`, Template: true}}},
+		{},
+		{Messages: []*StreamMessage{{Body: `a`}, {Body: `b`}}},
+	}}
+	require.NoError(t, action.Validate())
+
+	resp, err := action.Execute(ctx, newStreamTestRequest(t, GRPCBidiStream, "alice"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"message": "hi alice"}`}, streamBodies(t, resp))
+
+	resp, err = action.Execute(ctx, newStreamTestRequest(t, GRPCBidiStream, "1", "2"))
+	require.NoError(t, err)
+	assert.Empty(t, streamBodies(t, resp))
+
+	resp, err = action.Execute(ctx, newStreamTestRequest(t, GRPCBidiStream, "1", "2", "3", "4"))
+	require.NoError(t, err)
+	assert.Empty(t, streamBodies(t, resp), "no loop: script exhausted")
+
+	action.Loop = true
+	resp, err = action.Execute(ctx, newStreamTestRequest(t, GRPCBidiStream, "1", "2", "3", "bob"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"message": "hi bob"}`}, streamBodies(t, resp))
+
+	action = &StreamAction{Messages: []*StreamMessage{{Body: "x"}}, Replies: []*StreamReply{{}}}
+	assert.Error(t, action.Validate())
+}
+
+func TestStreamActionJSON(t *testing.T) {
+	w := ActionConfigWrapper{AType: ActionTypeStream, Config: &StreamAction{
+		Messages: []*StreamMessage{{Body: `{"message": "one"}`, Delay: 100}},
+	}}
+	data, err := json.Marshal(&w)
+	require.NoError(t, err)
+
+	var decoded ActionConfigWrapper
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, w.Config, decoded.Config)
+	assert.Equal(t, []string{`{"message": "one"}`}, StaticResponseBodies(decoded))
+}
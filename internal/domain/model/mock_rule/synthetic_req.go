@@ -0,0 +1,78 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SyntheticRequestInfo 由调用方直接构造的请求（用于 dry-run 等场景），不依赖真实连接
+type SyntheticRequestInfo struct {
+	protocol string
+	method   string
+	path     string
+	headers  map[string]string
+	query    url.Values
+	body     []byte
+}
+
+var _ RequestInfo = (*SyntheticRequestInfo)(nil)
+
+// 创建合成请求的工厂方法，header key 统一转为小写，与 HTTPRequestInfo 保持一致
+func NewSyntheticRequest(protocol, method, path string, headers map[string]string, query map[string]string, body []byte) RequestInfo {
+	h := make(map[string]string, len(headers))
+	for k, v := range headers {
+		h[strings.ToLower(k)] = v
+	}
+	q := url.Values{}
+	for k, v := range query {
+		q.Set(k, v)
+	}
+
+	return &SyntheticRequestInfo{
+		protocol: strings.ToLower(protocol),
+		method:   method,
+		path:     path,
+		headers:  h,
+		query:    q,
+		body:     body,
+	}
+}
+
+func (s *SyntheticRequestInfo) GetProtocol() string {
+	return s.protocol
+}
+
+func (s *SyntheticRequestInfo) GetMethod() string {
+	return s.method
+}
+
+func (s *SyntheticRequestInfo) GetPath() string {
+	return s.path
+}
+
+func (s *SyntheticRequestInfo) GetHeaders() map[string]string {
+	return s.headers
+}
+
+// GetQuery 返回查询参数
+func (s *SyntheticRequestInfo) GetQuery() url.Values {
+	return s.query
+}
+
+func (s *SyntheticRequestInfo) GetBody() []byte {
+	return s.body
+}
+
+func (s *SyntheticRequestInfo) GetBodyJSON() (map[string]any, error) {
+	var result map[string]any
+	if err := json.Unmarshal(s.body, &result); err != nil {
+		return nil, fmt.Errorf("JSON解析失败: %w", err)
+	}
+	return result, nil
+}
+
+func (s *SyntheticRequestInfo) GetMatchIndex() string {
+	return BuildL1MatchIndexKeyFromReq(s)
+}
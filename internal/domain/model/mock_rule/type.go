@@ -1,5 +1,7 @@
 package model
 
+import "errors"
+
 // RuleStatus represents the current state of a mock rule
 type RuleStatus string
 
@@ -23,6 +25,32 @@ func (s RuleStatus) String() string {
 	return string(s)
 }
 
+// ruleStatusTransitions 规则生命周期：draft → active ⇄ inactive → archived，archived 可恢复为 inactive
+var ruleStatusTransitions = map[RuleStatus][]RuleStatus{
+	RuleStatusDraft:    {RuleStatusActive, RuleStatusArchived},
+	RuleStatusActive:   {RuleStatusInactive, RuleStatusArchived},
+	RuleStatusInactive: {RuleStatusActive, RuleStatusArchived},
+	RuleStatusArchived: {RuleStatusInactive},
+}
+
+// CanTransitionTo 判断是否允许从当前状态迁移到目标状态
+func (s RuleStatus) CanTransitionTo(to RuleStatus) bool {
+	for _, next := range ruleStatusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIndexable 只有 active 规则进入匹配索引
+func (s RuleStatus) IsIndexable() bool {
+	return s == RuleStatusActive
+}
+
+// ErrIllegalTransition 非法的状态迁移
+var ErrIllegalTransition = errors.New("illegal rule status transition")
+
 // 匹配类型枚举
 const (
 	MatchPath       = "path"
@@ -31,6 +59,10 @@ const (
 	MatchQueryParam = "query_param"
 	MatchBodyJSON   = "json"
 	MatchBodyRaw    = "body_raw"
+	// MatchStreamMessage key 为作用于入站消息数组的 JSONPath，如 $[1].name 匹配第 2 条消息
+	MatchStreamMessage = "stream_message"
+	// MatchSNI TLS 握手中的 SNI 主机名，非 TLS 请求不匹配
+	MatchSNI = "sni"
 )
 
 // 操作符枚举
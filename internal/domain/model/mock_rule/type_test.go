@@ -0,0 +1,29 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from, to RuleStatus
+		allowed  bool
+	}{
+		{RuleStatusDraft, RuleStatusActive, true},
+		{RuleStatusDraft, RuleStatusInactive, false},
+		{RuleStatusActive, RuleStatusInactive, true},
+		{RuleStatusInactive, RuleStatusActive, true},
+		{RuleStatusActive, RuleStatusArchived, true},
+		{RuleStatusArchived, RuleStatusInactive, true},
+		{RuleStatusArchived, RuleStatusActive, false},
+		{RuleStatusActive, RuleStatusDraft, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to), "%s -> %s", tt.from, tt.to)
+	}
+
+	assert.True(t, RuleStatusActive.IsIndexable())
+	assert.False(t, RuleStatusInactive.IsIndexable())
+}
@@ -0,0 +1,270 @@
+package model
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WebSocketAction WebSocket 脚本，规则按握手请求匹配，连接期间一直使用该规则
+// onConnect：连接建立后依次发送
+// replies：按顺序匹配每个入站帧，使用第一个匹配的回复，没有匹配时不回复
+// pushes：连接期间按间隔周期发送
+// close：连接建立 delay 后由服务端关闭
+type WebSocketAction struct {
+	Headers      map[string]string      `json:"headers,omitempty"` // 握手响应头
+	OnConnect    []*WebSocketMessage    `json:"onConnect,omitempty"`
+	Replies      []*WebSocketReply      `json:"replies,omitempty"`
+	Pushes       []*WebSocketPush       `json:"pushes,omitempty"`
+	Close        *WebSocketClose        `json:"close,omitempty"`
+	TemplateData map[string]interface{} `json:"templateData,omitempty"`
+}
+
+// WebSocketMessage 出站消息，template 使用当前入站帧（须为 JSON）渲染，onConnect 与 pushes 只能使用 templateData
+type WebSocketMessage struct {
+	Type     WebSocketFrameType `json:"type,omitempty"` // text（默认）、json、binary（body 为 base64）
+	Body     string             `json:"body,omitempty"`
+	Template bool               `json:"template,omitempty"`
+	Delay    time.Duration      `json:"delay,omitempty"` // 发送前等待
+}
+
+// WebSocketReply 对入站帧的回复
+type WebSocketReply struct {
+	FrameType WebSocketFrameType  `json:"frameType,omitempty"` // 只匹配该类型的入站帧（text / binary），为空匹配任意帧
+	Match     *MatchConfig        `json:"match,omitempty"`     // 帧内容作为 body 参与 body_raw / body_json 条件，header、path、query 取握手请求；为空匹配任意帧
+	Messages  []*WebSocketMessage `json:"messages,omitempty"`
+	Close     *WebSocketClose     `json:"close,omitempty"` // 回复后关闭连接
+}
+
+// WebSocketPush 周期推送，message.delay 为第一次推送前的等待
+type WebSocketPush struct {
+	Message  *WebSocketMessage `json:"message"`
+	Interval time.Duration     `json:"interval"`
+	Count    int               `json:"count,omitempty"` // 推送次数，0 表示直到连接关闭
+}
+
+// WebSocketClose 服务端发送的关闭帧
+type WebSocketClose struct {
+	Code   int           `json:"code,omitempty"` // 默认 1000
+	Reason string        `json:"reason,omitempty"`
+	Delay  time.Duration `json:"delay,omitempty"`
+}
+
+// WebSocketFrame 渲染后的出站帧
+type WebSocketFrame struct {
+	Binary bool
+	Data   []byte
+	Delay  time.Duration
+}
+
+// WebSocketPushFrame 渲染后的周期推送
+type WebSocketPushFrame struct {
+	Frame    *WebSocketFrame
+	Interval time.Duration
+	Count    int
+}
+
+// CloseCode 未设置时为 1000（正常关闭）
+func (c *WebSocketClose) CloseCode() int {
+	if c.Code == 0 {
+		return 1000
+	}
+	return c.Code
+}
+
+func (c *WebSocketClose) Validate() error {
+	code := c.CloseCode()
+	// 1004、1005、1006、1015 为保留值，不能出现在关闭帧中
+	if code < 1000 || code > 4999 || code == 1004 || code == 1005 || code == 1006 || code == 1015 {
+		return fmt.Errorf("invalid websocket close code %d", code)
+	}
+	if len(c.Reason) > 123 {
+		return errors.New("websocket close reason must not exceed 123 bytes")
+	}
+	if c.Delay < 0 {
+		return errors.New("websocket close delay must not be negative")
+	}
+	return nil
+}
+
+func (m *WebSocketMessage) Validate() error {
+	if m.Delay < 0 {
+		return errors.New("delay must not be negative")
+	}
+	switch m.Type {
+	case "", WebSocketText:
+	case WebSocketJSON:
+		if !m.Template && !json.Valid([]byte(m.Body)) {
+			return errors.New("json message body is not valid JSON")
+		}
+	case WebSocketBinary:
+		if m.Template {
+			return errors.New("binary messages do not support templates")
+		}
+		if _, err := base64.StdEncoding.DecodeString(m.Body); err != nil {
+			return fmt.Errorf("binary message body must be base64: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown websocket message type %q", m.Type)
+	}
+	return nil
+}
+
+func (a *WebSocketAction) Validate() error {
+	check := func(field string, messages []*WebSocketMessage) error {
+		for i, m := range messages {
+			if m == nil {
+				return fmt.Errorf("%s[%d] is nil", field, i)
+			}
+			if err := m.Validate(); err != nil {
+				return fmt.Errorf("%s[%d]: %w", field, i, err)
+			}
+		}
+		return nil
+	}
+	if err := check("websocket onConnect", a.OnConnect); err != nil {
+		return err
+	}
+	for i, r := range a.Replies {
+		if r == nil {
+			return fmt.Errorf("websocket replies[%d] is nil", i)
+		}
+		if r.FrameType != "" && r.FrameType != WebSocketText && r.FrameType != WebSocketBinary {
+			return fmt.Errorf("websocket replies[%d].frameType must be text or binary", i)
+		}
+		if r.Match != nil {
+			if err := r.Match.Validate(); err != nil {
+				return fmt.Errorf("websocket replies[%d].match: %w", i, err)
+			}
+		}
+		if err := check(fmt.Sprintf("websocket replies[%d].messages", i), r.Messages); err != nil {
+			return err
+		}
+		if r.Close != nil {
+			if err := r.Close.Validate(); err != nil {
+				return fmt.Errorf("websocket replies[%d].close: %w", i, err)
+			}
+		}
+	}
+	for i, p := range a.Pushes {
+		if p == nil || p.Message == nil {
+			return fmt.Errorf("websocket pushes[%d].message is required", i)
+		}
+		if p.Interval <= 0 {
+			return fmt.Errorf("websocket pushes[%d].interval must be positive", i)
+		}
+		if p.Count < 0 {
+			return fmt.Errorf("websocket pushes[%d].count must not be negative", i)
+		}
+		if err := p.Message.Validate(); err != nil {
+			return fmt.Errorf("websocket pushes[%d].message: %w", i, err)
+		}
+	}
+	if a.Close != nil {
+		if err := a.Close.Validate(); err != nil {
+			return fmt.Errorf("websocket close: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateProtocol websocket 动作只能用于 websocket 规则
+func (a *WebSocketAction) ValidateProtocol(protocol string) error {
+	if !strings.EqualFold(protocol, string(ProtocolWebSocket)) {
+		return fmt.Errorf("websocket action is only supported for websocket rules, got protocol %q", protocol)
+	}
+	return nil
+}
+
+// Execute 握手请求返回 onConnect、pushes 与 close；入站帧返回第一个匹配的回复
+func (a *WebSocketAction) Execute(ctx context.Context, req RequestInfo) (ResponseInfo, error) {
+	resp := &WebSocketResponse{BaseResponse: BaseResponse{status: http.StatusSwitchingProtocols, headers: a.Headers}}
+	if fi, ok := req.(WebSocketFrameInfo); ok && fi.GetFrameType() != "" {
+		reply := a.matchReply(ctx, req, fi.GetFrameType())
+		if reply == nil {
+			return resp, nil
+		}
+		resp.close = reply.Close
+		return a.render(ctx, req, resp, reply.Messages)
+	}
+
+	resp.close = a.Close
+	for _, p := range a.Pushes {
+		frame, errResp, err := a.renderMessage(ctx, req, p.Message)
+		if err != nil || errResp != nil {
+			return errResp, err
+		}
+		resp.pushes = append(resp.pushes, &WebSocketPushFrame{Frame: frame, Interval: p.Interval, Count: p.Count})
+	}
+	return a.render(ctx, req, resp, a.OnConnect)
+}
+
+func (a *WebSocketAction) matchReply(ctx context.Context, req RequestInfo, frameType WebSocketFrameType) *WebSocketReply {
+	for _, r := range a.Replies {
+		if r.FrameType != "" && r.FrameType != frameType {
+			continue
+		}
+		if r.Match == nil || r.Match.Match(ctx, req) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (a *WebSocketAction) render(ctx context.Context, req RequestInfo, resp *WebSocketResponse, messages []*WebSocketMessage) (ResponseInfo, error) {
+	for _, m := range messages {
+		frame, errResp, err := a.renderMessage(ctx, req, m)
+		if err != nil || errResp != nil {
+			return errResp, err
+		}
+		resp.frames = append(resp.frames, frame)
+	}
+	return resp, nil
+}
+
+// renderMessage 渲染失败时返回带错误的响应，与 StreamAction 一致
+func (a *WebSocketAction) renderMessage(ctx context.Context, req RequestInfo, m *WebSocketMessage) (*WebSocketFrame, ResponseInfo, error) {
+	if m.Type == WebSocketBinary {
+		data, err := base64.StdEncoding.DecodeString(m.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &WebSocketFrame{Binary: true, Data: data, Delay: m.Delay}, nil, nil
+	}
+	r := &ResponseAction{Body: m.Body, Template: m.Template, TemplateData: a.TemplateData, Delay: m.Delay}
+	msg, err := r.Execute(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := msg.GetError(); err != nil {
+		return nil, &WebSocketResponse{BaseResponse: BaseResponse{err: err}}, nil
+	}
+	return &WebSocketFrame{Data: msg.GetBody(), Delay: m.Delay}, nil, nil
+}
+
+// WebSocketResponse WebSocket 脚本执行结果，body 为空
+type WebSocketResponse struct {
+	BaseResponse
+	frames []*WebSocketFrame
+	pushes []*WebSocketPushFrame
+	close  *WebSocketClose
+}
+
+var _ WebSocketResponseInfo = (*WebSocketResponse)(nil)
+
+func (r *WebSocketResponse) GetFrames() []*WebSocketFrame {
+	return r.frames
+}
+
+func (r *WebSocketResponse) GetPushes() []*WebSocketPushFrame {
+	return r.pushes
+}
+
+func (r *WebSocketResponse) GetClose() *WebSocketClose {
+	return r.close
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func frameBodies(resp ResponseInfo) []string {
+	var bodies []string
+	for _, f := range resp.(WebSocketResponseInfo).GetFrames() {
+		bodies = append(bodies, string(f.Data))
+	}
+	return bodies
+}
+
+func TestWebSocketActionExecute(t *testing.T) {
+	ctx := context.Background()
+	var action WebSocketAction
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"headers": {"X-Mock": "ws"},
+		"onConnect": [{"body": "welcome {{.user}}", "template": true}],
+		"replies": [
+			{"match": {"logical": "AND", "conditions": [{"type": "body_json", "operator": "eq", "key": "$.op", "value": "ping"}]},
+			 "messages": [{"type": "json", "body": "{\"op\": \"pong\", \"id\": \"{{.id}}\"}", "template": true}]},
+			{"frameType": "text", "match": {"logical": "AND", "conditions": [{"type": "body_raw", "operator": "eq", "value": "bye"}]},
+			 "messages": [{"body": "see you"}], "close": {"code": 4000, "reason": "done"}},
+			{"frameType": "binary", "messages": [{"type": "binary", "body": "AQID"}]}
+		],
+		"pushes": [{"message": {"body": "tick"}, "interval": 1000000, "count": 3}],
+		"templateData": {"user": "alice"}
+	}`), &action))
+	require.NoError(t, action.Validate())
+	require.NoError(t, action.ValidateProtocol("websocket"))
+	assert.Error(t, action.ValidateProtocol("http"))
+
+	upgrade := NewWebSocketRequest(httptest.NewRequest("GET", "/ws/chat?room=1", nil))
+	assert.Equal(t, "websocket_get_/ws/chat", upgrade.GetMatchIndex())
+
+	resp, err := action.Execute(ctx, upgrade)
+	require.NoError(t, err)
+	assert.Equal(t, 101, resp.GetStatus())
+	assert.Equal(t, []string{"welcome alice"}, frameBodies(resp))
+	pushes := resp.(WebSocketResponseInfo).GetPushes()
+	require.Len(t, pushes, 1)
+	assert.Equal(t, "tick", string(pushes[0].Frame.Data))
+	assert.Equal(t, time.Millisecond, pushes[0].Interval)
+	assert.Nil(t, resp.(WebSocketResponseInfo).GetClose())
+
+	// JSON 帧按 body_json 匹配并渲染模板
+	resp, err = action.Execute(ctx, upgrade.WithFrame(WebSocketText, []byte(`{"op": "ping", "id": "7"}`)))
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"op": "pong", "id": "7"}`}, frameBodies(resp))
+
+	// 文本帧按 body_raw 匹配，回复后关闭
+	resp, err = action.Execute(ctx, upgrade.WithFrame(WebSocketText, []byte("bye")))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"see you"}, frameBodies(resp))
+	assert.Equal(t, 4000, resp.(WebSocketResponseInfo).GetClose().CloseCode())
+
+	// 二进制帧只匹配 frameType 为 binary 的回复
+	resp, err = action.Execute(ctx, upgrade.WithFrame(WebSocketBinary, []byte("bye")))
+	require.NoError(t, err)
+	frames := resp.(WebSocketResponseInfo).GetFrames()
+	require.Len(t, frames, 1)
+	assert.True(t, frames[0].Binary)
+	assert.Equal(t, []byte{1, 2, 3}, frames[0].Data)
+
+	// 没有匹配的回复时不回复
+	resp, err = action.Execute(ctx, upgrade.WithFrame(WebSocketText, []byte("unknown")))
+	require.NoError(t, err)
+	assert.Empty(t, frameBodies(resp))
+}
+
+func TestWebSocketActionValidate(t *testing.T) {
+	cases := map[string]*WebSocketAction{
+		"invalid json body":   {OnConnect: []*WebSocketMessage{{Type: WebSocketJSON, Body: "{"}}},
+		"invalid base64 body": {OnConnect: []*WebSocketMessage{{Type: WebSocketBinary, Body: "%%"}}},
+		"binary template":     {OnConnect: []*WebSocketMessage{{Type: WebSocketBinary, Template: true}}},
+		"unknown type":        {OnConnect: []*WebSocketMessage{{Type: "xml"}}},
+		"reserved close code": {Close: &WebSocketClose{Code: 1006}},
+		"zero push interval":  {Pushes: []*WebSocketPush{{Message: &WebSocketMessage{Body: "x"}}}},
+		"invalid frame type":  {Replies: []*WebSocketReply{{FrameType: WebSocketJSON}}},
+		"invalid reply match": {Replies: []*WebSocketReply{{Match: &MatchConfig{Logical: "XOR"}}}},
+	}
+	for name, action := range cases {
+		assert.Error(t, action.Validate(), name)
+	}
+	assert.NoError(t, (&WebSocketAction{Close: &WebSocketClose{Reason: "bye"}}).Validate())
+}
+
+func TestWebSocketRuleIndex(t *testing.T) {
+	rule := &MockRule{
+		Protocol: string(ProtocolWebSocket),
+		MatchConfig: MatchConfig{Logical: "AND", Conditions: []MatchCondition{
+			{Type: MatchPath, Operator: OpEqual, Value: "/ws/chat"},
+		}},
+		ActionConfig: ActionConfigWrapper{AType: ActionTypeWebSocket, Config: &WebSocketAction{}},
+	}
+	require.NoError(t, rule.Validate())
+	assert.Equal(t, "websocket_get_/ws/chat", rule.L1MatchIndex)
+
+	rule.MatchConfig.Conditions = append(rule.MatchConfig.Conditions, MatchCondition{Type: MatchMethod, Operator: OpEqual, Value: "POST"})
+	assert.Error(t, rule.Validate())
+}
+
+func TestWebSocketRuleValidatesAction(t *testing.T) {
+	rule := &MockRule{
+		Protocol: string(ProtocolWebSocket),
+		MatchConfig: MatchConfig{Logical: "AND", Conditions: []MatchCondition{
+			{Type: MatchPath, Operator: OpEqual, Value: "/ws/chat"},
+		}},
+	}
+	assert.Error(t, rule.Validate())
+
+	var action WebSocketAction
+	require.NoError(t, json.Unmarshal([]byte(`{"pushes": [{"message": {"body": "tick"}}]}`), &action))
+	rule.ActionConfig = ActionConfigWrapper{AType: ActionTypeWebSocket, Config: &action}
+	assert.ErrorContains(t, rule.Validate(), "pushes[0].interval must be positive")
+}
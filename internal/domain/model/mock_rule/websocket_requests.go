@@ -0,0 +1,99 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// WebSocketFrameType WebSocket 帧类型
+type WebSocketFrameType string
+
+const (
+	WebSocketText   WebSocketFrameType = "text"
+	WebSocketJSON   WebSocketFrameType = "json" // 仅用于出站消息：body 必须是 JSON，作为 text 帧发送
+	WebSocketBinary WebSocketFrameType = "binary"
+)
+
+// WebSocketRequestInfo WebSocket 连接上的请求：握手请求用于匹配规则，
+// 之后每个入站帧通过 WithFrame 生成新的请求，header、path、query 仍取自握手请求，body 为帧内容
+type WebSocketRequestInfo struct {
+	path      string
+	headers   map[string]string
+	query     url.Values
+	frameType WebSocketFrameType // 握手请求为空
+	frame     []byte
+}
+
+var (
+	_ QueryProvider      = (*WebSocketRequestInfo)(nil)
+	_ WebSocketFrameInfo = (*WebSocketRequestInfo)(nil)
+)
+
+// 创建 WebSocket 握手请求的工厂方法，header key 统一转为小写，与 HTTPRequestInfo 保持一致
+func NewWebSocketRequest(r *http.Request) *WebSocketRequestInfo {
+	headers := make(map[string]string, len(r.Header))
+	for k, v := range r.Header {
+		headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	return &WebSocketRequestInfo{
+		path:    r.URL.Path,
+		headers: headers,
+		query:   r.URL.Query(),
+	}
+}
+
+// WithFrame 返回携带入站帧的请求，接收者不变
+func (w *WebSocketRequestInfo) WithFrame(frameType WebSocketFrameType, data []byte) *WebSocketRequestInfo {
+	cp := *w
+	cp.frameType = frameType
+	cp.frame = data
+	return &cp
+}
+
+func (w *WebSocketRequestInfo) GetProtocol() string {
+	return string(ProtocolWebSocket)
+}
+
+// GetMethod 握手请求总是 GET
+func (w *WebSocketRequestInfo) GetMethod() string {
+	return http.MethodGet
+}
+
+func (w *WebSocketRequestInfo) GetPath() string {
+	return w.path
+}
+
+func (w *WebSocketRequestInfo) GetHeaders() map[string]string {
+	return w.headers
+}
+
+func (w *WebSocketRequestInfo) GetQuery() url.Values {
+	return w.query
+}
+
+func (w *WebSocketRequestInfo) GetBody() []byte {
+	return w.frame
+}
+
+// GetBodyJSON 握手请求返回空对象，便于 onConnect 消息只使用 templateData 渲染；入站帧必须是 JSON 对象
+func (w *WebSocketRequestInfo) GetBodyJSON() (map[string]any, error) {
+	if w.frameType == "" {
+		return map[string]any{}, nil
+	}
+	var result map[string]any
+	if err := json.Unmarshal(w.frame, &result); err != nil {
+		return nil, fmt.Errorf("JSON解析失败: %w", err)
+	}
+	return result, nil
+}
+
+func (w *WebSocketRequestInfo) GetMatchIndex() string {
+	return BuildL1MatchIndexKeyFromReq(w)
+}
+
+func (w *WebSocketRequestInfo) GetFrameType() WebSocketFrameType {
+	return w.frameType
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/infra/repo"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ChangeSetService 草稿变更集：暂存多条规则变更，预览后原子发布
+type ChangeSetService struct {
+	ruleRepo          repo.RuleRepositoryIface
+	changeSetRepo     repo.ChangeSetRepositoryIface
+	responseValidator model.ResponseValidator
+}
+
+func NewChangeSetService(ruleRepo repo.RuleRepositoryIface, changeSetRepo repo.ChangeSetRepositoryIface) *ChangeSetService {
+	return &ChangeSetService{
+		ruleRepo:      ruleRepo,
+		changeSetRepo: changeSetRepo,
+	}
+}
+
+// SetResponseValidator 设置暂存规则时的响应校验，为 nil 时不校验
+func (s *ChangeSetService) SetResponseValidator(v model.ResponseValidator) {
+	s.responseValidator = v
+}
+
+// CreateChangeSet 创建 open 状态的变更集
+func (s *ChangeSetService) CreateChangeSet(ctx context.Context, name, description string) (*model.ChangeSet, error) {
+	if name == "" {
+		return nil, fmt.Errorf("change set name is required")
+	}
+	cs := &model.ChangeSet{
+		ID:          model.GenerateRuleID("cs", name, strconv.FormatInt(time.Now().UnixNano(), 10)),
+		Name:        name,
+		Description: description,
+		Status:      model.ChangeSetOpen,
+	}
+	if err := s.changeSetRepo.CreateChangeSet(ctx, cs); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+func (s *ChangeSetService) GetChangeSet(ctx context.Context, id string) (*model.ChangeSet, error) {
+	return s.changeSetRepo.GetChangeSet(ctx, id)
+}
+
+func (s *ChangeSetService) ListChangeSets(ctx context.Context, status model.ChangeSetStatus) ([]*model.ChangeSet, error) {
+	return s.changeSetRepo.ListChangeSets(ctx, status)
+}
+
+// StageRule 暂存新增或修改的规则，规则以 draft 状态保存在变更集中，发布后变为 target 状态（默认 active）
+func (s *ChangeSetService) StageRule(ctx context.Context, changeSetID string, rule *model.MockRule, target model.RuleStatus) (*model.ChangeSetItem, error) {
+	if target == "" {
+		target = model.RuleStatusActive
+	}
+	if target != model.RuleStatusActive && target != model.RuleStatusInactive {
+		return nil, fmt.Errorf("target status must be active or inactive, got %s", target)
+	}
+
+	rule.Status = model.RuleStatusDraft
+	if err := validateRule(rule); err != nil {
+		return nil, fmt.Errorf("rule validation failed: %w", err)
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("rule validation failed: %w", err)
+	}
+	if err := validateResponse(ctx, s.responseValidator, rule); err != nil {
+		return nil, fmt.Errorf("rule validation failed: %w", err)
+	}
+	if rule.ID == "" {
+		rule.ID = model.GenerateRuleID("cs", changeSetID, rule.Protocol, rule.Name)
+	}
+
+	item := &model.ChangeSetItem{
+		ChangeSetID:  changeSetID,
+		RuleID:       rule.ID,
+		Op:           model.ChangeSetOpUpsert,
+		Rule:         rule,
+		TargetStatus: target,
+	}
+	if err := s.changeSetRepo.SaveChangeSetItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// StageDelete 暂存规则删除
+func (s *ChangeSetService) StageDelete(ctx context.Context, changeSetID, ruleID string) (*model.ChangeSetItem, error) {
+	existing, err := s.changeSetRepo.BatchGetRules(ctx, []string{ruleID})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		return nil, fmt.Errorf("rule %s not found", ruleID)
+	}
+
+	item := &model.ChangeSetItem{
+		ChangeSetID: changeSetID,
+		RuleID:      ruleID,
+		Op:          model.ChangeSetOpDelete,
+	}
+	if err := s.changeSetRepo.SaveChangeSetItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Unstage 撤销变更集中某条规则的暂存变更
+func (s *ChangeSetService) Unstage(ctx context.Context, changeSetID, ruleID string) error {
+	return s.changeSetRepo.DeleteChangeSetItem(ctx, changeSetID, ruleID)
+}
+
+// Preview 对样例请求分别按当前规则和发布后的规则进行匹配，返回命中规则的变化
+func (s *ChangeSetService) Preview(ctx context.Context, changeSetID string, samples []model.RequestInfo) (*model.ChangeSetPreview, error) {
+	cs, err := s.changeSetRepo.GetChangeSet(ctx, changeSetID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &model.ChangeSetPreview{
+		ChangeSetID: cs.ID,
+		Samples:     make([]*model.ChangeSetPreviewSample, 0, len(samples)),
+	}
+	for i, req := range samples {
+		matchIndex := req.GetMatchIndex()
+		live, err := s.ruleRepo.GetIndexRule(ctx, matchIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get candidate rules: %w", err)
+		}
+
+		// 发布后的候选：叠加变更集，并排除索引已变化的规则
+		var after []*model.MockRule
+		for _, rule := range model.OverlayChangeSet(live, cs.Items) {
+			if rule.L1MatchIndex == matchIndex {
+				after = append(after, rule)
+			}
+		}
+
+		sample := &model.ChangeSetPreviewSample{
+			Index:      i,
+			MatchIndex: matchIndex,
+			Before:     bestMatchRuleID(ctx, live, req),
+			After:      bestMatchRuleID(ctx, after, req),
+		}
+		sample.Changed = sample.Before != sample.After
+		preview.Samples = append(preview.Samples, sample)
+	}
+	return preview, nil
+}
+
+// Publish 原子发布变更集：规则写入、outbox 事件与历史记录在同一事务中提交
+func (s *ChangeSetService) Publish(ctx context.Context, changeSetID string) (*model.ChangeSet, error) {
+	cs, err := s.changeSetRepo.GetChangeSet(ctx, changeSetID)
+	if err != nil {
+		return nil, err
+	}
+	if cs.Status != model.ChangeSetOpen {
+		return nil, fmt.Errorf("%w: %s is %s", model.ErrChangeSetNotOpen, cs.ID, cs.Status)
+	}
+
+	ids := make([]string, 0, len(cs.Items))
+	for _, item := range cs.Items {
+		ids = append(ids, item.RuleID)
+	}
+	existingRules, err := s.changeSetRepo.BatchGetRules(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current rules: %w", err)
+	}
+	existing := make(map[string]*model.MockRule, len(existingRules))
+	for _, rule := range existingRules {
+		existing[rule.ID] = rule
+	}
+
+	now := int(time.Now().Unix())
+	var upserts []*model.MockRule
+	var deleteIDs []string
+	var histories []*model.RuleHistory
+	for _, item := range cs.Items {
+		old := existing[item.RuleID]
+		switch item.Op {
+		case model.ChangeSetOpUpsert:
+			rule := item.PublishedRule()
+			if rule == nil {
+				return nil, fmt.Errorf("change set item for rule %s has no content", item.RuleID)
+			}
+			if err := rule.Validate(); err != nil {
+				return nil, fmt.Errorf("rule %s validation failed: %w", rule.ID, err)
+			}
+			changeType := model.HistoryChangeCreate
+			rule.Version, rule.CreatedAt = 1, now
+			if old != nil {
+				changeType = model.HistoryChangeUpdate
+				rule.Version, rule.CreatedAt = old.Version+1, old.CreatedAt
+			}
+			rule.UpdatedAt = now
+			upserts = append(upserts, rule)
+			histories = append(histories, &model.RuleHistory{
+				RuleID: rule.ID, Version: rule.Version, ChangeType: changeType,
+				Content: rule, ChangeSetID: cs.ID, CreatedAt: now,
+			})
+		case model.ChangeSetOpDelete:
+			if old == nil {
+				continue // 已被删除
+			}
+			deleteIDs = append(deleteIDs, old.ID)
+			histories = append(histories, &model.RuleHistory{
+				RuleID: old.ID, Version: old.Version, ChangeType: model.HistoryChangeDelete,
+				Content: old, ChangeSetID: cs.ID, CreatedAt: now,
+			})
+		default:
+			return nil, fmt.Errorf("unknown change set op: %s", item.Op)
+		}
+	}
+
+	if err := s.changeSetRepo.PublishChangeSet(ctx, cs.ID, upserts, deleteIDs, histories); err != nil {
+		return nil, err
+	}
+	return s.changeSetRepo.GetChangeSet(ctx, cs.ID)
+}
+
+// Discard 丢弃变更集，暂存的变更不会生效
+func (s *ChangeSetService) Discard(ctx context.Context, changeSetID string) error {
+	return s.changeSetRepo.DiscardChangeSet(ctx, changeSetID)
+}
+
+// RuleHistory 规则变更历史，按时间倒序
+func (s *ChangeSetService) RuleHistory(ctx context.Context, ruleID string) ([]*model.RuleHistory, error) {
+	return s.changeSetRepo.ListRuleHistory(ctx, ruleID)
+}
+
+// bestMatchRuleID 按 Redis 索引的顺序（优先级降序，同优先级 ID 降序）返回第一个命中的规则
+func bestMatchRuleID(ctx context.Context, rules []*model.MockRule, req model.RequestInfo) string {
+	sorted := append([]*model.MockRule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].ID > sorted[j].ID
+	})
+	for _, rule := range sorted {
+		if rule.IsMatch(ctx, req) {
+			return rule.ID
+		}
+	}
+	return ""
+}
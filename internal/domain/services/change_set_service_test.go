@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newChangeSetTestRule(name, path string, priority int) *model.MockRule {
+	return &model.MockRule{
+		Name:     name,
+		Protocol: "http",
+		Priority: priority,
+		MatchConfig: model.MatchConfig{
+			Logical: "AND",
+			Conditions: []model.MatchCondition{
+				{Type: "method", Operator: "eq", Value: "GET"},
+				{Type: "path", Operator: "eq", Value: path},
+			},
+		},
+		ActionConfig: model.ActionConfigWrapper{
+			AType:  model.ActionTypeResponse,
+			Config: &model.ResponseAction{StatusCode: 200, Body: name},
+		},
+	}
+}
+
+func TestChangeSetPublishFlow(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	changeSetRepo, err := repo.NewChangeSetRepository(ruleRepo)
+	require.NoError(t, err)
+	svc := NewChangeSetService(ruleRepo, changeSetRepo)
+
+	live := newChangeSetTestRule("users-v1", "/api/users", 1)
+	live.ID = "users-v1"
+	live.Status = model.RuleStatusActive
+	require.NoError(t, live.Validate())
+	require.NoError(t, ruleRepo.SaveRule(ctx, live))
+
+	cs, err := svc.CreateChangeSet(ctx, "release", "")
+	require.NoError(t, err)
+
+	staged, err := svc.StageRule(ctx, cs.ID, newChangeSetTestRule("users-v2", "/api/users", 5), "")
+	require.NoError(t, err)
+	assert.Equal(t, model.RuleStatusDraft, staged.Rule.Status)
+	_, err = svc.StageDelete(ctx, cs.ID, "users-v1")
+	require.NoError(t, err)
+
+	// 发布前数据面仍命中旧规则
+	req := model.NewSyntheticRequest("http", "GET", "/api/users", nil, nil, nil)
+	matched, err := ruleRepo.FindBestMatchRule(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, matched)
+	assert.Equal(t, "users-v1", matched.ID)
+
+	preview, err := svc.Preview(ctx, cs.ID, []model.RequestInfo{req})
+	require.NoError(t, err)
+	require.Len(t, preview.Samples, 1)
+	assert.Equal(t, "users-v1", preview.Samples[0].Before)
+	assert.Equal(t, staged.RuleID, preview.Samples[0].After)
+	assert.True(t, preview.Samples[0].Changed)
+
+	published, err := svc.Publish(ctx, cs.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ChangeSetPublished, published.Status)
+
+	rules, err := ruleRepo.GetIndexRule(ctx, live.L1MatchIndex)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, staged.RuleID, rules[0].ID)
+	assert.Equal(t, model.RuleStatusActive, rules[0].Status)
+
+	history, err := svc.RuleHistory(ctx, "users-v1")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, model.HistoryChangeDelete, history[0].ChangeType)
+
+	_, err = svc.Publish(ctx, cs.ID)
+	assert.True(t, errors.Is(err, model.ErrChangeSetNotOpen))
+}
+
+func TestChangeSetDiscard(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	changeSetRepo, err := repo.NewChangeSetRepository(ruleRepo)
+	require.NoError(t, err)
+	svc := NewChangeSetService(ruleRepo, changeSetRepo)
+
+	cs, err := svc.CreateChangeSet(ctx, "scratch", "")
+	require.NoError(t, err)
+	staged, err := svc.StageRule(ctx, cs.ID, newChangeSetTestRule("tmp", "/api/tmp", 1), model.RuleStatusInactive)
+	require.NoError(t, err)
+	require.NoError(t, svc.Discard(ctx, cs.ID))
+
+	_, err = ruleRepo.FindByID(ctx, staged.RuleID)
+	assert.Error(t, err)
+	_, err = svc.StageRule(ctx, cs.ID, newChangeSetTestRule("tmp", "/api/tmp", 1), "")
+	assert.True(t, errors.Is(err, model.ErrChangeSetNotOpen))
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/infra/converter"
+	"go_mock_server/internal/infra/repo"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// DescriptorService gRPC mock 描述符管理：上传、版本与删除，并校验 gRPC 规则的响应是否符合方法的输出类型
+type DescriptorService struct {
+	descriptorRepo repo.DescriptorRepositoryIface
+}
+
+var _ model.ResponseValidator = (*DescriptorService)(nil)
+
+func NewDescriptorService(descriptorRepo repo.DescriptorRepositoryIface) *DescriptorService {
+	return &DescriptorService{descriptorRepo: descriptorRepo}
+}
+
+// UploadDescriptorSet 上传二进制 FileDescriptorSet，同名时生成新版本
+func (s *DescriptorService) UploadDescriptorSet(ctx context.Context, name string, data []byte) (*model.ProtoDescriptorSet, error) {
+	return s.save(ctx, name, model.DescriptorSourceSet, data)
+}
+
+// UploadProtoFiles 在进程内编译 .proto 源文件（路径 -> 内容）后保存
+func (s *DescriptorService) UploadProtoFiles(ctx context.Context, name string, files map[string]string) (*model.ProtoDescriptorSet, error) {
+	data, err := converter.CompileProtoFiles(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	return s.save(ctx, name, model.DescriptorSourceProto, data)
+}
+
+func (s *DescriptorService) save(ctx context.Context, name string, source model.DescriptorSource, data []byte) (*model.ProtoDescriptorSet, error) {
+	if name == "" || len(name) > 100 {
+		return nil, fmt.Errorf("descriptor name is required and must be at most 100 characters")
+	}
+	d := &model.ProtoDescriptorSet{Name: name, Source: source, Content: data}
+	if err := s.descriptorRepo.SaveDescriptor(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *DescriptorService) ListDescriptors(ctx context.Context, name string) ([]*model.ProtoDescriptorSet, error) {
+	return s.descriptorRepo.ListDescriptors(ctx, name)
+}
+
+// GetDescriptor version 为 0 时返回最新版本
+func (s *DescriptorService) GetDescriptor(ctx context.Context, name string, version int) (*model.ProtoDescriptorSet, error) {
+	return s.descriptorRepo.GetDescriptor(ctx, name, version)
+}
+
+// DeleteDescriptor version 为 0 时删除全部版本
+func (s *DescriptorService) DeleteDescriptor(ctx context.Context, name string, version int) (int64, error) {
+	return s.descriptorRepo.DeleteDescriptor(ctx, name, version)
+}
+
+// LoadDescriptorSetFiles 加载本地 FileDescriptorSet 文件，不持久化
+func (s *DescriptorService) LoadDescriptorSetFiles(paths []string) error {
+	return s.descriptorRepo.LoadDescriptorSetFiles(paths)
+}
+
+func (s *DescriptorService) FindMethod(fullMethod string) (protoreflect.MethodDescriptor, error) {
+	return s.descriptorRepo.FindMethod(fullMethod)
+}
+
+func (s *DescriptorService) Files() *protoregistry.Files {
+	return s.descriptorRepo.Files()
+}
+
+// Services 返回当前生效的服务及其方法名
+func (s *DescriptorService) Services() map[string][]string {
+	services := make(map[string][]string)
+	s.descriptorRepo.Files().RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		for i := 0; i < fd.Services().Len(); i++ {
+			sd := fd.Services().Get(i)
+			methods := make([]string, 0, sd.Methods().Len())
+			for j := 0; j < sd.Methods().Len(); j++ {
+				methods = append(methods, string(sd.Methods().Get(j).Name()))
+			}
+			sort.Strings(methods)
+			services[string(sd.FullName())] = methods
+		}
+		return true
+	})
+	return services
+}
+
+// ValidateResponse 校验 gRPC 规则的静态响应体能否解码为方法的输出消息
+// 服务或方法不是 eq 条件时无法确定目标方法，跳过校验
+func (s *DescriptorService) ValidateResponse(ctx context.Context, rule *model.MockRule) error {
+	if !strings.EqualFold(rule.Protocol, string(model.ProtocolGRPC)) {
+		return nil
+	}
+	service := rule.MatchConfig.ExactConditionValue(model.MatchPath)
+	method := rule.MatchConfig.ExactConditionValue(model.MatchMethod)
+	if service == "" || method == "" {
+		return nil
+	}
+
+	md, err := s.descriptorRepo.FindMethod("/" + strings.TrimPrefix(service, "/") + "/" + method)
+	if err != nil {
+		return fmt.Errorf("grpc rule targets an unregistered method: %w", err)
+	}
+	for i, body := range model.StaticResponseBodies(rule.ActionConfig) {
+		if err := protojson.Unmarshal([]byte(body), dynamicpb.NewMessage(md.Output())); err != nil {
+			return fmt.Errorf("response body #%d does not fit %s: %w", i, md.Output().FullName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const greeterProto = `syntax = "proto3";
+package mock.test;
+message HelloRequest { string name = 1; }
+message HelloReply { string message = 1; int32 count = 2; }
+service Greeter { rpc SayHello(HelloRequest) returns (HelloReply); }`
+
+func newGRPCTestRule(name, method string, action model.Action) *model.MockRule {
+	return &model.MockRule{
+		ID:       name,
+		Name:     name,
+		Protocol: "grpc",
+		Status:   model.RuleStatusActive,
+		MatchConfig: model.MatchConfig{
+			Logical: "AND",
+			Conditions: []model.MatchCondition{
+				{Type: "path", Operator: "eq", Value: "mock.test.Greeter"},
+				{Type: "method", Operator: "eq", Value: method},
+			},
+		},
+		ActionConfig: model.ActionConfigWrapper{AType: model.ActionTypeResponse, Config: action},
+	}
+}
+
+func TestDescriptorServiceValidatesGRPCRules(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	descriptorRepo, err := repo.NewDescriptorRepository(ruleRepo)
+	require.NoError(t, err)
+	descriptors := NewDescriptorService(descriptorRepo)
+	manage := NewRuleManageService(ruleRepo)
+	manage.SetResponseValidator(descriptors)
+
+	d, err := descriptors.UploadProtoFiles(ctx, "greeter", map[string]string{"mock/test/greeter.proto": greeterProto})
+	require.NoError(t, err)
+	assert.Equal(t, model.DescriptorSourceProto, d.Source)
+	assert.Equal(t, map[string][]string{"mock.test.Greeter": {"SayHello"}}, descriptors.Services())
+
+	_, err = descriptors.UploadProtoFiles(ctx, "broken", map[string]string{"broken.proto": `syntax = "proto3"; message {`})
+	assert.Error(t, err)
+
+	ok := newGRPCTestRule("ok", "SayHello", &model.ResponseAction{Body: `{"message": "hi", "count": 2}`})
+	assert.NoError(t, manage.CreateRule(ctx, ok))
+
+	badField := newGRPCTestRule("bad_field", "SayHello", &model.ResponseAction{Body: `{"greeting": "hi"}`})
+	assert.ErrorContains(t, manage.CreateRule(ctx, badField), "does not fit mock.test.HelloReply")
+
+	badType := newGRPCTestRule("bad_type", "SayHello", &model.SequenceAction{Responses: []*model.ResponseAction{
+		{Body: `{"message": "first"}`},
+		{Body: `{"count": "many"}`},
+	}})
+	badType.ActionConfig.AType = model.ActionTypeSequence
+	assert.ErrorContains(t, manage.CreateRule(ctx, badType), "response body #1")
+
+	unknown := newGRPCTestRule("unknown", "SayGoodbye", &model.ResponseAction{Body: `{}`})
+	assert.ErrorContains(t, manage.CreateRule(ctx, unknown), "unregistered method")
+
+	// 模板响应在运行时渲染，保存时不校验
+	templated := newGRPCTestRule("templated", "SayHello", &model.ResponseAction{Body: `{"message": "{{.name}}", "extra": 1}`, Template: true})
+	assert.NoError(t, manage.CreateRule(ctx, templated))
+
+	// 非 gRPC 规则不受影响
+	httpRule := newChangeSetTestRule("http", "/api/users", 1)
+	httpRule.ID = "http"
+	assert.NoError(t, manage.CreateRule(ctx, httpRule))
+}
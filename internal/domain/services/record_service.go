@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/utils"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RecordService 将录制代理捕获的流量转换为规则，支持去重与保存前审核
+type RecordService struct {
+	ruleService iface.RuleService
+	opts        model.RecordOptions
+	review      bool
+
+	mu      sync.Mutex
+	seen    map[string]*model.RecordedRule // fingerprint -> 录制结果，用于去重
+	pending map[string]*model.RecordedRule // id -> 待审核的录制结果
+}
+
+var _ iface.RecordService = (*RecordService)(nil)
+
+func NewRecordService(ruleService iface.RuleService, config *configs.RecordConfig) *RecordService {
+	return &RecordService{
+		ruleService: ruleService,
+		opts: model.RecordOptions{
+			MatchHeaders: config.MatchHeaders,
+			MatchBody:    config.MatchBody,
+			Priority:     config.Priority,
+		},
+		review:  config.ReviewBeforeSave,
+		seen:    make(map[string]*model.RecordedRule),
+		pending: make(map[string]*model.RecordedRule),
+	}
+}
+
+// Record 处理一次录制，相同指纹的请求只会生成一条规则
+func (s *RecordService) Record(ctx context.Context, ex *model.RecordedExchange) (*model.RecordedRule, error) {
+	fp := ex.Fingerprint(s.opts)
+
+	s.mu.Lock()
+	if recorded, ok := s.seen[fp]; ok {
+		recorded.Hits++
+		result := *recorded
+		s.mu.Unlock()
+		return &result, nil
+	}
+
+	rule := model.BuildRuleFromExchange(ex, s.opts)
+	rule.ID = model.GenerateRuleID("rec", fp)
+	recorded := &model.RecordedRule{
+		ID:          fp[:12],
+		Fingerprint: fp,
+		Rule:        rule,
+		Hits:        1,
+		RecordedAt:  time.Now().Unix(),
+	}
+	s.seen[fp] = recorded
+	if s.review {
+		s.pending[recorded.ID] = recorded
+		result := *recorded
+		s.mu.Unlock()
+		utils.GetLogger().Infof("recorded rule %s pending review", rule.Name)
+		return &result, nil
+	}
+	s.mu.Unlock()
+
+	if err := s.save(ctx, recorded); err != nil {
+		// 保存失败时允许下次录制重试
+		s.mu.Lock()
+		delete(s.seen, fp)
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := *recorded
+	return &result, nil
+}
+
+// ListPending 返回待审核录制结果的副本，按录制时间排序
+// 返回副本是因为 Hits 会被并发的 Record 在锁内修改
+func (s *RecordService) ListPending() []*model.RecordedRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*model.RecordedRule, 0, len(s.pending))
+	for _, r := range s.pending {
+		c := *r
+		result = append(result, &c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RecordedAt < result[j].RecordedAt
+	})
+	return result
+}
+
+// Approve 保存指定的待审核规则，返回成功保存的 ID
+func (s *RecordService) Approve(ctx context.Context, ids []string) ([]string, error) {
+	saved := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s.mu.Lock()
+		recorded, ok := s.pending[id]
+		if ok {
+			delete(s.pending, id)
+		}
+		s.mu.Unlock()
+		if !ok {
+			return saved, fmt.Errorf("pending record %s not found", id)
+		}
+
+		if err := s.save(ctx, recorded); err != nil {
+			s.mu.Lock()
+			s.pending[id] = recorded
+			s.mu.Unlock()
+			return saved, err
+		}
+		saved = append(saved, id)
+	}
+	return saved, nil
+}
+
+// Discard 丢弃指定的待审核规则，被丢弃的指纹可以被重新录制
+func (s *RecordService) Discard(ids []string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, id := range ids {
+		if recorded, ok := s.pending[id]; ok {
+			delete(s.pending, id)
+			delete(s.seen, recorded.Fingerprint)
+			count++
+		}
+	}
+	return count
+}
+
+func (s *RecordService) save(ctx context.Context, recorded *model.RecordedRule) error {
+	if err := s.ruleService.CreateRule(ctx, recorded.Rule); err != nil {
+		return fmt.Errorf("failed to save recorded rule %s: %w", recorded.Rule.Name, err)
+	}
+	s.mu.Lock()
+	recorded.Saved = true
+	s.mu.Unlock()
+	return nil
+}
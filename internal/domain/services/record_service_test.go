@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecordedExchange(path, tenant string) *model.RecordedExchange {
+	return &model.RecordedExchange{
+		Protocol:       "http",
+		Method:         "GET",
+		Path:           path,
+		RequestHeaders: map[string]string{"x-tenant": tenant, "x-request-id": path + tenant},
+		StatusCode:     200,
+		ResponseBody:   []byte(`{"tenant": "` + tenant + `"}`),
+	}
+}
+
+func TestRecordServiceDedupe(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	svc := NewRecordService(NewRuleManageService(ruleRepo), &configs.RecordConfig{MatchHeaders: []string{"X-Tenant"}})
+
+	first, err := svc.Record(ctx, newRecordedExchange("/api/users", "a"))
+	require.NoError(t, err)
+	assert.True(t, first.Saved)
+	assert.Equal(t, 1, first.Hits)
+
+	// 未参与匹配的请求头不影响指纹
+	again, err := svc.Record(ctx, newRecordedExchange("/api/users", "a"))
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+	assert.Equal(t, 2, again.Hits)
+	assert.Equal(t, 1, first.Hits, "returned records are snapshots")
+
+	other, err := svc.Record(ctx, newRecordedExchange("/api/users", "b"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, other.ID)
+
+	saved, err := ruleRepo.FindByID(ctx, first.Rule.ID)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	req := model.NewSyntheticRequest("http", "GET", "/api/users", map[string]string{"X-Tenant": "b"}, nil, nil)
+	matched, err := ruleRepo.FindBestMatchRule(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, matched)
+	assert.Equal(t, other.Rule.ID, matched.ID)
+}
+
+func TestRecordServiceSeparatesHostsAndQueries(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	svc := NewRecordService(NewRuleManageService(ruleRepo), &configs.RecordConfig{})
+
+	record := func(host, rawQuery string) *model.RecordedRule {
+		ex := newRecordedExchange("/api/users", "a")
+		ex.Host = host
+		ex.Query, _ = url.ParseQuery(rawQuery)
+		recorded, err := svc.Record(ctx, ex)
+		require.NoError(t, err)
+		return recorded
+	}
+
+	first := record("users.example.com", "page=1&size=10")
+	assert.Equal(t, first.ID, record("USERS.example.com", "size=10&page=1").ID)
+	assert.NotEqual(t, first.ID, record("legacy.example.com", "page=1&size=10").ID)
+	page2 := record("users.example.com", "page=2&size=10")
+	assert.NotEqual(t, first.ID, page2.ID)
+	assert.Equal(t, model.RuleTags{"users.example.com"}, page2.Rule.Tags)
+
+	req := model.NewSyntheticRequest("http", "GET", "/api/users", nil, map[string]string{"page": "2", "size": "10"}, nil)
+	matched, err := ruleRepo.FindBestMatchRule(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, matched)
+	assert.Equal(t, page2.Rule.ID, matched.ID)
+}
+
+func TestRecordServiceReview(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	svc := NewRecordService(NewRuleManageService(ruleRepo), &configs.RecordConfig{ReviewBeforeSave: true})
+
+	users, err := svc.Record(ctx, newRecordedExchange("/api/users", "a"))
+	require.NoError(t, err)
+	orders, err := svc.Record(ctx, newRecordedExchange("/api/orders", "a"))
+	require.NoError(t, err)
+	assert.False(t, users.Saved)
+
+	pending := svc.ListPending()
+	require.Len(t, pending, 2)
+	rule, err := ruleRepo.FindByID(ctx, users.Rule.ID)
+	assert.True(t, err != nil || rule == nil, "pending records are not saved")
+
+	// 修改返回的列表不影响服务内部状态
+	pending[0].Hits = 100
+	_, err = svc.Record(ctx, newRecordedExchange("/api/users", "a"))
+	require.NoError(t, err)
+	for _, r := range svc.ListPending() {
+		assert.Less(t, r.Hits, 100)
+	}
+
+	saved, err := svc.Approve(ctx, []string{users.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{users.ID}, saved)
+	rule, err = ruleRepo.FindByID(ctx, users.Rule.ID)
+	require.NoError(t, err)
+	require.NotNil(t, rule)
+
+	_, err = svc.Approve(ctx, []string{"missing"})
+	assert.Error(t, err)
+
+	// 丢弃后同一指纹可以重新录制
+	assert.Equal(t, 1, svc.Discard([]string{orders.ID}))
+	assert.Empty(t, svc.ListPending())
+	recorded, err := svc.Record(ctx, newRecordedExchange("/api/orders", "a"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, recorded.Hits)
+	assert.Len(t, svc.ListPending(), 1)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/infra/repo"
+	"time"
+)
+
+// RuleBundleService 规则集快照的导出与导入
+type RuleBundleService struct {
+	ruleRepo repo.RuleRepositoryIface
+}
+
+var _ iface.RuleBundleService = (*RuleBundleService)(nil)
+
+func NewRuleBundleService(ruleRepo repo.RuleRepositoryIface) *RuleBundleService {
+	return &RuleBundleService{
+		ruleRepo: ruleRepo,
+	}
+}
+
+// ExportBundle 导出符合条件的规则快照
+func (s *RuleBundleService) ExportBundle(ctx context.Context, filter *model.RuleFilter) (*model.RuleBundle, error) {
+	rules, err := listAllRules(ctx, s.ruleRepo, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &model.RuleBundle{
+		Version:    model.RuleBundleVersion,
+		ExportedAt: time.Now().Unix(),
+		Rules:      rules,
+	}, nil
+}
+
+// ImportBundle 按模式导入规则快照，全部变更在一个事务中提交；dryRun 时只返回该模式下的导入计划
+func (s *RuleBundleService) ImportBundle(ctx context.Context, bundle *model.RuleBundle, mode model.BundleImportMode, dryRun bool) (*model.BundleImportResult, error) {
+	if !mode.IsValid() {
+		return nil, fmt.Errorf("invalid import mode: %s", mode)
+	}
+	if err := bundle.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	existing, err := listAllRules(ctx, s.ruleRepo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := model.PlanBundleImport(existing, bundle, mode)
+	result := &model.BundleImportResult{
+		Mode:      mode,
+		DryRun:    dryRun,
+		Created:   model.RuleIDs(plan.Create),
+		Updated:   model.RuleIDs(plan.Update),
+		Deleted:   model.RuleIDs(plan.Delete),
+		Conflicts: plan.Conflicts,
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	upserts := append(append([]*model.MockRule{}, plan.Create...), plan.Update...)
+	if err := s.ruleRepo.ApplyRuleChanges(ctx, upserts, result.Deleted); err != nil {
+		return nil, fmt.Errorf("failed to apply bundle: %w", err)
+	}
+	result.Applied = true
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportBundleDryRun(t *testing.T) {
+	ctx := context.Background()
+	ruleRepo := repo.NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	svc := NewRuleBundleService(ruleRepo)
+	for _, id := range []string{"users", "orders", "stale"} {
+		rule := newChangeSetTestRule(id, "/api/"+id, 0)
+		rule.ID = id
+		rule.Status = model.RuleStatusActive
+		require.NoError(t, rule.Validate())
+		require.NoError(t, ruleRepo.SaveRule(ctx, rule))
+	}
+
+	newBundle := func() *model.RuleBundle {
+		users := newChangeSetTestRule("users", "/api/users", 1)
+		users.ID = "users"
+		orders := newChangeSetTestRule("orders", "/api/orders", 1)
+		orders.ID = "orders-v2" // 与已有的 orders 冲突
+		return &model.RuleBundle{Version: model.RuleBundleVersion, Rules: []*model.MockRule{users, orders}}
+	}
+
+	for _, mode := range []model.BundleImportMode{model.BundleImportMerge, model.BundleImportReplace} {
+		preview, err := svc.ImportBundle(ctx, newBundle(), mode, true)
+		require.NoError(t, err)
+		assert.True(t, preview.DryRun)
+		assert.False(t, preview.Applied)
+		stale, err := ruleRepo.FindByID(ctx, "stale")
+		require.NoError(t, err)
+		require.NotNil(t, stale, "dry run must not modify rules")
+
+		if mode == model.BundleImportMerge {
+			assert.Empty(t, preview.Deleted)
+			assert.Equal(t, "skipped", preview.Conflicts[0].Resolution)
+			continue
+		}
+		// 预览与实际 replace 的结果一致
+		assert.ElementsMatch(t, []string{"orders", "stale"}, preview.Deleted)
+		assert.Equal(t, "replaced", preview.Conflicts[0].Resolution)
+		applied, err := svc.ImportBundle(ctx, newBundle(), mode, false)
+		require.NoError(t, err)
+		assert.True(t, applied.Applied)
+		assert.Equal(t, preview.Created, applied.Created)
+		assert.Equal(t, preview.Updated, applied.Updated)
+		assert.ElementsMatch(t, preview.Deleted, applied.Deleted)
+	}
+
+	_, err := svc.ImportBundle(ctx, newBundle(), "dry_run", true)
+	assert.Error(t, err)
+}
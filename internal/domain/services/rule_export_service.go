@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/infra/converter"
+	"go_mock_server/internal/infra/repo"
+)
+
+const exportPageSize = 100
+
+// RuleExportService 将规则导出为外部格式
+type RuleExportService struct {
+	ruleRepo repo.RuleRepositoryIface
+}
+
+var _ iface.RuleExportService = (*RuleExportService)(nil)
+
+func NewRuleExportService(ruleRepo repo.RuleRepositoryIface) *RuleExportService {
+	return &RuleExportService{
+		ruleRepo: ruleRepo,
+	}
+}
+
+// ExportWireMock 导出为 WireMock stub mappings
+func (s *RuleExportService) ExportWireMock(ctx context.Context, filter *model.RuleFilter) (any, *model.ConversionReport, error) {
+	rules, err := listAllRules(ctx, s.ruleRepo, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	mappings, report := converter.ToWireMock(rules)
+	return mappings, report, nil
+}
+
+// ExportPostman 导出为 Postman collection
+func (s *RuleExportService) ExportPostman(ctx context.Context, name string, filter *model.RuleFilter) (any, *model.ConversionReport, error) {
+	rules, err := listAllRules(ctx, s.ruleRepo, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	collection, report := converter.ToPostman(name, rules)
+	return collection, report, nil
+}
+
+// listAllRules 分页读取全部符合条件的规则
+func listAllRules(ctx context.Context, ruleRepo repo.RuleRepositoryIface, filter *model.RuleFilter) ([]*model.MockRule, error) {
+	var rules []*model.MockRule
+	for page := 1; ; page++ {
+		batch, total, err := ruleRepo.ListRulesWithPage(ctx, filter, page, exportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list rules: %w", err)
+		}
+		rules = append(rules, batch...)
+		if len(batch) < exportPageSize || int64(len(rules)) >= total {
+			return rules, nil
+		}
+	}
+}
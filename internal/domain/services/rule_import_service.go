@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go_mock_server/internal/domain/iface"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/infra/converter"
+	"go_mock_server/utils"
+)
+
+// RuleImportService 将外部格式转换为规则并保存
+type RuleImportService struct {
+	ruleService iface.RuleService
+}
+
+var _ iface.RuleImportService = (*RuleImportService)(nil)
+
+func NewRuleImportService(ruleService iface.RuleService) *RuleImportService {
+	return &RuleImportService{
+		ruleService: ruleService,
+	}
+}
+
+// ImportOpenAPI 从 OpenAPI 3 文档导入规则
+func (s *RuleImportService) ImportOpenAPI(ctx context.Context, data []byte, opts model.OpenAPIImportOptions) (*model.ImportResult, error) {
+	rules, report, err := converter.FromOpenAPI(data, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert openapi document: %w", err)
+	}
+	result := s.saveRules(ctx, rules)
+	result.Report = report
+	return result, nil
+}
+
+// ImportHAR 从 HAR 文件导入规则
+func (s *RuleImportService) ImportHAR(ctx context.Context, data []byte, opts model.HARImportOptions) (*model.ImportResult, error) {
+	rules, err := converter.FromHAR(data, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert har file: %w", err)
+	}
+	return s.saveRules(ctx, rules), nil
+}
+
+// ImportWireMock 从 WireMock stub mappings 导入规则
+func (s *RuleImportService) ImportWireMock(ctx context.Context, data []byte) (*model.ImportResult, error) {
+	rules, report, err := converter.FromWireMock(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert wiremock mappings: %w", err)
+	}
+	result := s.saveRules(ctx, rules)
+	result.Report = report
+	return result, nil
+}
+
+// ImportPostman 从 Postman collection 的 example 导入规则
+func (s *RuleImportService) ImportPostman(ctx context.Context, data []byte) (*model.ImportResult, error) {
+	rules, report, err := converter.FromPostman(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert postman collection: %w", err)
+	}
+	result := s.saveRules(ctx, rules)
+	result.Report = report
+	return result, nil
+}
+
+// saveRules 逐条保存规则，单条失败不影响其他规则
+func (s *RuleImportService) saveRules(ctx context.Context, rules []*model.MockRule) *model.ImportResult {
+	result := &model.ImportResult{
+		Total:   len(rules),
+		Created: make([]string, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		if err := s.ruleService.CreateRule(ctx, rule); err != nil {
+			utils.GetLogger().Warnf("import rule %s err: %v", rule.Name, err)
+			result.Failed = append(result.Failed, model.ImportFailure{Name: rule.Name, Error: err.Error()})
+			continue
+		}
+		result.Created = append(result.Created, rule.ID)
+	}
+	return result
+}
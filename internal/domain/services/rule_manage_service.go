@@ -5,10 +5,12 @@ import (
 	"fmt"
 	model "go_mock_server/internal/domain/model/mock_rule"
 	"go_mock_server/internal/infra/repo"
+	"time"
 )
 
 type RuleManageService struct {
-	ruleRepo repo.RuleRepositoryIface
+	ruleRepo          repo.RuleRepositoryIface
+	responseValidator model.ResponseValidator
 }
 
 func NewRuleManageService(ruleRepo repo.RuleRepositoryIface) *RuleManageService {
@@ -17,9 +19,27 @@ func NewRuleManageService(ruleRepo repo.RuleRepositoryIface) *RuleManageService
 	}
 }
 
+// SetResponseValidator 设置保存规则前的响应校验（如 gRPC 规则的响应需符合方法输出类型），为 nil 时不校验
+func (s *RuleManageService) SetResponseValidator(v model.ResponseValidator) {
+	s.responseValidator = v
+}
+
 // CreateRule 创建规则
 func (s *RuleManageService) CreateRule(ctx context.Context, rule *model.MockRule) error {
-	if err := s.validateRule(rule); err != nil {
+	// 新规则默认为草稿，需要显式激活
+	if rule.Status == "" {
+		rule.Status = model.RuleStatusDraft
+	}
+
+	if err := validateRule(rule); err != nil {
+		return fmt.Errorf("rule validation failed: %w", err)
+	}
+
+	// 计算 Method / PathPattern / L1MatchIndex 等派生字段
+	if err := rule.Validate(); err != nil {
+		return fmt.Errorf("rule validation failed: %w", err)
+	}
+	if err := validateResponse(ctx, s.responseValidator, rule); err != nil {
 		return fmt.Errorf("rule validation failed: %w", err)
 	}
 
@@ -32,7 +52,68 @@ func (s *RuleManageService) CreateRule(ctx context.Context, rule *model.MockRule
 	return nil
 }
 
-func (s *RuleManageService) validateRule(rule *model.MockRule) error {
+// TransitionRule 按生命周期迁移规则状态，非 active 规则会被移出匹配索引
+// 从存储读取当前版本，避免基于过期的缓存内容覆盖数据库中更新的规则
+func (s *RuleManageService) TransitionRule(ctx context.Context, ruleID string, to model.RuleStatus) (*model.MockRule, error) {
+	if !to.IsValid() {
+		return nil, fmt.Errorf("invalid rule status: %s", to)
+	}
+	rule, err := s.ruleRepo.GetStoredRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Status == to {
+		return rule, nil
+	}
+	if !rule.Status.CanTransitionTo(to) {
+		return nil, fmt.Errorf("%w: %s -> %s", model.ErrIllegalTransition, rule.Status, to)
+	}
+	if to == model.RuleStatusActive && rule.IsExpiredAt(time.Now().Unix()) {
+		return nil, fmt.Errorf("%w: rule %s expired at %d", model.ErrIllegalTransition, ruleID, rule.ActiveUntil)
+	}
+
+	rule.Status = to
+	rule.Version++
+	rule.UpdatedAt = int(time.Now().Unix())
+	if err := s.ruleRepo.ApplyRuleChanges(ctx, []*model.MockRule{rule}, nil); err != nil {
+		return nil, fmt.Errorf("failed to update rule status: %w", err)
+	}
+	return rule, nil
+}
+
+// ArchiveRule 软删除：归档后规则不再参与匹配，可通过 RestoreRule 恢复
+func (s *RuleManageService) ArchiveRule(ctx context.Context, ruleID string) (*model.MockRule, error) {
+	return s.TransitionRule(ctx, ruleID, model.RuleStatusArchived)
+}
+
+// RestoreRule 恢复已归档规则，恢复后为 inactive，需要显式激活
+func (s *RuleManageService) RestoreRule(ctx context.Context, ruleID string) (*model.MockRule, error) {
+	rule, err := s.ruleRepo.GetStoredRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Status != model.RuleStatusArchived {
+		return nil, fmt.Errorf("%w: rule %s is %s, only archived rules can be restored", model.ErrIllegalTransition, ruleID, rule.Status)
+	}
+	return s.TransitionRule(ctx, ruleID, model.RuleStatusInactive)
+}
+
+// PurgeRule 物理删除规则，只允许删除已归档的规则
+func (s *RuleManageService) PurgeRule(ctx context.Context, ruleID string) error {
+	rule, err := s.ruleRepo.GetStoredRule(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+	if rule.Status != model.RuleStatusArchived {
+		return fmt.Errorf("%w: rule %s must be archived before purge", model.ErrIllegalTransition, ruleID)
+	}
+	if err := s.ruleRepo.DeleteRule(ctx, ruleID); err != nil {
+		return fmt.Errorf("failed to purge rule: %w", err)
+	}
+	return nil
+}
+
+func validateRule(rule *model.MockRule) error {
 	if rule.Protocol == "" {
 		return fmt.Errorf("missing 'protocol' field")
 	}
@@ -51,10 +132,21 @@ func (s *RuleManageService) validateRule(rule *model.MockRule) error {
 		// ...  根据 condition.Type 和 condition.Operator  进行更细致的验证 ...
 	}
 
+	if !rule.Status.IsValid() {
+		return fmt.Errorf("invalid rule status: %s", rule.Status)
+	}
+
 	// Action 配置验证
-	if rule.ActionConfig == nil {
+	if rule.ActionConfig.Config == nil {
 		return fmt.Errorf("action configuration is missing")
 	}
 
 	return nil // 验证通过
 }
+
+func validateResponse(ctx context.Context, v model.ResponseValidator, rule *model.MockRule) error {
+	if v == nil {
+		return nil
+	}
+	return v.ValidateResponse(ctx, rule)
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/repo"
+	"go_mock_server/internal/infra/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitionRuleIgnoresStaleCache(t *testing.T) {
+	ctx := context.Background()
+	cache := storage.NewMemoryRuleCache()
+	ruleRepo := repo.NewRuleRepoImpl(storage.NewMemoryRuleStorage(), cache, nil, configs.DefaultRuleRepoConfig())
+	t.Cleanup(ruleRepo.Close)
+	service := NewRuleManageService(ruleRepo)
+
+	rule := newChangeSetTestRule("users", "/api/users", 1)
+	rule.ID, rule.Status, rule.Version = "r1", model.RuleStatusActive, 1
+	require.NoError(t, rule.Validate())
+	require.NoError(t, ruleRepo.SaveRule(ctx, rule))
+
+	updated := *rule
+	updated.Name, updated.Version = "users v2", 2
+	require.NoError(t, ruleRepo.ApplyRuleChanges(ctx, []*model.MockRule{&updated}, nil))
+	// 缓存落后于数据库
+	stale := *rule
+	require.NoError(t, cache.SetRuleToCache(ctx, &stale))
+
+	got, err := service.TransitionRule(ctx, "r1", model.RuleStatusInactive)
+	require.NoError(t, err)
+	assert.Equal(t, "users v2", got.Name)
+	assert.Equal(t, 3, got.Version)
+
+	stored, err := ruleRepo.GetStoredRule(ctx, "r1")
+	require.NoError(t, err)
+	assert.Equal(t, "users v2", stored.Name)
+	assert.Equal(t, model.RuleStatusInactive, stored.Status)
+}
@@ -2,17 +2,28 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	model "go_mock_server/internal/domain/model/mock_rule"
 	"go_mock_server/internal/infra/repo"
+	"sort"
 )
 
 type RuleMatchService struct {
 	ruleRepo repo.RuleRepositoryIface
 }
 
+func NewRuleMatchService(ruleRepo repo.RuleRepositoryIface) *RuleMatchService {
+	return &RuleMatchService{
+		ruleRepo: ruleRepo,
+	}
+}
+
 func (s *RuleMatchService) MatchRule(ctx context.Context, reqInfo model.RequestInfo) (*model.MockRule, error) {
 	bestMatchRule, err := s.ruleRepo.FindBestMatchRule(ctx, reqInfo)
+	if errors.Is(err, repo.ErrNoMatchingRule) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to find best match rule from repo: %w", err)
 	}
@@ -26,3 +37,44 @@ func (s *RuleMatchService) MatchRule(ctx context.Context, reqInfo model.RequestI
 
 	return nil, nil //  未找到匹配规则
 }
+
+// ExecuteRuleAction 执行规则动作
+func (s *RuleMatchService) ExecuteRuleAction(ctx context.Context, rule *model.MockRule, reqInfo model.RequestInfo) (model.ResponseInfo, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("rule is nil")
+	}
+	return rule.ExecuteAction(ctx, reqInfo)
+}
+
+// DryRunMatch 对合成请求进行匹配评估，返回每个候选规则的评估过程及命中规则的响应
+// 只读操作：不会修改规则或写入任何匹配记录
+func (s *RuleMatchService) DryRunMatch(ctx context.Context, reqInfo model.RequestInfo) (*model.DryRunResult, error) {
+	matchIndex := reqInfo.GetMatchIndex()
+	candidates, err := s.ruleRepo.GetIndexRule(ctx, matchIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candidate rules: %w", err)
+	}
+
+	// 按优先级从高到低排序，与 Redis 索引的 ZRevRange 顺序一致
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Priority > candidates[j].Priority
+	})
+
+	result := &model.DryRunResult{
+		MatchIndex: matchIndex,
+		Traces:     make([]*model.RuleMatchTrace, 0, len(candidates)),
+	}
+	for _, rule := range candidates {
+		trace := rule.TraceMatch(ctx, reqInfo)
+		result.Traces = append(result.Traces, trace)
+		if trace.Matched && result.MatchedRule == nil {
+			result.MatchedRule = rule
+		}
+	}
+
+	if result.MatchedRule != nil {
+		result.Response, result.ActionError = result.MatchedRule.ExecuteAction(model.WithDryRun(ctx), reqInfo)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,168 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	configs "go_mock_server/internal/infra/config"
+
+	"github.com/google/martian/v3/mitm"
+)
+
+const (
+	caCertFile = "ca.crt"
+	caKeyFile  = "ca.key"
+
+	defaultOrganization = "go_mock_server"
+	defaultCAValidity   = 10 * 365 * 24 * time.Hour
+	defaultCertValidity = 30 * 24 * time.Hour
+)
+
+// CertificateAuthority 本地 CA：按 TLS 握手中的 SNI 主机名即时签发证书并缓存
+// 配置了 CADir 时 CA 持久化到目录中，客户端只需信任一次
+type CertificateAuthority struct {
+	cert *x509.Certificate
+	mitm *mitm.Config
+}
+
+// LoadOrCreateCA 从 CADir 加载 CA，不存在时生成并写入；CADir 为空时生成仅在本次运行有效的 CA
+func LoadOrCreateCA(config *configs.HTTPSMockConfig) (*CertificateAuthority, error) {
+	org := config.Organization
+	if org == "" {
+		org = defaultOrganization
+	}
+	caValidity := config.CAValidity
+	if caValidity <= 0 {
+		caValidity = defaultCAValidity
+	}
+
+	var cert *x509.Certificate
+	var key crypto.Signer
+	var err error
+	if config.CADir != "" {
+		cert, key, err = loadCA(config.CADir)
+	}
+	if config.CADir == "" || errors.Is(err, os.ErrNotExist) {
+		cert, key, err = mitm.NewAuthority(org+" Mock CA", org, caValidity)
+		if err == nil && config.CADir != "" {
+			err = saveCA(config.CADir, cert, key)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mc, err := mitm.NewConfig(cert, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init certificate authority: %w", err)
+	}
+	mc.SetOrganization(org)
+	certValidity := config.CertValidity
+	if certValidity <= 0 {
+		certValidity = defaultCertValidity
+	}
+	mc.SetValidity(certValidity)
+	return &CertificateAuthority{cert: cert, mitm: mc}, nil
+}
+
+// loadCA 证书与私钥必须同时存在，只存在其一视为配置错误
+func loadCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	certPEM, certErr := os.ReadFile(filepath.Join(dir, caCertFile))
+	keyPEM, keyErr := os.ReadFile(filepath.Join(dir, caKeyFile))
+	if errors.Is(certErr, os.ErrNotExist) && errors.Is(keyErr, os.ErrNotExist) {
+		return nil, nil, os.ErrNotExist
+	}
+	if err := errors.Join(certErr, keyErr); err != nil {
+		return nil, nil, fmt.Errorf("failed to read certificate authority from %s: %w", dir, err)
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, nil, fmt.Errorf("%s: no PEM certificate found", caCertFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", caCertFile, err)
+	}
+	if !cert.IsCA {
+		return nil, nil, fmt.Errorf("%s is not a CA certificate", caCertFile)
+	}
+
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, nil, fmt.Errorf("%s: no PEM private key found", caKeyFile)
+	}
+	var parsed any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", caKeyFile, err)
+	}
+	key, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: unsupported private key type %T", caKeyFile, parsed)
+	}
+	return cert, key, nil
+}
+
+// saveCA 私钥以 0600 权限写入 PKCS#8 PEM
+func saveCA(dir string, cert *x509.Certificate, key crypto.Signer) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create certificate authority dir %s: %w", dir, err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, caKeyFile), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", caKeyFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, caCertFile), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", caCertFile, err)
+	}
+	return nil
+}
+
+// Certificate CA 证书，客户端需要将其加入信任列表
+func (ca *CertificateAuthority) Certificate() *x509.Certificate {
+	return ca.cert
+}
+
+// CertificatePEM PEM 编码的 CA 证书，用于下载
+func (ca *CertificateAuthority) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+// GetCertificate 按 SNI 签发证书；客户端直接用 IP 访问、未发送 SNI 时按监听地址的 IP 签发
+func (ca *CertificateAuthority) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	host := hello.ServerName
+	if host == "" && hello.Conn != nil {
+		host, _, _ = net.SplitHostPort(hello.Conn.LocalAddr().String())
+	}
+	if host == "" {
+		return nil, errors.New("no server name available to issue a certificate")
+	}
+	return ca.mitm.TLSForHost(host).GetCertificate(hello)
+}
+
+// TLSConfig 使用本地 CA 即时签发证书的服务端 TLS 配置
+func (ca *CertificateAuthority) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: ca.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+}
@@ -0,0 +1,33 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	configs "go_mock_server/internal/infra/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrCreateCA(t *testing.T) {
+	config := &configs.HTTPSMockConfig{CADir: t.TempDir()}
+	ca, err := LoadOrCreateCA(config)
+	require.NoError(t, err)
+	assert.True(t, ca.Certificate().IsCA)
+
+	// 再次启动时复用已持久化的 CA
+	reloaded, err := LoadOrCreateCA(config)
+	require.NoError(t, err)
+	assert.Equal(t, ca.Certificate().Raw, reloaded.Certificate().Raw)
+
+	cert, err := reloaded.GetCertificate(&tls.ClientHelloInfo{ServerName: "api.example.com"})
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate())
+	_, err = leaf.Verify(x509.VerifyOptions{DNSName: "api.example.com", Roots: roots})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,9 @@
+package configs
+
+// GRPCMockConfig gRPC mock 服务配置
+type GRPCMockConfig struct {
+	ListenAddr         string   `json:"listenAddr" yaml:"listenAddr"`                 // gRPC 监听地址，如 :9090
+	DescriptorSetFiles []string `json:"descriptorSetFiles" yaml:"descriptorSetFiles"` // 启动时加载的 FileDescriptorSet 文件（protoc --include_imports -o 生成）
+	DescriptorSetDirs  []string `json:"descriptorSetDirs" yaml:"descriptorSetDirs"`   // 扫描其中的 *.pb / *.protoset / *.desc 文件，通常为 grpc 规则所在目录
+	DisableReflection  bool     `json:"disableReflection" yaml:"disableReflection"`   // 关闭 gRPC server reflection（grpcurl / Postman 服务发现）
+}
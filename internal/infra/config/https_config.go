@@ -0,0 +1,12 @@
+package configs
+
+import "time"
+
+// HTTPSMockConfig HTTPS mock 服务配置
+type HTTPSMockConfig struct {
+	ListenAddr   string        `json:"listenAddr" yaml:"listenAddr"`     // HTTPS 监听地址，如 :8443
+	CADir        string        `json:"caDir" yaml:"caDir"`               // 本地 CA 证书与私钥（ca.crt / ca.key）所在目录，不存在时生成；为空时每次启动生成临时 CA
+	Organization string        `json:"organization" yaml:"organization"` // 签发证书的组织名
+	CAValidity   time.Duration `json:"caValidity" yaml:"caValidity"`     // 新生成 CA 的有效期，默认 10 年
+	CertValidity time.Duration `json:"certValidity" yaml:"certValidity"` // 按主机名签发的证书有效期，默认 30 天，过期后自动重新签发
+}
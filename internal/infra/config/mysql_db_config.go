@@ -5,13 +5,22 @@ import (
 	"time"
 )
 
+const (
+	DatabaseDriverMySQL  = "mysql"
+	DatabaseDriverSQLite = "sqlite"
+)
+
 // DatabaseConfig 数据库基础配置
 type DatabaseConfig struct {
+	Driver   string `yaml:"driver"` // mysql（默认）/ sqlite
+	Path     string `yaml:"path"`   // sqlite 数据库文件路径
 	Host     string `yaml:"host"`
 	Port     int    `yaml:"port"`
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
 	Database string `yaml:"database"`
+
+	AutoMigrate bool `yaml:"autoMigrate"` // 启动时执行未完成的结构迁移
 }
 
 // DatabaseOptionConfig 数据库连接池配置
@@ -24,6 +33,11 @@ type DatabaseOptionConfig struct {
 	SlowThreshold   time.Duration `yaml:"slowThreshold"`
 }
 
+// IsSQLite 是否使用 SQLite 存储
+func (c *DatabaseConfig) IsSQLite() bool {
+	return c.Driver == DatabaseDriverSQLite
+}
+
 // GetDSN 获取数据库连接字符串
 func (c *DatabaseConfig) GetDSN() string {
 	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
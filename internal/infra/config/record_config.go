@@ -0,0 +1,11 @@
+package configs
+
+// RecordConfig 录制代理配置
+type RecordConfig struct {
+	ListenAddr       string            `json:"listenAddr" yaml:"listenAddr"`             // 代理监听地址，如 :8089
+	Upstreams        map[string]string `json:"upstreams" yaml:"upstreams"`               // 请求 Host -> 上游地址，如 api.local -> http://10.0.0.1:8080
+	MatchHeaders     []string          `json:"matchHeaders" yaml:"matchHeaders"`         // 生成 header 匹配条件的请求头
+	MatchBody        bool              `json:"matchBody" yaml:"matchBody"`               // 是否生成 body_json 匹配条件
+	ReviewBeforeSave bool              `json:"reviewBeforeSave" yaml:"reviewBeforeSave"` // 录制结果先进入待审核列表，确认后再保存
+	Priority         int               `json:"priority" yaml:"priority"`                 // 生成规则的优先级
+}
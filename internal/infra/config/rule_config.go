@@ -15,6 +15,8 @@ type RuleConfig struct {
 	DatabaseOptionConfig DatabaseOptionConfig `yaml:"databaseConfig"`
 	RedisConfig          RedisConfig          `yaml:"redis"`
 	RuleRepoConfig       RuleRepoConfig       `yaml:"ruleRepo"`
+	RecordConfig         RecordConfig         `yaml:"record"`
+	RuleSource           RuleSourceConfig     `yaml:"ruleSource"`
 }
 
 // RuleRepoConfig 封装 ruleRepoImpl 的配置参数 (不变)
@@ -26,6 +28,23 @@ type RuleRepoConfig struct {
 	IndexUpdateRetryCount int           `json:"indexUpdateRetryCount" yaml:"indexUpdateRetryCount"`
 	IndexUpdateRetryDelay time.Duration `json:"indexUpdateRetryDelay" yaml:"indexUpdateRetryDelay"`
 	IndexUpdatePoolSize   int           `json:"indexUpdatePoolSize" yaml:"indexUpdatePoolSize"`
+	SyncCacheUpdate       bool          `json:"syncCacheUpdate" yaml:"syncCacheUpdate"`         // 同步更新缓存与索引
+	ReconcileInterval     time.Duration `json:"reconcileInterval" yaml:"reconcileInterval"`     // 索引一致性检查周期，0 表示不定期执行
+	OutboxPollInterval    time.Duration `json:"outboxPollInterval" yaml:"outboxPollInterval"`   // outbox 轮询间隔，默认 1s
+	OutboxBatchSize       int           `json:"outboxBatchSize" yaml:"outboxBatchSize"`         // 每批分发的事件数，默认 100
+	OutboxRetention       time.Duration `json:"outboxRetention" yaml:"outboxRetention"`         // 已完成事件的保留时间，默认 24h
+	ExpireSweepInterval   time.Duration `json:"expireSweepInterval" yaml:"expireSweepInterval"` // 过期规则归档周期，默认 1m，负数表示不执行
+}
+
+// DefaultRuleRepoConfig 内存模式与单元测试使用的默认配置
+func DefaultRuleRepoConfig() *RuleRepoConfig {
+	return &RuleRepoConfig{
+		RedisCacheRetryCount:  1,
+		SaveRuleDBRetryCount:  1,
+		IndexUpdateRetryCount: 1,
+		IndexUpdatePoolSize:   1,
+		SyncCacheUpdate:       true,
+	}
 }
 
 // LoadConfig 加载配置
@@ -80,8 +99,33 @@ func getConfigPath() string {
 
 // validate 验证配置
 func (c *RuleConfig) validate() error {
+	switch c.RuleSource.Type {
+	case "", RuleSourceMySQL:
+	case RuleSourceFile:
+		// 文件模式不依赖数据库
+		if c.RuleSource.Dir == "" {
+			return fmt.Errorf("ruleSource.dir is required for file rule source")
+		}
+		return nil
+	case RuleSourceMemory:
+		return nil
+	default:
+		return fmt.Errorf("unknown rule source type: %s", c.RuleSource.Type)
+	}
+
 	// 验证数据库配置
 	db := c.DatabaseConfig
+	switch db.Driver {
+	case "", DatabaseDriverMySQL:
+	case DatabaseDriverSQLite:
+		// SQLite 单机运行，不需要连接信息与连接池配置
+		if db.Path == "" {
+			return fmt.Errorf("database path is required for sqlite")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown database driver: %s", db.Driver)
+	}
 	if db.Host == "" {
 		return fmt.Errorf("database host is required")
 	}
@@ -0,0 +1,21 @@
+package configs
+
+import "time"
+
+const (
+	RuleSourceMySQL  = "mysql"
+	RuleSourceFile   = "file"
+	RuleSourceMemory = "memory"
+)
+
+// RuleSourceConfig 规则来源配置
+type RuleSourceConfig struct {
+	Type     string        `json:"type" yaml:"type"`         // mysql（默认）/ file / memory
+	Dir      string        `json:"dir" yaml:"dir"`           // file 模式下的规则目录
+	Debounce time.Duration `json:"debounce" yaml:"debounce"` // 文件变更后延迟重新加载的时间，默认 200ms
+}
+
+// IsFile 是否使用文件作为规则来源
+func (c *RuleSourceConfig) IsFile() bool {
+	return c.Type == RuleSourceFile
+}
@@ -0,0 +1,9 @@
+package configs
+
+// WebSocketMockConfig WebSocket mock 服务配置
+type WebSocketMockConfig struct {
+	ListenAddr     string   `json:"listenAddr" yaml:"listenAddr"`         // 独立监听地址，如 :8090；也可以只作为 handler 挂到 HTTP 监听上
+	AllowedOrigins []string `json:"allowedOrigins" yaml:"allowedOrigins"` // 允许握手的 Origin，为空时接受任意来源
+	Subprotocols   []string `json:"subprotocols" yaml:"subprotocols"`     // 服务端支持的子协议，按客户端请求的顺序协商
+	MaxMessageSize int64    `json:"maxMessageSize" yaml:"maxMessageSize"` // 入站帧的最大字节数，0 表示不限制
+}
@@ -0,0 +1,72 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BundleFormat bundle 文件格式
+type BundleFormat string
+
+const (
+	BundleFormatJSON BundleFormat = "json"
+	BundleFormatYAML BundleFormat = "yaml"
+)
+
+// ParseBundleFormat 解析格式参数，为空时默认 JSON
+func ParseBundleFormat(s string) (BundleFormat, error) {
+	switch strings.ToLower(s) {
+	case "", "json":
+		return BundleFormatJSON, nil
+	case "yaml", "yml":
+		return BundleFormatYAML, nil
+	}
+	return "", fmt.Errorf("unsupported bundle format: %s", s)
+}
+
+// EncodeBundle 序列化 bundle
+// YAML 通过 JSON 中转，保证字段名与自定义 JSON 编码（如 action）与 JSON 格式一致
+func EncodeBundle(bundle *model.RuleBundle, format BundleFormat) ([]byte, error) {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+	if format != BundleFormatYAML {
+		return data, nil
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to convert bundle to yaml: %w", err)
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle yaml: %w", err)
+	}
+	return out, nil
+}
+
+// DecodeBundle 反序列化 bundle
+func DecodeBundle(data []byte, format BundleFormat) (*model.RuleBundle, error) {
+	if format == BundleFormatYAML {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse bundle yaml: %w", err)
+		}
+		var err error
+		data, err = json.Marshal(normalizeYAML(doc))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert bundle yaml: %w", err)
+		}
+	}
+
+	bundle := &model.RuleBundle{}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,56 @@
+package converter
+
+import (
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundleYAMLRoundTrip(t *testing.T) {
+	bundle := &model.RuleBundle{
+		Version:    model.RuleBundleVersion,
+		ExportedAt: 1700000000,
+		Rules: []*model.MockRule{{
+			ID:       "rule-1",
+			Name:     "get user",
+			Protocol: "http",
+			Priority: 10,
+			Status:   model.RuleStatusActive,
+			MatchConfig: model.MatchConfig{
+				Logical: "AND",
+				Conditions: []model.MatchCondition{
+					{Type: "method", Operator: "eq", Value: "GET"},
+					{Type: "path", Operator: "eq", Value: "/users/1"},
+				},
+			},
+			ActionConfig: model.ActionConfigWrapper{
+				AType:  model.ActionTypeResponse,
+				Config: &model.ResponseAction{StatusCode: 200, Body: `{"id":1}`},
+			},
+			Tags: model.RuleTags{"fixtures"},
+		}},
+	}
+
+	for _, format := range []BundleFormat{BundleFormatJSON, BundleFormatYAML} {
+		data, err := EncodeBundle(bundle, format)
+		assert.NoError(t, err)
+
+		decoded, err := DecodeBundle(data, format)
+		assert.NoError(t, err)
+		assert.NoError(t, decoded.Validate())
+		if assert.Len(t, decoded.Rules, 1) {
+			rule := decoded.Rules[0]
+			assert.Equal(t, "rule-1", rule.ID)
+			assert.Equal(t, 10, rule.Priority)
+			assert.Equal(t, model.RuleTags{"fixtures"}, rule.Tags)
+			assert.Equal(t, "http_get_/users/*", rule.L1MatchIndex)
+			action, ok := rule.ActionConfig.Config.(*model.ResponseAction)
+			if assert.True(t, ok) {
+				assert.Equal(t, 200, action.StatusCode)
+				assert.Equal(t, `{"id":1}`, action.Body)
+			}
+		}
+	}
+}
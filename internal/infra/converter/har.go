@@ -0,0 +1,247 @@
+package converter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type harFile struct {
+	Log struct {
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harEntry struct {
+	Request  harRequest  `json:"request"`
+	Response harResponse `json:"response"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+}
+
+type harResponse struct {
+	Status  int            `json:"status"`
+	Headers []harNameValue `json:"headers"`
+	Content harContent     `json:"content"`
+}
+
+type harContent struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HAR 中不应写入响应的头（HTTP/2 伪头部与传输相关的头）
+var skippedHARHeaders = map[string]bool{
+	"content-length":    true,
+	"content-encoding":  true,
+	"transfer-encoding": true,
+	"connection":        true,
+	"keep-alive":        true,
+	"date":              true,
+	"set-cookie":        true,
+}
+
+// harGroup 相同匹配条件的 HAR 条目
+type harGroup struct {
+	key        string
+	method     string
+	path       string
+	conditions []model.MatchCondition
+	responses  []*model.ResponseAction
+}
+
+// FromHAR 将 HAR 文件转换为规则
+//
+// 匹配条件为 method + path，以及选定的查询参数和请求头；
+// 匹配条件相同但响应不同的条目会合并为一条 sequence 规则，按录制顺序依次返回。
+func FromHAR(data []byte, opts model.HARImportOptions) ([]*model.MockRule, error) {
+	var har harFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("failed to parse har file: %w", err)
+	}
+
+	hosts := make(map[string]bool, len(opts.Hosts))
+	for _, h := range opts.Hosts {
+		hosts[strings.ToLower(h)] = true
+	}
+
+	groups := make(map[string]*harGroup)
+	order := make([]string, 0)
+	for i, entry := range har.Log.Entries {
+		if entry.Response.Status <= 0 {
+			continue // 请求失败或被取消
+		}
+		u, err := url.Parse(entry.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("entries[%d]: invalid url %q: %w", i, entry.Request.URL, err)
+		}
+		if len(hosts) > 0 && !hosts[strings.ToLower(u.Hostname())] {
+			continue
+		}
+
+		method := strings.ToUpper(entry.Request.Method)
+		path := u.Path
+		if path == "" {
+			path = "/"
+		}
+		conditions := []model.MatchCondition{
+			{Type: model.MatchMethod, Operator: model.OpEqual, Value: method},
+			{Type: model.MatchPath, Operator: model.OpEqual, Value: path},
+		}
+		conditions = append(conditions, harQueryConditions(u.Query(), opts.MatchQuery)...)
+		conditions = append(conditions, harHeaderConditions(entry.Request.Headers, opts.MatchHeaders)...)
+
+		key := conditionsKey(conditions)
+		g, ok := groups[key]
+		if !ok {
+			g = &harGroup{key: key, method: method, path: path, conditions: conditions}
+			groups[key] = g
+			order = append(order, key)
+		}
+
+		resp, err := harResponseAction(entry.Response)
+		if err != nil {
+			return nil, fmt.Errorf("entries[%d]: %w", i, err)
+		}
+		g.responses = append(g.responses, resp)
+	}
+
+	rules := make([]*model.MockRule, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		rule := &model.MockRule{
+			ID:          model.GenerateRuleID("har", opts.Tag, key),
+			Name:        truncate(fmt.Sprintf("har_%s_%s", strings.ToLower(g.method), g.path), 50),
+			Protocol:    string(model.ProtocolHTTP),
+			MatchConfig: model.MatchConfig{Logical: "AND", Conditions: g.conditions},
+			// 条件越多的规则越具体，优先级越高
+			Priority: opts.Priority + len(g.conditions) - 2,
+			Status:   model.RuleStatusActive,
+			Version:  1,
+		}
+		if opts.Tag != "" {
+			rule.Tags = model.RuleTags{opts.Tag}
+		}
+
+		responses := dedupeConsecutive(g.responses)
+		if len(responses) == 1 {
+			rule.ActionConfig = model.ActionConfigWrapper{AType: model.ActionTypeResponse, Config: responses[0]}
+		} else {
+			rule.ActionConfig = model.ActionConfigWrapper{AType: model.ActionTypeSequence, Config: &model.SequenceAction{Responses: responses}}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func harQueryConditions(query url.Values, selected []string) []model.MatchCondition {
+	names := selected
+	if len(names) == 0 {
+		names = make([]string, 0, len(query))
+		for k := range query {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	conditions := make([]model.MatchCondition, 0, len(names))
+	for _, name := range names {
+		if v, ok := query[name]; ok && len(v) > 0 {
+			conditions = append(conditions, model.MatchCondition{Type: model.MatchQueryParam, Operator: model.OpEqual, Key: name, Value: v[0]})
+		}
+	}
+	return conditions
+}
+
+func harHeaderConditions(headers []harNameValue, selected []string) []model.MatchCondition {
+	if len(selected) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(headers))
+	for _, h := range headers {
+		values[strings.ToLower(h.Name)] = h.Value
+	}
+
+	names := make([]string, 0, len(selected))
+	for _, name := range selected {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+
+	conditions := make([]model.MatchCondition, 0, len(names))
+	for _, name := range names {
+		if v, ok := values[name]; ok {
+			conditions = append(conditions, model.MatchCondition{Type: model.MatchHeader, Operator: model.OpEqual, Key: name, Value: v})
+		}
+	}
+	return conditions
+}
+
+func harResponseAction(resp harResponse) (*model.ResponseAction, error) {
+	headers := make(map[string]string)
+	for _, h := range resp.Headers {
+		name := strings.ToLower(h.Name)
+		if strings.HasPrefix(name, ":") || skippedHARHeaders[name] {
+			continue
+		}
+		headers[http.CanonicalHeaderKey(name)] = h.Value
+	}
+	if resp.Content.MimeType != "" {
+		headers["Content-Type"] = resp.Content.MimeType
+	}
+
+	action := &model.ResponseAction{
+		StatusCode: resp.Status,
+		Headers:    headers,
+	}
+	if resp.Content.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(resp.Content.Text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 response content: %w", err)
+		}
+		action.BodyBase64 = resp.Content.Text
+		action.BodyBytes = decoded
+	} else {
+		action.Body = resp.Content.Text
+	}
+	return action, nil
+}
+
+// dedupeConsecutive 合并相邻的相同响应
+func dedupeConsecutive(responses []*model.ResponseAction) []*model.ResponseAction {
+	result := make([]*model.ResponseAction, 0, len(responses))
+	var last []byte
+	for _, r := range responses {
+		b, _ := json.Marshal(r)
+		if last != nil && string(b) == string(last) {
+			continue
+		}
+		result = append(result, r)
+		last = b
+	}
+	return result
+}
+
+func conditionsKey(conditions []model.MatchCondition) string {
+	parts := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		parts = append(parts, fmt.Sprintf("%s:%v=%v", c.Type, c.Key, c.Value))
+	}
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,70 @@
+package converter
+
+import (
+	"context"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleHAR = `{"log": {"entries": [
+	{"request": {"method": "GET", "url": "https://api.example.com/jobs/1?verbose=1",
+		"headers": [{"name": "X-Tenant", "value": "a"}], "queryString": [{"name": "verbose", "value": "1"}]},
+	 "response": {"status": 202, "headers": [{"name": "Content-Length", "value": "7"}, {"name": ":status", "value": "202"}],
+		"content": {"mimeType": "text/plain", "text": "pending"}}},
+	{"request": {"method": "GET", "url": "https://api.example.com/jobs/1?verbose=1", "headers": [{"name": "X-Tenant", "value": "a"}]},
+	 "response": {"status": 202, "content": {"mimeType": "text/plain", "text": "pending"}}},
+	{"request": {"method": "GET", "url": "https://api.example.com/jobs/1?verbose=1", "headers": [{"name": "X-Tenant", "value": "a"}]},
+	 "response": {"status": 200, "content": {"mimeType": "text/plain", "text": "done"}}},
+	{"request": {"method": "GET", "url": "https://api.example.com/jobs/1"},
+	 "response": {"status": 200, "content": {"mimeType": "text/plain", "text": "plain"}}},
+	{"request": {"method": "GET", "url": "https://cdn.example.com/logo.png"},
+	 "response": {"status": 200, "content": {"mimeType": "image/png", "text": "iVBORw0K", "encoding": "base64"}}},
+	{"request": {"method": "GET", "url": "https://api.example.com/cancelled"},
+	 "response": {"status": 0}}
+]}}`
+
+func TestFromHAR(t *testing.T) {
+	rules, err := FromHAR([]byte(sampleHAR), model.HARImportOptions{MatchHeaders: []string{"X-Tenant"}, Tag: "har", Priority: 1})
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	for _, rule := range rules {
+		require.NoError(t, rule.Validate())
+		assert.Equal(t, model.RuleTags{"har"}, rule.Tags)
+	}
+
+	// 相同条件的条目合并为 sequence，相邻的相同响应去重
+	jobs := rules[0]
+	assert.Len(t, jobs.MatchConfig.Conditions, 4)
+	assert.Equal(t, 3, jobs.Priority, "more conditions, higher priority")
+	require.Equal(t, model.ActionTypeSequence, jobs.ActionConfig.AType)
+	seq := jobs.ActionConfig.Config.(*model.SequenceAction)
+	require.Len(t, seq.Responses, 2)
+	assert.Equal(t, "pending", seq.Responses[0].Body)
+	assert.Equal(t, map[string]string{"Content-Type": "text/plain"}, seq.Responses[0].Headers)
+	assert.Equal(t, "done", seq.Responses[1].Body)
+
+	plain := rules[1]
+	assert.Equal(t, 1, plain.Priority)
+	assert.Equal(t, model.ActionTypeResponse, plain.ActionConfig.AType)
+
+	logo := rules[2].ActionConfig.Config.(*model.ResponseAction)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a}, logo.BodyBytes)
+	resp, err := logo.Execute(context.Background(), model.NewSyntheticRequest("http", "GET", "/logo.png", nil, nil, nil))
+	require.NoError(t, err)
+	assert.Equal(t, logo.BodyBytes, resp.GetBody())
+}
+
+func TestFromHARFilters(t *testing.T) {
+	rules, err := FromHAR([]byte(sampleHAR), model.HARImportOptions{Hosts: []string{"CDN.example.com"}})
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "/logo.png", rules[0].MatchConfig.ExactConditionValue(model.MatchPath))
+
+	_, err = FromHAR([]byte(`{"log": {"entries": [{"request": {"method": "GET", "url": "http://a/x"},
+		"response": {"status": 200, "content": {"text": "%%%", "encoding": "base64"}}}]}}`), model.HARImportOptions{})
+	assert.Error(t, err)
+}
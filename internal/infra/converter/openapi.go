@@ -0,0 +1,482 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// 非默认响应码通过该请求头选择
+	OpenAPIStatusHeader = "x-mock-status"
+	// 同一响应码下存在多个 example 时通过该请求头选择
+	OpenAPIExampleHeader = "x-mock-example"
+)
+
+type openAPIDoc struct {
+	OpenAPI    string                     `yaml:"openapi"`
+	Info       openAPIInfo                `yaml:"info"`
+	Paths      map[string]openAPIPathItem `yaml:"paths"`
+	Components openAPIComponents          `yaml:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `yaml:"schemas"`
+}
+
+type openAPIPathItem struct {
+	Parameters []*openAPIParameter `yaml:"parameters"`
+	Get        *openAPIOperation   `yaml:"get"`
+	Put        *openAPIOperation   `yaml:"put"`
+	Post       *openAPIOperation   `yaml:"post"`
+	Delete     *openAPIOperation   `yaml:"delete"`
+	Options    *openAPIOperation   `yaml:"options"`
+	Head       *openAPIOperation   `yaml:"head"`
+	Patch      *openAPIOperation   `yaml:"patch"`
+	Trace      *openAPIOperation   `yaml:"trace"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `yaml:"operationId"`
+	Summary     string                      `yaml:"summary"`
+	Parameters  []*openAPIParameter         `yaml:"parameters"`
+	Responses   map[string]*openAPIResponse `yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Name   string         `yaml:"name"`
+	In     string         `yaml:"in"`
+	Schema *openAPISchema `yaml:"schema"`
+}
+
+type openAPIResponse struct {
+	Description string                       `yaml:"description"`
+	Headers     map[string]*openAPIHeader    `yaml:"headers"`
+	Content     map[string]*openAPIMediaType `yaml:"content"`
+}
+
+type openAPIHeader struct {
+	Schema  *openAPISchema `yaml:"schema"`
+	Example any            `yaml:"example"`
+}
+
+type openAPIMediaType struct {
+	Schema   *openAPISchema             `yaml:"schema"`
+	Example  any                        `yaml:"example"`
+	Examples map[string]*openAPIExample `yaml:"examples"`
+}
+
+type openAPIExample struct {
+	Summary string `yaml:"summary"`
+	Value   any    `yaml:"value"`
+}
+
+type openAPISchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       string                    `yaml:"type"`
+	Format     string                    `yaml:"format"`
+	Example    any                       `yaml:"example"`
+	Default    any                       `yaml:"default"`
+	Enum       []any                     `yaml:"enum"`
+	Properties map[string]*openAPISchema `yaml:"properties"`
+	Items      *openAPISchema            `yaml:"items"`
+	AllOf      []*openAPISchema          `yaml:"allOf"`
+	OneOf      []*openAPISchema          `yaml:"oneOf"`
+	AnyOf      []*openAPISchema          `yaml:"anyOf"`
+}
+
+// operation 方法顺序固定，保证导入结果稳定
+func (p openAPIPathItem) operations() []struct {
+	method string
+	op     *openAPIOperation
+} {
+	all := []struct {
+		method string
+		op     *openAPIOperation
+	}{
+		{"GET", p.Get}, {"PUT", p.Put}, {"POST", p.Post}, {"DELETE", p.Delete},
+		{"OPTIONS", p.Options}, {"HEAD", p.Head}, {"PATCH", p.Patch}, {"TRACE", p.Trace},
+	}
+	result := all[:0]
+	for _, o := range all {
+		if o.op != nil {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// FromOpenAPI 解析 OpenAPI 3 文档（YAML 或 JSON），为每个 operation 的每个响应示例生成一条规则
+//
+// 默认响应码的规则无额外条件；其他响应码需要请求头 x-mock-status 指定，
+// 同一响应码下的多个 example 通过请求头 x-mock-example 选择。
+// 匹配索引无法定位的路径模板记录在报告中并跳过，参见 PathTemplateCondition。
+func FromOpenAPI(data []byte, opts model.OpenAPIImportOptions) ([]*model.MockRule, *model.ConversionReport, error) {
+	var doc openAPIDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse openapi document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, nil, fmt.Errorf("unsupported openapi version %q, expect 3.x", doc.OpenAPI)
+	}
+
+	tag := opts.Tag
+	if tag == "" {
+		tag = doc.Info.Title
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	report := &model.ConversionReport{}
+	var rules []*model.MockRule
+	for _, path := range paths {
+		item := doc.Paths[path]
+		pathCond, err := PathTemplateCondition(path)
+		if err != nil {
+			report.Add(path, "path", err.Error(), true)
+			continue
+		}
+		for _, o := range item.operations() {
+			if pathCond.Operator == model.OpRegex {
+				if names := doc.nonIntegerPathParams(path, item, o.op); len(names) > 0 {
+					report.Add(o.method+" "+path, "path parameters",
+						fmt.Sprintf("%s only match numeric values, the match index treats other values as literal paths", strings.Join(names, ", ")), false)
+				}
+			}
+			opRules, err := doc.operationRules(path, pathCond, o.method, o.op, tag, opts)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s %s: %w", o.method, path, err)
+			}
+			rules = append(rules, opRules...)
+			report.Converted += len(opRules)
+		}
+	}
+	return rules, report, nil
+}
+
+// nonIntegerPathParams 返回未声明为 integer 的路径参数，operation 级参数覆盖 path 级同名参数
+func (doc *openAPIDoc) nonIntegerPathParams(path string, item openAPIPathItem, op *openAPIOperation) []string {
+	types := make(map[string]string)
+	for _, params := range [][]*openAPIParameter{item.Parameters, op.Parameters} {
+		for _, p := range params {
+			if p == nil || p.In != "path" {
+				continue
+			}
+			schema := p.Schema
+			if schema != nil && schema.Ref != "" {
+				schema = doc.resolveRef(schema.Ref)
+			}
+			types[p.Name] = ""
+			if schema != nil {
+				types[p.Name] = schema.Type
+			}
+		}
+	}
+
+	var names []string
+	for _, m := range pathParamRegex.FindAllString(path, -1) {
+		name := strings.Trim(m, "{}")
+		if types[name] != "integer" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (doc *openAPIDoc) operationRules(path string, pathCond model.MatchCondition, method string, op *openAPIOperation, tag string, opts model.OpenAPIImportOptions) ([]*model.MockRule, error) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if _, err := strconv.Atoi(code); err == nil {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	sort.Strings(codes)
+
+	defaultCode := opts.DefaultStatus
+	if _, ok := op.Responses[defaultCode]; !ok {
+		defaultCode = codes[0]
+		for _, c := range codes {
+			if strings.HasPrefix(c, "2") {
+				defaultCode = c
+				break
+			}
+		}
+	}
+
+	name := op.OperationID
+	if name == "" {
+		name = strings.ToLower(method) + "_" + path
+	}
+
+	var rules []*model.MockRule
+	for _, code := range codes {
+		status, _ := strconv.Atoi(code)
+		resp := op.Responses[code]
+		contentType, examples := doc.responseExamples(resp)
+
+		for i, ex := range examples {
+			conditions := []model.MatchCondition{
+				{Type: model.MatchMethod, Operator: model.OpEqual, Value: method},
+				pathCond,
+			}
+			// 越具体的规则优先级越高
+			priority := opts.Priority
+			if code != defaultCode {
+				conditions = append(conditions, model.MatchCondition{Type: model.MatchHeader, Operator: model.OpEqual, Key: OpenAPIStatusHeader, Value: code})
+				priority++
+			}
+			if ex.name != "" && i > 0 {
+				conditions = append(conditions, model.MatchCondition{Type: model.MatchHeader, Operator: model.OpEqual, Key: OpenAPIExampleHeader, Value: ex.name})
+				priority++
+			}
+
+			headers := doc.responseHeaders(resp)
+			if contentType != "" {
+				headers["Content-Type"] = contentType
+			}
+			action := &model.ResponseAction{
+				StatusCode: status,
+				Headers:    headers,
+				Body:       ex.body,
+			}
+
+			ruleName := fmt.Sprintf("%s_%s", name, code)
+			if ex.name != "" && i > 0 {
+				ruleName += "_" + ex.name
+			}
+			rules = append(rules, &model.MockRule{
+				ID:           model.GenerateRuleID("oas", tag, method, path, code, ex.name),
+				Name:         truncate(ruleName, 50),
+				Protocol:     string(model.ProtocolHTTP),
+				MatchConfig:  model.MatchConfig{Logical: "AND", Conditions: conditions},
+				ActionConfig: model.ActionConfigWrapper{AType: model.ActionTypeResponse, Config: action},
+				Priority:     priority,
+				Status:       model.RuleStatusActive,
+				Version:      1,
+				Tags:         model.RuleTags{tag},
+			})
+		}
+	}
+	return rules, nil
+}
+
+type namedExample struct {
+	name string
+	body string
+}
+
+// responseExamples 优先使用 examples / example，没有示例时根据 schema 生成
+func (doc *openAPIDoc) responseExamples(resp *openAPIResponse) (string, []namedExample) {
+	if resp == nil || len(resp.Content) == 0 {
+		return "", []namedExample{{}}
+	}
+
+	contentType := ""
+	for ct := range resp.Content {
+		if strings.Contains(ct, "json") {
+			contentType = ct
+			break
+		}
+	}
+	if contentType == "" {
+		cts := make([]string, 0, len(resp.Content))
+		for ct := range resp.Content {
+			cts = append(cts, ct)
+		}
+		sort.Strings(cts)
+		contentType = cts[0]
+	}
+	media := resp.Content[contentType]
+	if media == nil {
+		return contentType, []namedExample{{}}
+	}
+
+	if len(media.Examples) > 0 {
+		names := make([]string, 0, len(media.Examples))
+		for n := range media.Examples {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		examples := make([]namedExample, 0, len(names))
+		for _, n := range names {
+			if media.Examples[n] == nil {
+				continue
+			}
+			examples = append(examples, namedExample{name: n, body: encodeExample(media.Examples[n].Value)})
+		}
+		return contentType, examples
+	}
+	if media.Example != nil {
+		return contentType, []namedExample{{body: encodeExample(media.Example)}}
+	}
+	if media.Schema != nil {
+		return contentType, []namedExample{{body: encodeExample(doc.sampleFromSchema(media.Schema, 0))}}
+	}
+	return contentType, []namedExample{{}}
+}
+
+func (doc *openAPIDoc) responseHeaders(resp *openAPIResponse) map[string]string {
+	headers := make(map[string]string)
+	if resp == nil {
+		return headers
+	}
+	for name, h := range resp.Headers {
+		if h == nil {
+			continue
+		}
+		v := h.Example
+		if v == nil && h.Schema != nil {
+			v = doc.sampleFromSchema(h.Schema, 0)
+		}
+		if v != nil {
+			headers[name] = fmt.Sprint(v)
+		}
+	}
+	return headers
+}
+
+// sampleFromSchema 根据 schema 生成示例值
+func (doc *openAPIDoc) sampleFromSchema(s *openAPISchema, depth int) any {
+	if s == nil || depth > 8 {
+		return nil
+	}
+	if s.Ref != "" {
+		return doc.sampleFromSchema(doc.resolveRef(s.Ref), depth+1)
+	}
+	if s.Example != nil {
+		return normalizeYAML(s.Example)
+	}
+	if s.Default != nil {
+		return normalizeYAML(s.Default)
+	}
+	if len(s.Enum) > 0 {
+		return normalizeYAML(s.Enum[0])
+	}
+	if len(s.AllOf) > 0 {
+		merged := map[string]any{}
+		for _, sub := range s.AllOf {
+			if m, ok := doc.sampleFromSchema(sub, depth+1).(map[string]any); ok {
+				for k, v := range m {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	if len(s.OneOf) > 0 {
+		return doc.sampleFromSchema(s.OneOf[0], depth+1)
+	}
+	if len(s.AnyOf) > 0 {
+		return doc.sampleFromSchema(s.AnyOf[0], depth+1)
+	}
+
+	switch s.Type {
+	case "object", "":
+		if len(s.Properties) == 0 && s.Type == "" {
+			return nil
+		}
+		obj := make(map[string]any, len(s.Properties))
+		for name, prop := range s.Properties {
+			obj[name] = doc.sampleFromSchema(prop, depth+1)
+		}
+		return obj
+	case "array":
+		item := doc.sampleFromSchema(s.Items, depth+1)
+		if item == nil {
+			return []any{}
+		}
+		return []any{item}
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return true
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "date":
+			return "2024-01-01"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "email":
+			return "user@example.com"
+		}
+		return "string"
+	}
+	return nil
+}
+
+func (doc *openAPIDoc) resolveRef(ref string) *openAPISchema {
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(ref, prefix) {
+		return nil
+	}
+	return doc.Components.Schemas[strings.TrimPrefix(ref, prefix)]
+}
+
+// encodeExample 字符串示例原样返回，其他类型编码为 JSON
+func encodeExample(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(normalizeYAML(v))
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// normalizeYAML 将 yaml 解析出的 map[interface{}]interface{} 转换为可 JSON 编码的结构
+func normalizeYAML(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(t))
+		for k, val := range t {
+			m[k] = normalizeYAML(val)
+		}
+		return m
+	case map[any]any:
+		m := make(map[string]any, len(t))
+		for k, val := range t {
+			m[fmt.Sprint(k)] = normalizeYAML(val)
+		}
+		return m
+	case []any:
+		s := make([]any, len(t))
+		for i, val := range t {
+			s[i] = normalizeYAML(val)
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,179 @@
+package converter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const petstoreSpec = `
+openapi: 3.0.0
+info:
+  title: petstore
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        "404":
+          description: not found
+          content:
+            application/json:
+              example:
+                message: pet not found
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+          example: doggie
+`
+
+func TestFromOpenAPI(t *testing.T) {
+	rules, report, err := FromOpenAPI([]byte(petstoreSpec), model.OpenAPIImportOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, 2, report.Converted)
+	// petId 未声明类型，报告中提示只能匹配数字
+	require.Len(t, report.Unsupported, 1)
+	assert.Equal(t, "GET /pets/{petId}", report.Unsupported[0].Item)
+	assert.False(t, report.Unsupported[0].Skipped)
+
+	ctx := context.Background()
+	for _, rule := range rules {
+		assert.NoError(t, rule.Validate())
+		assert.Equal(t, model.RuleTags{"petstore"}, rule.Tags)
+		assert.Equal(t, "http_get_/pets/*", rule.L1MatchIndex)
+	}
+
+	httpReq, _ := http.NewRequest("GET", "http://localhost/pets/42", strings.NewReader(""))
+	req := model.NewHTTPRequest(httpReq)
+	assert.True(t, rules[0].IsMatch(ctx, req))
+	assert.False(t, rules[1].IsMatch(ctx, req))
+
+	resp, err := rules[0].ExecuteAction(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.GetStatus())
+	assert.JSONEq(t, `{"id":0,"name":"doggie"}`, string(resp.GetBody()))
+
+	httpReq, _ = http.NewRequest("GET", "http://localhost/pets/42", strings.NewReader(""))
+	httpReq.Header.Set(OpenAPIStatusHeader, "404")
+	req = model.NewHTTPRequest(httpReq)
+	assert.True(t, rules[1].IsMatch(ctx, req))
+	assert.Greater(t, rules[1].Priority, rules[0].Priority)
+}
+
+const pathsSpec = `
+openapi: 3.0.0
+info:
+  title: paths
+  version: 1.0.0
+paths:
+  /v1.0/pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            text/plain:
+              example: list
+  /v1.0/pets/{petId}/toys/{toyId}:
+    parameters:
+      - name: petId
+        in: path
+        schema:
+          type: integer
+    get:
+      parameters:
+        - name: toyId
+          in: path
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            text/plain:
+              example: toy
+  /files/{name}.json:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+
+func TestFromOpenAPIRequestIndex(t *testing.T) {
+	rules, report, err := FromOpenAPI([]byte(pathsSpec), model.OpenAPIImportOptions{})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, 2, report.Converted)
+	require.Len(t, report.Unsupported, 1)
+	assert.Equal(t, "/files/{name}.json", report.Unsupported[0].Item)
+	assert.True(t, report.Unsupported[0].Skipped)
+
+	ctx := context.Background()
+	for _, rule := range rules {
+		require.NoError(t, rule.Validate())
+	}
+	// 与仓库一致：先按请求的索引键取候选规则，再逐条匹配
+	findRule := func(req model.RequestInfo) *model.MockRule {
+		for _, rule := range rules {
+			if rule.L1MatchIndex == model.BuildL1MatchIndexKeyFromReq(req) && rule.IsMatch(ctx, req) {
+				return rule
+			}
+		}
+		return nil
+	}
+
+	cases := map[string]string{
+		"/v1.0/pets":           "list",
+		"/v1.0/pets/7/toys/42": "toy",
+		"/v1.0/pets/7/toys/x":  "",
+		"/v1x0/pets":           "",
+	}
+	for path, body := range cases {
+		httpReq, _ := http.NewRequest("GET", "http://localhost"+path, strings.NewReader(""))
+		req := model.NewHTTPRequest(httpReq)
+		matched := findRule(req)
+		if body == "" {
+			assert.Nil(t, matched, path)
+			continue
+		}
+		require.NotNil(t, matched, path)
+		resp, err := matched.ExecuteAction(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(resp.GetBody()), path)
+	}
+}
+
+func TestPathTemplateCondition(t *testing.T) {
+	cond, err := PathTemplateCondition("/v1.0/pets")
+	require.NoError(t, err)
+	assert.Equal(t, model.OpEqual, cond.Operator)
+
+	cond, err = PathTemplateCondition("/pets/{petId}")
+	require.NoError(t, err)
+	assert.Equal(t, `^/pets/[0-9]+$`, cond.Value)
+
+	for _, path := range []string{"/files/{name}.json", "/search(v2)/{id}"} {
+		_, err = PathTemplateCondition(path)
+		assert.Error(t, err, path)
+	}
+}
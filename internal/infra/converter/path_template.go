@@ -0,0 +1,60 @@
+package converter
+
+import (
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"regexp"
+	"strings"
+)
+
+var pathParamRegex = regexp.MustCompile(`\{[^}]+\}`)
+
+// 路径中具有正则含义的字符，"." 除外
+const pathRegexMeta = `+*?()|[]{}^$\`
+
+// pathSegmentFunc 解析一个路径段：参数段返回该段的正则，字面段返回该段的原文
+type pathSegmentFunc func(seg string) (pattern string, param bool, err error)
+
+// PathTemplateCondition 将 OpenAPI 路径模板转换为 path 条件，规则的索引键需要与请求路径标准化后的键一致：
+//   - 无参数的模板生成 eq 条件，如 /v1.0/pets
+//   - 参数必须占据完整的路径段，生成数字段正则，如 /pets/{id} => ^/pets/[0-9]+$；
+//     请求侧只有纯数字段会被标准化为 *，非数字的参数值无法通过索引定位到规则
+//   - 字面段中的 "." 不转义：索引键已经限定了该段的原文，转义后 NormalizePath 会把该段视为动态段
+//
+// 参数只占部分路径段（如 /files/{name}.json）或字面段包含其他正则字符时返回错误
+func PathTemplateCondition(path string) (model.MatchCondition, error) {
+	return routablePathCondition(path, func(seg string) (string, bool, error) {
+		if !pathParamRegex.MatchString(seg) {
+			return seg, false, nil
+		}
+		if pathParamRegex.FindString(seg) != seg {
+			return "", false, fmt.Errorf("parameter in %q does not span the whole path segment, which the match index cannot route", seg)
+		}
+		return "[0-9]+", true, nil
+	})
+}
+
+// routablePathCondition 按 PathTemplateCondition 的规则逐段生成 path 条件，由 segment 决定哪些段是参数
+func routablePathCondition(path string, segment pathSegmentFunc) (model.MatchCondition, error) {
+	segments := strings.Split(path, "/")
+	hasParam := false
+	literal := make([]bool, len(segments))
+	for i, seg := range segments {
+		pattern, param, err := segment(seg)
+		if err != nil {
+			return model.MatchCondition{}, err
+		}
+		segments[i] = pattern
+		literal[i] = !param
+		hasParam = hasParam || param
+	}
+	if !hasParam {
+		return model.MatchCondition{Type: model.MatchPath, Operator: model.OpEqual, Value: strings.Join(segments, "/")}, nil
+	}
+	for i, seg := range segments {
+		if literal[i] && strings.ContainsAny(seg, pathRegexMeta) {
+			return model.MatchCondition{}, fmt.Errorf("path segment %q contains regex characters, which the match index cannot route", seg)
+		}
+	}
+	return model.MatchCondition{Type: model.MatchPath, Operator: model.OpRegex, Value: "^" + strings.Join(segments, "/") + "$"}, nil
+}
@@ -0,0 +1,381 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	postmanSchemaV21 = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+	// Postman mock server 通过该请求头选择 example
+	PostmanResponseNameHeader = "x-mock-response-name"
+)
+
+// PostmanCollection Postman collection v2.1
+type PostmanCollection struct {
+	Info PostmanInfo   `json:"info"`
+	Item []PostmanItem `json:"item"`
+}
+
+type PostmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+// PostmanItem 请求或文件夹（包含子 item）
+type PostmanItem struct {
+	Name     string            `json:"name"`
+	Item     []PostmanItem     `json:"item,omitempty"`
+	Request  *PostmanRequest   `json:"request,omitempty"`
+	Response []PostmanResponse `json:"response,omitempty"`
+	Event    []json.RawMessage `json:"event,omitempty"`
+}
+
+type PostmanRequest struct {
+	Method string            `json:"method"`
+	Header []PostmanKeyValue `json:"header,omitempty"`
+	URL    PostmanURL        `json:"url"`
+	Body   json.RawMessage   `json:"body,omitempty"`
+	Auth   json.RawMessage   `json:"auth,omitempty"`
+}
+
+// PostmanURL 既可以是字符串也可以是对象
+type PostmanURL struct {
+	Raw   string            `json:"raw"`
+	Host  []string          `json:"host,omitempty"`
+	Path  []string          `json:"path,omitempty"`
+	Query []PostmanKeyValue `json:"query,omitempty"`
+}
+
+func (u *PostmanURL) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		u.Raw = raw
+		return nil
+	}
+	type alias PostmanURL
+	return json.Unmarshal(data, (*alias)(u))
+}
+
+type PostmanKeyValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+type PostmanResponse struct {
+	Name            string            `json:"name"`
+	OriginalRequest *PostmanRequest   `json:"originalRequest,omitempty"`
+	Status          string            `json:"status,omitempty"`
+	Code            int               `json:"code"`
+	Header          []PostmanKeyValue `json:"header,omitempty"`
+	Body            string            `json:"body,omitempty"`
+}
+
+// postman 路径变量 :id 与集合变量 {{var}}
+var postmanVarRegex = regexp.MustCompile(`^(:\w+|\{\{[^}]+\}\})$`)
+
+// FromPostman 将 Postman collection 中的 example 转换为规则
+//
+// 每个请求的第一个 example 作为默认响应，其他 example 需要请求头 x-mock-response-name 选择，
+// 与 Postman mock server 的行为一致。
+func FromPostman(data []byte) ([]*model.MockRule, *model.ConversionReport, error) {
+	var collection PostmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse postman collection: %w", err)
+	}
+
+	report := &model.ConversionReport{}
+	var rules []*model.MockRule
+	var walk func(prefix string, items []PostmanItem)
+	walk = func(prefix string, items []PostmanItem) {
+		for _, item := range items {
+			name := strings.TrimPrefix(prefix+"/"+item.Name, "/")
+			if len(item.Item) > 0 {
+				walk(name, item.Item)
+				continue
+			}
+			if len(item.Event) > 0 {
+				report.Add(name, "event", "pre-request and test scripts are ignored", false)
+			}
+			if len(item.Response) == 0 {
+				report.Add(name, "response", "request has no examples", true)
+				continue
+			}
+			for i, resp := range item.Response {
+				rule, ok := postmanExampleToRule(collection.Info.Name, name, item, resp, i, report)
+				if !ok {
+					continue
+				}
+				rules = append(rules, rule)
+				report.Converted++
+			}
+		}
+	}
+	walk("", collection.Item)
+	return rules, report, nil
+}
+
+func postmanExampleToRule(collectionName, itemName string, item PostmanItem, resp PostmanResponse, index int, report *model.ConversionReport) (*model.MockRule, bool) {
+	exampleName := resp.Name
+	if exampleName == "" {
+		exampleName = fmt.Sprintf("example%d", index)
+	}
+	reportItem := itemName + "#" + exampleName
+
+	req := resp.OriginalRequest
+	if req == nil {
+		req = item.Request
+	}
+	if req == nil {
+		report.Add(reportItem, "request", "missing request", true)
+		return nil, false
+	}
+	if len(req.Auth) > 0 {
+		report.Add(reportItem, "request.auth", "", false)
+	}
+	if len(req.Body) > 0 && string(req.Body) != "null" {
+		report.Add(reportItem, "request.body", "request body matching is ignored", false)
+	}
+
+	path, query := postmanPath(req.URL)
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+	pathCond, vars, err := postmanPathCondition(path)
+	if err != nil {
+		report.Add(reportItem, "request.url.path", err.Error(), true)
+		return nil, false
+	}
+	if len(vars) > 0 {
+		report.Add(reportItem, "request.url.path",
+			fmt.Sprintf("%s only match numeric values, the match index treats other values as literal paths", strings.Join(vars, ", ")), false)
+	}
+	conditions := []model.MatchCondition{
+		{Type: model.MatchMethod, Operator: model.OpEqual, Value: method},
+		pathCond,
+	}
+	for _, q := range query {
+		if q.Disabled || q.Key == "" {
+			continue
+		}
+		if strings.Contains(q.Value, "{{") {
+			report.Add(reportItem, "request.url.query."+q.Key, "variable values are not matched", false)
+			continue
+		}
+		conditions = append(conditions, model.MatchCondition{Type: model.MatchQueryParam, Operator: model.OpEqual, Key: q.Key, Value: q.Value})
+	}
+
+	priority := 0
+	if index > 0 {
+		conditions = append(conditions, model.MatchCondition{Type: model.MatchHeader, Operator: model.OpEqual, Key: PostmanResponseNameHeader, Value: exampleName})
+		priority = 1
+	}
+
+	headers := make(map[string]string, len(resp.Header))
+	for _, h := range resp.Header {
+		if h.Disabled || skippedHARHeaders[strings.ToLower(h.Key)] {
+			continue
+		}
+		headers[h.Key] = h.Value
+	}
+	status := resp.Code
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	return &model.MockRule{
+		ID:          model.GenerateRuleID("pm", collectionName, itemName, exampleName),
+		Name:        truncate(exampleName, 50),
+		Protocol:    string(model.ProtocolHTTP),
+		MatchConfig: model.MatchConfig{Logical: "AND", Conditions: conditions},
+		ActionConfig: model.ActionConfigWrapper{AType: model.ActionTypeResponse, Config: &model.ResponseAction{
+			StatusCode: status,
+			Headers:    headers,
+			Body:       resp.Body,
+		}},
+		Priority: priority,
+		Status:   model.RuleStatusActive,
+		Version:  1,
+		Tags:     postmanTags(collectionName),
+	}, true
+}
+
+// postmanPath 从 URL 对象或 raw 字符串中提取路径与查询参数
+func postmanPath(u PostmanURL) (string, []PostmanKeyValue) {
+	if len(u.Path) > 0 {
+		return "/" + strings.Join(u.Path, "/"), u.Query
+	}
+
+	raw := u.Raw
+	if i := strings.Index(raw, "://"); i >= 0 {
+		raw = raw[i+3:]
+	}
+	// 去掉 host 部分（可能是 {{baseUrl}}）
+	if i := strings.Index(raw, "/"); i >= 0 {
+		raw = raw[i:]
+	} else {
+		raw = "/"
+	}
+
+	query := u.Query
+	if i := strings.Index(raw, "?"); i >= 0 {
+		if len(query) == 0 {
+			for _, kv := range strings.Split(raw[i+1:], "&") {
+				parts := strings.SplitN(kv, "=", 2)
+				q := PostmanKeyValue{Key: parts[0]}
+				if len(parts) == 2 {
+					q.Value = parts[1]
+				}
+				query = append(query, q)
+			}
+		}
+		raw = raw[:i]
+	}
+	return raw, query
+}
+
+// postmanPathCondition 按 PathTemplateCondition 的规则转换路径，:id 与 {{var}} 段作为数字参数段，返回路径中的变量
+func postmanPathCondition(path string) (model.MatchCondition, []string, error) {
+	var vars []string
+	cond, err := routablePathCondition(path, func(seg string) (string, bool, error) {
+		if postmanVarRegex.MatchString(seg) {
+			vars = append(vars, seg)
+			return "[0-9]+", true, nil
+		}
+		if strings.Contains(seg, "{{") {
+			return "", false, fmt.Errorf("variable in %q does not span the whole path segment, which the match index cannot route", seg)
+		}
+		return seg, false, nil
+	})
+	return cond, vars, err
+}
+
+func postmanTags(collectionName string) model.RuleTags {
+	if collectionName == "" {
+		return nil
+	}
+	return model.RuleTags{collectionName}
+}
+
+// ToPostman 将规则导出为 Postman collection，每条规则对应一个带 example 的请求
+func ToPostman(name string, rules []*model.MockRule) (*PostmanCollection, *model.ConversionReport) {
+	report := &model.ConversionReport{}
+	collection := &PostmanCollection{
+		Info: PostmanInfo{Name: name, Schema: postmanSchemaV21},
+		Item: make([]PostmanItem, 0, len(rules)),
+	}
+
+	sorted := append([]*model.MockRule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Priority > sorted[j].Priority })
+
+	for _, rule := range sorted {
+		item := rule.Name
+		if p := strings.ToLower(rule.Protocol); p != string(model.ProtocolHTTP) && p != string(model.ProtocolHTTPS) {
+			report.Add(item, "protocol", rule.Protocol, true)
+			continue
+		}
+		if strings.ToUpper(rule.MatchConfig.Logical) == "OR" && len(rule.MatchConfig.Conditions) > 1 {
+			report.Add(item, "match.logical", "OR is exported as AND", false)
+		}
+
+		req := &PostmanRequest{Method: http.MethodGet, URL: PostmanURL{Host: []string{"{{baseUrl}}"}}}
+		path := "/"
+		for i, cond := range rule.MatchConfig.Conditions {
+			value := fmt.Sprint(cond.Value)
+			key, _ := cond.Key.(string)
+			op := strings.ToLower(cond.Operator)
+			switch {
+			case strings.ToLower(cond.Type) == model.MatchMethod && (op == model.OpEqual || op == ""):
+				req.Method = strings.ToUpper(value)
+			case strings.ToLower(cond.Type) == model.MatchPath && (op == model.OpEqual || op == ""):
+				path = value
+			case strings.ToLower(cond.Type) == model.MatchHeader && (op == model.OpEqual || op == "") && key != "":
+				req.Header = append(req.Header, PostmanKeyValue{Key: key, Value: value})
+			case strings.ToLower(cond.Type) == model.MatchQueryParam && (op == model.OpEqual || op == "") && key != "":
+				req.URL.Query = append(req.URL.Query, PostmanKeyValue{Key: key, Value: value})
+			default:
+				b, _ := json.Marshal(cond)
+				report.Add(item, fmt.Sprintf("match.conditions[%d]", i), string(b), false)
+			}
+		}
+		req.URL.Path = strings.Split(strings.TrimPrefix(path, "/"), "/")
+		req.URL.Raw = "{{baseUrl}}" + path + rawQuery(req.URL.Query)
+
+		responses, ok := postmanResponses(item, rule.ActionConfig, req, report)
+		if !ok {
+			continue
+		}
+		collection.Item = append(collection.Item, PostmanItem{Name: rule.Name, Request: req, Response: responses})
+		report.Converted++
+	}
+	return collection, report
+}
+
+func postmanResponses(item string, action model.ActionConfigWrapper, req *PostmanRequest, report *model.ConversionReport) ([]PostmanResponse, bool) {
+	var actions []*model.ResponseAction
+	switch cfg := action.Config.(type) {
+	case *model.ResponseAction:
+		actions = []*model.ResponseAction{cfg}
+	case *model.SequenceAction:
+		report.Add(item, "action.sequence", "each response is exported as a separate example", false)
+		actions = cfg.Responses
+	default:
+		report.Add(item, "action.type", string(action.AType), true)
+		return nil, false
+	}
+
+	responses := make([]PostmanResponse, 0, len(actions))
+	for i, a := range actions {
+		if a.Template {
+			report.Add(item, "action.template", "go templates are exported verbatim", false)
+		}
+		if a.Delay > 0 {
+			report.Add(item, "action.delay", a.Delay.String(), false)
+		}
+		if len(a.BodyBytes) > 0 {
+			report.Add(item, "action.bodyBase64", "binary body is not exported", false)
+		}
+
+		headerKeys := make([]string, 0, len(a.Headers))
+		for k := range a.Headers {
+			headerKeys = append(headerKeys, k)
+		}
+		sort.Strings(headerKeys)
+		headers := make([]PostmanKeyValue, 0, len(headerKeys))
+		for _, k := range headerKeys {
+			headers = append(headers, PostmanKeyValue{Key: k, Value: a.Headers[k]})
+		}
+
+		name := item
+		if len(actions) > 1 {
+			name = fmt.Sprintf("%s #%d", item, i+1)
+		}
+		responses = append(responses, PostmanResponse{
+			Name:            name,
+			OriginalRequest: req,
+			Status:          http.StatusText(a.StatusCode),
+			Code:            a.StatusCode,
+			Header:          headers,
+			Body:            a.Body,
+		})
+	}
+	return responses, true
+}
+
+func rawQuery(query []PostmanKeyValue) string {
+	if len(query) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(query))
+	for _, q := range query {
+		parts = append(parts, q.Key+"="+q.Value)
+	}
+	return "?" + strings.Join(parts, "&")
+}
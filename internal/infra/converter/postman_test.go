@@ -0,0 +1,148 @@
+package converter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const postmanCollection = `{
+	"info": {"name": "shop", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+	"item": [{
+		"name": "users",
+		"item": [{
+			"name": "get user",
+			"event": [{"listen": "test"}],
+			"request": {"method": "GET", "url": "{{baseUrl}}/users/:id?verbose={{verbose}}"},
+			"response": [
+				{"name": "found", "code": 200, "header": [{"key": "Content-Type", "value": "application/json"}, {"key": "Content-Length", "value": "9"}],
+				 "body": "{\"id\":1}",
+				 "originalRequest": {"method": "GET", "url": {"raw": "{{baseUrl}}/users/:id?active=true", "path": ["users", ":id"], "query": [{"key": "active", "value": "true"}]}}},
+				{"name": "missing", "code": 404, "body": "not found"}
+			]
+		}, {
+			"name": "no examples",
+			"request": {"method": "POST", "url": "{{baseUrl}}/users"}
+		}]
+	}]
+}`
+
+func TestFromPostman(t *testing.T) {
+	rules, report, err := FromPostman([]byte(postmanCollection))
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, 2, report.Converted)
+
+	var features []string
+	for _, issue := range report.Unsupported {
+		features = append(features, issue.Item+" "+issue.Feature)
+	}
+	assert.ElementsMatch(t, []string{
+		"users/get user event",
+		"users/get user#found request.url.path",
+		"users/get user#missing request.url.path",
+		"users/get user#missing request.url.query.verbose",
+		"users/no examples response",
+	}, features)
+
+	ctx := context.Background()
+	found, missing := rules[0], rules[1]
+	for _, rule := range rules {
+		require.NoError(t, rule.Validate())
+		assert.Equal(t, model.RuleTags{"shop"}, rule.Tags)
+	}
+	assert.Greater(t, missing.Priority, found.Priority)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, found.ActionConfig.Config.(*model.ResponseAction).Headers)
+
+	httpReq, _ := http.NewRequest("GET", "http://localhost/users/1?active=true", strings.NewReader(""))
+	req := model.NewHTTPRequest(httpReq)
+	assert.Equal(t, req.GetMatchIndex(), found.L1MatchIndex)
+	assert.True(t, found.IsMatch(ctx, req))
+	assert.False(t, missing.IsMatch(ctx, req))
+
+	// 其他 example 通过 x-mock-response-name 选择
+	httpReq, _ = http.NewRequest("GET", "http://localhost/users/1", strings.NewReader(""))
+	httpReq.Header.Set(PostmanResponseNameHeader, "missing")
+	assert.True(t, missing.IsMatch(ctx, model.NewHTTPRequest(httpReq)))
+}
+
+func TestPostmanPathCondition(t *testing.T) {
+	cond, vars, err := postmanPathCondition("/v1.0/users/{{userId}}/orders/:id")
+	require.NoError(t, err)
+	assert.Equal(t, `^/v1.0/users/[0-9]+/orders/[0-9]+$`, cond.Value)
+	assert.Equal(t, []string{"{{userId}}", ":id"}, vars)
+	rule := &model.MockRule{Protocol: "http", MatchConfig: model.MatchConfig{Logical: "AND", Conditions: []model.MatchCondition{
+		{Type: model.MatchMethod, Operator: model.OpEqual, Value: "GET"}, cond,
+	}}}
+	require.NoError(t, rule.ResolveMatchIndex())
+	httpReq, _ := http.NewRequest("GET", "http://localhost/v1.0/users/7/orders/42", strings.NewReader(""))
+	assert.Equal(t, model.NewHTTPRequest(httpReq).GetMatchIndex(), rule.L1MatchIndex)
+
+	cond, vars, err = postmanPathCondition("/v1.0/users")
+	require.NoError(t, err)
+	assert.Equal(t, model.OpEqual, cond.Operator)
+	assert.Empty(t, vars)
+
+	_, _, err = postmanPathCondition("/files/{{name}}.json")
+	assert.Error(t, err)
+}
+
+func TestToPostman(t *testing.T) {
+	rules := []*model.MockRule{
+		{
+			Name:     "list users",
+			Protocol: "http",
+			MatchConfig: model.MatchConfig{Logical: "AND", Conditions: []model.MatchCondition{
+				{Type: model.MatchMethod, Operator: model.OpEqual, Value: "GET"},
+				{Type: model.MatchPath, Operator: model.OpEqual, Value: "/users"},
+				{Type: model.MatchQueryParam, Operator: model.OpEqual, Key: "page", Value: "2"},
+				{Type: model.MatchHeader, Operator: model.OpRegex, Key: "x-token", Value: ".*"},
+			}},
+			ActionConfig: model.ActionConfigWrapper{AType: model.ActionTypeSequence, Config: &model.SequenceAction{Responses: []*model.ResponseAction{
+				{StatusCode: 200, Body: "first", Headers: map[string]string{"X-B": "2", "X-A": "1"}},
+				{StatusCode: 503, Body: "second"},
+			}}},
+		},
+		{
+			Name:     "fuzzy path",
+			Protocol: "http",
+			Priority: 5,
+			MatchConfig: model.MatchConfig{Logical: "AND", Conditions: []model.MatchCondition{
+				{Type: model.MatchPath, Operator: model.OpContains, Value: "/orders"},
+			}},
+			ActionConfig: model.ActionConfigWrapper{AType: model.ActionTypeResponse, Config: &model.ResponseAction{StatusCode: 200}},
+		},
+		{Name: "grpc", Protocol: "grpc"},
+	}
+
+	collection, report := ToPostman("shop", rules)
+	assert.Equal(t, 2, report.Converted)
+	require.Len(t, collection.Item, 2)
+
+	// 按优先级从高到低导出
+	fuzzy, users := collection.Item[0], collection.Item[1]
+	assert.Equal(t, "{{baseUrl}}/", fuzzy.Request.URL.Raw, "contains path is not exported as exact path")
+	assert.Equal(t, "{{baseUrl}}/users?page=2", users.Request.URL.Raw)
+	assert.Empty(t, users.Request.Header)
+	require.Len(t, users.Response, 2)
+	assert.Equal(t, "list users #2", users.Response[1].Name)
+	assert.Equal(t, 503, users.Response[1].Code)
+	assert.Equal(t, []PostmanKeyValue{{Key: "X-A", Value: "1"}, {Key: "X-B", Value: "2"}}, users.Response[0].Header)
+
+	var features []string
+	for _, issue := range report.Unsupported {
+		features = append(features, issue.Item+" "+issue.Feature)
+	}
+	assert.ElementsMatch(t, []string{
+		"fuzzy path match.conditions[0]",
+		"list users match.conditions[3]",
+		"list users action.sequence",
+		"grpc protocol",
+	}, features)
+}
@@ -0,0 +1,51 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// CompileProtoFiles 在进程内编译一组 .proto 源文件（路径 -> 内容），返回序列化的 FileDescriptorSet
+// import 路径相对于 bundle 根目录，google/protobuf/*.proto 等标准文件无需上传
+func CompileProtoFiles(ctx context.Context, files map[string]string) ([]byte, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no .proto files to compile")
+	}
+
+	srcs := make(map[string]string, len(files))
+	names := make([]string, 0, len(files))
+	for name, content := range files {
+		clean := path.Clean(strings.TrimPrefix(strings.ReplaceAll(name, "\\", "/"), "/"))
+		if !strings.HasSuffix(clean, ".proto") || strings.HasPrefix(clean, "../") {
+			return nil, fmt.Errorf("invalid proto file path %q", name)
+		}
+		srcs[clean] = content
+		names = append(names, clean)
+	}
+	sort.Strings(names)
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(srcs),
+		}),
+	}
+	compiled, err := compiler.Compile(ctx, names...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile proto files: %w", err)
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range compiled {
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	return proto.Marshal(set)
+}
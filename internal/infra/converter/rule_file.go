@@ -0,0 +1,186 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileRule 规则文件中单条规则的格式，与 internal/test/http_mock.json 一致：
+// 匹配条件使用 matcher（兼容 match），action 为扁平结构 {"type": "response", "statusCode": 200, ...}
+type fileRule struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Protocol  string             `json:"protocol"`
+	Matcher   *model.MatchConfig `json:"matcher"`
+	Match     *model.MatchConfig `json:"match"`
+	Action    json.RawMessage    `json:"action"`
+	IsEnabled *bool              `json:"isEnabled"`
+	Status    model.RuleStatus   `json:"status"`
+	Priority  int                `json:"priority"`
+	Tags      model.RuleTags     `json:"tags"`
+	// 生效时间窗口（Unix 秒）与存活秒数
+	ActiveFrom  int64 `json:"activeFrom"`
+	ActiveUntil int64 `json:"activeUntil"`
+	TTL         int64 `json:"ttl"`
+}
+
+// IsRuleFile 判断文件扩展名是否为支持的规则文件
+func IsRuleFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// FromRuleFile 解析规则文件，文件内容可以是单条规则、规则数组或 {"rules": [...]}
+// name 为文件的相对路径，用于生成缺省的规则 ID 和名称；
+// modTime 为文件修改时间（Unix 秒），ttl 从该时间起算，热加载重新解析文件时不会延长规则的存活时间
+func FromRuleFile(name string, data []byte, modTime int64) ([]*model.MockRule, error) {
+	if ext := strings.ToLower(filepath.Ext(name)); ext == ".yaml" || ext == ".yml" {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse yaml: %w", err)
+		}
+		var err error
+		if data, err = json.Marshal(normalizeYAML(doc)); err != nil {
+			return nil, fmt.Errorf("failed to convert yaml: %w", err)
+		}
+	}
+
+	var items []fileRule
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")):
+		return nil, nil
+	case trimmed[0] == '[':
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("failed to parse rules: %w", err)
+		}
+	default:
+		var wrapper struct {
+			Rules []fileRule `json:"rules"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, fmt.Errorf("failed to parse rules: %w", err)
+		}
+		if wrapper.Rules != nil {
+			items = wrapper.Rules
+		} else {
+			var item fileRule
+			if err := json.Unmarshal(trimmed, &item); err != nil {
+				return nil, fmt.Errorf("failed to parse rule: %w", err)
+			}
+			items = []fileRule{item}
+		}
+	}
+
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	rules := make([]*model.MockRule, 0, len(items))
+	for i, item := range items {
+		rule, err := item.toRule(modTime)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		if rule.ID == "" {
+			rule.ID = model.GenerateRuleID("file", filepath.ToSlash(name), fmt.Sprint(i))
+		}
+		if rule.Name == "" {
+			rule.Name = truncate(fmt.Sprintf("%s_%d", base, i), 50)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (f *fileRule) toRule(modTime int64) (*model.MockRule, error) {
+	if f.Protocol == "" {
+		return nil, fmt.Errorf("missing 'protocol' field")
+	}
+	match := f.Matcher
+	if match == nil {
+		match = f.Match
+	}
+	if match == nil || len(match.Conditions) == 0 {
+		return nil, fmt.Errorf("matcher must have at least one condition")
+	}
+
+	action, err := parseFileAction(f.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	status := f.Status
+	switch {
+	case status != "":
+		if !status.IsValid() {
+			return nil, fmt.Errorf("invalid status %q", status)
+		}
+	case f.IsEnabled != nil && !*f.IsEnabled:
+		status = model.RuleStatusInactive
+	default:
+		status = model.RuleStatusActive
+	}
+
+	rule := &model.MockRule{
+		ID:           f.ID,
+		Name:         f.Name,
+		Protocol:     f.Protocol,
+		MatchConfig:  *match,
+		ActionConfig: action,
+		Priority:     f.Priority,
+		Status:       status,
+		Version:      1,
+		Tags:         f.Tags,
+		ActiveFrom:   f.ActiveFrom,
+		ActiveUntil:  f.ActiveUntil,
+		TTL:          f.TTL,
+	}
+	if f.TTL > 0 && f.ActiveUntil == 0 && modTime > 0 {
+		start := f.ActiveFrom
+		if start < modTime {
+			start = modTime
+		}
+		rule.ActiveUntil = start + f.TTL
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// parseFileAction 支持扁平结构 {"type": "response", ...} 与标准结构 {"type": "response", "config": {...}}
+func parseFileAction(data json.RawMessage) (model.ActionConfigWrapper, error) {
+	var action model.ActionConfigWrapper
+	if len(data) == 0 {
+		return action, fmt.Errorf("action configuration is missing")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return action, fmt.Errorf("invalid action: %w", err)
+	}
+	if _, ok := fields["config"]; !ok {
+		actionType := fields["type"]
+		delete(fields, "type")
+		config, err := json.Marshal(fields)
+		if err != nil {
+			return action, fmt.Errorf("invalid action: %w", err)
+		}
+		data, err = json.Marshal(map[string]json.RawMessage{"type": actionType, "config": config})
+		if err != nil {
+			return action, fmt.Errorf("invalid action: %w", err)
+		}
+	}
+
+	if err := action.UnmarshalJSON(data); err != nil {
+		return action, fmt.Errorf("invalid action: %w", err)
+	}
+	return action, nil
+}
@@ -0,0 +1,26 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromRuleFileTTL(t *testing.T) {
+	data := []byte(`{"protocol": "http", "ttl": 3600,
+		"matcher": {"logical": "AND", "conditions": [{"type": "path", "operator": "eq", "value": "/promo"}]},
+		"action": {"type": "response", "statusCode": 200, "body": "sale"}}`)
+	modTime := time.Now().Add(-time.Hour).Unix() + 60
+
+	rules, err := FromRuleFile("promo.json", data, modTime)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, modTime+3600, rules[0].ActiveUntil)
+
+	// 重新加载同一文件时存活时间不变
+	reloaded, err := FromRuleFile("promo.json", data, modTime)
+	require.NoError(t, err)
+	assert.Equal(t, rules[0].ActiveUntil, reloaded[0].ActiveUntil)
+}
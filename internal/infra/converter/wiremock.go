@@ -0,0 +1,563 @@
+package converter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WireMock 优先级数值越小越优先，默认 5；本系统数值越大越优先
+const (
+	wireMockDefaultPriority = 5
+	wireMockPriorityBase    = 10
+)
+
+// wireMockAnyMethods 不限方法的 stub 展开的方法
+var wireMockAnyMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodHead, http.MethodOptions,
+}
+
+var (
+	wireMockNumericSegment = regexp.MustCompile(`^(\[0-9\]|\\d)(\+|\{\d+(,\d*)?\})?$`)
+	regexEscapeRegex       = regexp.MustCompile(`\\([^0-9A-Za-z])`)
+)
+
+// WireMockMappings WireMock stub mapping 文件（/__admin/mappings 的格式）
+type WireMockMappings struct {
+	Mappings []*WireMockStub `json:"mappings"`
+}
+
+type WireMockStub struct {
+	ID       string           `json:"id,omitempty"`
+	Name     string           `json:"name,omitempty"`
+	Priority int              `json:"priority,omitempty"`
+	Request  WireMockRequest  `json:"request"`
+	Response WireMockResponse `json:"response"`
+
+	// 以下字段无法翻译，仅用于生成报告
+	ScenarioName          string `json:"scenarioName,omitempty"`
+	RequiredScenarioState string `json:"requiredScenarioState,omitempty"`
+	NewScenarioState      string `json:"newScenarioState,omitempty"`
+}
+
+type WireMockRequest struct {
+	Method          string                     `json:"method,omitempty"`
+	URL             string                     `json:"url,omitempty"`
+	URLPath         string                     `json:"urlPath,omitempty"`
+	URLPattern      string                     `json:"urlPattern,omitempty"`
+	URLPathPattern  string                     `json:"urlPathPattern,omitempty"`
+	Headers         map[string]WireMockPattern `json:"headers,omitempty"`
+	QueryParameters map[string]WireMockPattern `json:"queryParameters,omitempty"`
+	BodyPatterns    []map[string]any           `json:"bodyPatterns,omitempty"`
+	Cookies         map[string]WireMockPattern `json:"cookies,omitempty"`
+	BasicAuth       map[string]string          `json:"basicAuthCredentials,omitempty"`
+}
+
+// WireMockPattern 字符串匹配器，如 {"equalTo": "x"}、{"matches": "re"}
+type WireMockPattern map[string]any
+
+type WireMockResponse struct {
+	Status                 int             `json:"status,omitempty"`
+	Headers                map[string]any  `json:"headers,omitempty"`
+	Body                   string          `json:"body,omitempty"`
+	JSONBody               any             `json:"jsonBody,omitempty"`
+	Base64Body             string          `json:"base64Body,omitempty"`
+	BodyFileName           string          `json:"bodyFileName,omitempty"`
+	FixedDelayMilliseconds int             `json:"fixedDelayMilliseconds,omitempty"`
+	Transformers           []string        `json:"transformers,omitempty"`
+	Fault                  string          `json:"fault,omitempty"`
+	ProxyBaseURL           string          `json:"proxyBaseUrl,omitempty"`
+	DelayDistribution      json.RawMessage `json:"delayDistribution,omitempty"`
+}
+
+// FromWireMock 将 WireMock stub mappings 转换为规则，支持 {"mappings": [...]} 或单个 stub
+func FromWireMock(data []byte) ([]*model.MockRule, *model.ConversionReport, error) {
+	var mappings WireMockMappings
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse wiremock mappings: %w", err)
+	}
+	if mappings.Mappings == nil {
+		var stub WireMockStub
+		if err := json.Unmarshal(data, &stub); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse wiremock stub: %w", err)
+		}
+		mappings.Mappings = []*WireMockStub{&stub}
+	}
+
+	report := &model.ConversionReport{}
+	rules := make([]*model.MockRule, 0, len(mappings.Mappings))
+	for i, stub := range mappings.Mappings {
+		if stub == nil {
+			continue
+		}
+		item := stub.Name
+		if item == "" {
+			item = stub.ID
+		}
+		if item == "" {
+			item = fmt.Sprintf("mappings[%d]", i)
+		}
+
+		stubRules, ok := stubToRules(item, stub, report)
+		if !ok {
+			continue
+		}
+		rules = append(rules, stubRules...)
+		report.Converted += len(stubRules)
+	}
+	return rules, report, nil
+}
+
+// stubToRules 将 stub 转换为规则；未指定方法或方法为 ANY 的 stub 按 wireMockAnyMethods 展开为每个方法一条规则，
+// 因为匹配索引键包含请求方法，不含方法条件的规则无法被定位
+func stubToRules(item string, stub *WireMockStub, report *model.ConversionReport) ([]*model.MockRule, bool) {
+	if stub.Response.Fault != "" {
+		report.Add(item, "response.fault", stub.Response.Fault, true)
+		return nil, false
+	}
+	if stub.Response.ProxyBaseURL != "" {
+		report.Add(item, "response.proxyBaseUrl", stub.Response.ProxyBaseURL, true)
+		return nil, false
+	}
+	if stub.Response.BodyFileName != "" {
+		report.Add(item, "response.bodyFileName", stub.Response.BodyFileName, true)
+		return nil, false
+	}
+	if stub.ScenarioName != "" {
+		report.Add(item, "scenario", stub.ScenarioName, false)
+	}
+
+	req := stub.Request
+	methods := []string{strings.ToUpper(req.Method)}
+	if methods[0] == "" || methods[0] == "ANY" {
+		methods = wireMockAnyMethods
+		report.Add(item, "request.method", "any method is expanded to "+strings.Join(methods, ", "), false)
+	}
+
+	conditions := make([]model.MatchCondition, 0)
+	switch {
+	case req.URL != "":
+		u, err := url.Parse(req.URL)
+		if err != nil {
+			report.Add(item, "request.url", err.Error(), true)
+			return nil, false
+		}
+		conditions = append(conditions, model.MatchCondition{Type: model.MatchPath, Operator: model.OpEqual, Value: u.Path})
+		keys := sortedKeys(u.Query())
+		for _, k := range keys {
+			conditions = append(conditions, model.MatchCondition{Type: model.MatchQueryParam, Operator: model.OpEqual, Key: k, Value: u.Query().Get(k)})
+		}
+	case req.URLPath != "":
+		conditions = append(conditions, model.MatchCondition{Type: model.MatchPath, Operator: model.OpEqual, Value: req.URLPath})
+	case req.URLPathPattern != "":
+		cond, err := wireMockPathCondition(req.URLPathPattern)
+		if err != nil {
+			report.Add(item, "request.urlPathPattern", err.Error(), true)
+			return nil, false
+		}
+		conditions = append(conditions, cond)
+	case req.URLPattern != "":
+		pattern := req.URLPattern
+		if i := strings.Index(pattern, `\?`); i >= 0 {
+			// 路径匹配不包含查询串
+			report.Add(item, "request.urlPattern", "query part of pattern is not supported", false)
+			pattern = pattern[:i]
+		}
+		cond, err := wireMockPathCondition(pattern)
+		if err != nil {
+			report.Add(item, "request.urlPattern", err.Error(), true)
+			return nil, false
+		}
+		conditions = append(conditions, cond)
+	default:
+		report.Add(item, "request", "stub without a url matcher is not supported", true)
+		return nil, false
+	}
+
+	for _, name := range sortedPatternKeys(req.Headers) {
+		cond, ok := patternCondition(model.MatchHeader, strings.ToLower(name), req.Headers[name])
+		if !ok {
+			report.Add(item, "request.headers."+name, patternDesc(req.Headers[name]), false)
+			continue
+		}
+		conditions = append(conditions, cond)
+	}
+	for _, name := range sortedPatternKeys(req.QueryParameters) {
+		cond, ok := patternCondition(model.MatchQueryParam, name, req.QueryParameters[name])
+		if !ok {
+			report.Add(item, "request.queryParameters."+name, patternDesc(req.QueryParameters[name]), false)
+			continue
+		}
+		conditions = append(conditions, cond)
+	}
+	for i, bp := range req.BodyPatterns {
+		cond, ok := bodyPatternCondition(bp)
+		if !ok {
+			b, _ := json.Marshal(bp)
+			report.Add(item, fmt.Sprintf("request.bodyPatterns[%d]", i), string(b), false)
+			continue
+		}
+		conditions = append(conditions, cond)
+	}
+	if len(req.Cookies) > 0 {
+		report.Add(item, "request.cookies", "", false)
+	}
+	if len(req.BasicAuth) > 0 {
+		report.Add(item, "request.basicAuthCredentials", "", false)
+	}
+	action, ok := wireMockResponseAction(item, stub.Response, report)
+	if !ok {
+		return nil, false
+	}
+
+	priority := stub.Priority
+	if priority == 0 {
+		priority = wireMockDefaultPriority
+	}
+	if priority > wireMockPriorityBase {
+		report.Add(item, "priority", fmt.Sprintf("priority %d is lower than supported, clamped to 0", priority), false)
+		priority = wireMockPriorityBase
+	}
+
+	rules := make([]*model.MockRule, 0, len(methods))
+	for _, method := range methods {
+		id := model.GenerateRuleID("wm", item)
+		if len(methods) > 1 {
+			id = model.GenerateRuleID("wm", item, method)
+		}
+		ruleAction := *action
+		ruleAction.Headers = maps.Clone(action.Headers)
+		rules = append(rules, &model.MockRule{
+			ID:       id,
+			Name:     truncate(item, 50),
+			Protocol: string(model.ProtocolHTTP),
+			MatchConfig: model.MatchConfig{Logical: "AND", Conditions: append([]model.MatchCondition{
+				{Type: model.MatchMethod, Operator: model.OpEqual, Value: method},
+			}, conditions...)},
+			ActionConfig: model.ActionConfigWrapper{AType: model.ActionTypeResponse, Config: &ruleAction},
+			Priority:     wireMockPriorityBase - priority,
+			Status:       model.RuleStatusActive,
+			Version:      1,
+		})
+	}
+	return rules, true
+}
+
+// wireMockPathCondition 将 urlPathPattern 转换为 path 条件：由 [0-9] 或 \d 组成的段作为数字参数段，
+// 其余段须为字面量（允许转义），无法由匹配索引定位的正则返回错误，参见 PathTemplateCondition
+func wireMockPathCondition(pattern string) (model.MatchCondition, error) {
+	path := strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+	return routablePathCondition(path, func(seg string) (string, bool, error) {
+		if wireMockNumericSegment.MatchString(seg) {
+			return seg, true, nil
+		}
+		if strings.ContainsAny(regexEscapeRegex.ReplaceAllString(seg, ""), pathRegexMeta) {
+			return "", false, fmt.Errorf("path segment %q is neither a literal nor a numeric pattern, which the match index cannot route", seg)
+		}
+		return regexEscapeRegex.ReplaceAllString(seg, "$1"), false, nil
+	})
+}
+
+func wireMockResponseAction(item string, resp WireMockResponse, report *model.ConversionReport) (*model.ResponseAction, bool) {
+	status := resp.Status
+	if status == 0 {
+		status = 200
+	}
+	action := &model.ResponseAction{
+		StatusCode: status,
+		Headers:    make(map[string]string, len(resp.Headers)),
+		Delay:      time.Duration(resp.FixedDelayMilliseconds) * time.Millisecond,
+	}
+	for k, v := range resp.Headers {
+		switch t := v.(type) {
+		case string:
+			action.Headers[k] = t
+		case []any:
+			parts := make([]string, 0, len(t))
+			for _, p := range t {
+				parts = append(parts, fmt.Sprint(p))
+			}
+			action.Headers[k] = strings.Join(parts, ",")
+		default:
+			action.Headers[k] = fmt.Sprint(t)
+		}
+	}
+
+	switch {
+	case resp.Base64Body != "":
+		decoded, err := base64.StdEncoding.DecodeString(resp.Base64Body)
+		if err != nil {
+			report.Add(item, "response.base64Body", err.Error(), true)
+			return nil, false
+		}
+		action.BodyBase64 = resp.Base64Body
+		action.BodyBytes = decoded
+	case resp.JSONBody != nil:
+		b, err := json.Marshal(resp.JSONBody)
+		if err != nil {
+			report.Add(item, "response.jsonBody", err.Error(), true)
+			return nil, false
+		}
+		action.Body = string(b)
+		if _, ok := action.Headers["Content-Type"]; !ok {
+			action.Headers["Content-Type"] = "application/json"
+		}
+	default:
+		action.Body = resp.Body
+	}
+
+	for _, t := range resp.Transformers {
+		// WireMock 模板为 Handlebars 语法，与 Go template 不兼容，按原文返回
+		report.Add(item, "response.transformers", t, false)
+	}
+	if len(resp.DelayDistribution) > 0 {
+		report.Add(item, "response.delayDistribution", string(resp.DelayDistribution), false)
+	}
+	return action, true
+}
+
+// patternCondition 将 WireMock 字符串匹配器转换为匹配条件
+func patternCondition(condType, key string, p WireMockPattern) (model.MatchCondition, bool) {
+	cond := model.MatchCondition{Type: condType, Key: key}
+	if len(p) != 1 {
+		return cond, false
+	}
+	for op, v := range p {
+		switch op {
+		case "equalTo":
+			cond.Operator, cond.Value = model.OpEqual, fmt.Sprint(v)
+		case "matches":
+			cond.Operator, cond.Value = model.OpRegex, fmt.Sprint(v)
+		case "contains":
+			cond.Operator, cond.Value = model.OpContains, fmt.Sprint(v)
+		case "absent":
+			if b, ok := v.(bool); ok && !b {
+				cond.Operator, cond.Value = model.OpExists, ""
+				return cond, true
+			}
+			return cond, false
+		default:
+			return cond, false
+		}
+	}
+	return cond, true
+}
+
+// bodyPatternCondition 仅支持带 equalTo 的 matchesJsonPath
+func bodyPatternCondition(bp map[string]any) (model.MatchCondition, bool) {
+	v, ok := bp["matchesJsonPath"]
+	if !ok || len(bp) != 1 {
+		return model.MatchCondition{}, false
+	}
+	expr, ok := v.(map[string]any)
+	if !ok {
+		return model.MatchCondition{}, false
+	}
+	path, _ := expr["expression"].(string)
+	value, ok := expr["equalTo"].(string)
+	if path == "" || !ok || len(expr) != 2 {
+		return model.MatchCondition{}, false
+	}
+	return model.MatchCondition{Type: "body_json", Operator: model.OpJsonPath, Key: path, Value: value}, true
+}
+
+func patternDesc(p WireMockPattern) string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+// ToWireMock 将规则导出为 WireMock stub mappings
+func ToWireMock(rules []*model.MockRule) (*WireMockMappings, *model.ConversionReport) {
+	report := &model.ConversionReport{}
+	mappings := &WireMockMappings{Mappings: make([]*WireMockStub, 0, len(rules))}
+
+	for _, rule := range rules {
+		item := rule.Name
+		if p := strings.ToLower(rule.Protocol); p != string(model.ProtocolHTTP) && p != string(model.ProtocolHTTPS) {
+			report.Add(item, "protocol", rule.Protocol, true)
+			continue
+		}
+		if strings.ToUpper(rule.MatchConfig.Logical) == "OR" && len(rule.MatchConfig.Conditions) > 1 {
+			report.Add(item, "match.logical", "OR is exported as AND", false)
+		}
+
+		stub := &WireMockStub{
+			ID:       wireMockUUID(rule.ID),
+			Name:     rule.Name,
+			Priority: clampWireMockPriority(wireMockPriorityBase - rule.Priority),
+			Request:  WireMockRequest{Method: "ANY"},
+		}
+		if stub.Priority != wireMockPriorityBase-rule.Priority {
+			report.Add(item, "priority", fmt.Sprintf("priority %d clamped to wiremock priority %d", rule.Priority, stub.Priority), false)
+		}
+
+		for i, cond := range rule.MatchConfig.Conditions {
+			if !conditionToWireMock(&stub.Request, cond) {
+				b, _ := json.Marshal(cond)
+				report.Add(item, fmt.Sprintf("match.conditions[%d]", i), string(b), false)
+			}
+		}
+
+		resp, ok := actionToWireMock(item, rule.ActionConfig, report)
+		if !ok {
+			continue
+		}
+		stub.Response = *resp
+		mappings.Mappings = append(mappings.Mappings, stub)
+		report.Converted++
+	}
+	return mappings, report
+}
+
+func conditionToWireMock(req *WireMockRequest, cond model.MatchCondition) bool {
+	value := fmt.Sprint(cond.Value)
+	key, _ := cond.Key.(string)
+	op := strings.ToLower(cond.Operator)
+
+	switch strings.ToLower(cond.Type) {
+	case model.MatchMethod:
+		if op != model.OpEqual && op != "" {
+			return false
+		}
+		req.Method = strings.ToUpper(value)
+		return true
+	case model.MatchPath:
+		// 只有 eq 与 regex 能等价翻译，其他操作符写入报告
+		switch op {
+		case model.OpEqual, "":
+			req.URLPath = value
+		case model.OpRegex:
+			req.URLPathPattern = value
+		default:
+			return false
+		}
+		return true
+	case model.MatchHeader:
+		p, ok := wireMockPattern(op, value)
+		if !ok || key == "" {
+			return false
+		}
+		if req.Headers == nil {
+			req.Headers = make(map[string]WireMockPattern)
+		}
+		req.Headers[key] = p
+		return true
+	case model.MatchQueryParam:
+		p, ok := wireMockPattern(op, value)
+		if !ok || key == "" {
+			return false
+		}
+		if req.QueryParameters == nil {
+			req.QueryParameters = make(map[string]WireMockPattern)
+		}
+		req.QueryParameters[key] = p
+		return true
+	case "body_json":
+		// body_json 按 JSONPath 结果做字符串相等比较，对应 matchesJsonPath + equalTo
+		if _, ok := cond.Value.(string); !ok || key == "" {
+			return false
+		}
+		if op != model.OpJsonPath && op != model.OpEqual && op != "" {
+			return false
+		}
+		req.BodyPatterns = append(req.BodyPatterns, map[string]any{
+			"matchesJsonPath": map[string]any{"expression": key, "equalTo": value},
+		})
+		return true
+	default:
+		return false
+	}
+}
+
+func wireMockPattern(op, value string) (WireMockPattern, bool) {
+	switch op {
+	case model.OpEqual, "":
+		return WireMockPattern{"equalTo": value}, true
+	case model.OpRegex:
+		return WireMockPattern{"matches": value}, true
+	case model.OpContains:
+		return WireMockPattern{"contains": value}, true
+	case model.OpExists:
+		return WireMockPattern{"absent": false}, true
+	default:
+		return nil, false
+	}
+}
+
+func actionToWireMock(item string, action model.ActionConfigWrapper, report *model.ConversionReport) (*WireMockResponse, bool) {
+	var resp *model.ResponseAction
+	switch cfg := action.Config.(type) {
+	case *model.ResponseAction:
+		resp = cfg
+	case *model.SequenceAction:
+		if len(cfg.Responses) == 0 {
+			report.Add(item, "action.sequence", "empty sequence", true)
+			return nil, false
+		}
+		report.Add(item, "action.sequence", "only the first response is exported", false)
+		resp = cfg.Responses[0]
+	default:
+		report.Add(item, "action.type", string(action.AType), true)
+		return nil, false
+	}
+
+	out := &WireMockResponse{
+		Status:                 resp.StatusCode,
+		FixedDelayMilliseconds: int(resp.Delay / time.Millisecond),
+	}
+	if len(resp.Headers) > 0 {
+		out.Headers = make(map[string]any, len(resp.Headers))
+		for k, v := range resp.Headers {
+			out.Headers[k] = v
+		}
+	}
+	if len(resp.BodyBytes) > 0 {
+		out.Base64Body = base64.StdEncoding.EncodeToString(resp.BodyBytes)
+	} else {
+		out.Body = resp.Body
+	}
+	if resp.Template {
+		report.Add(item, "action.template", "go templates are exported verbatim", false)
+	}
+	return out, true
+}
+
+// wireMockUUID WireMock 要求 stub id 为 UUID，这里由规则 ID 派生
+func wireMockUUID(ruleID string) string {
+	h := model.GenerateRuleID("x", ruleID)[2:]
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32])
+}
+
+func clampWireMockPriority(p int) int {
+	if p < 1 {
+		return 1
+	}
+	return p
+}
+
+func sortedKeys(v url.Values) []string {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedPatternKeys(m map[string]WireMockPattern) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
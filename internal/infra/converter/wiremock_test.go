@@ -0,0 +1,157 @@
+package converter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const wireMockStubs = `{
+  "mappings": [
+    {
+      "name": "get user",
+      "priority": 1,
+      "request": {
+        "method": "GET",
+        "urlPath": "/users/1",
+        "headers": {"X-Token": {"equalTo": "abc"}},
+        "queryParameters": {"verbose": {"matches": "true|yes"}}
+      },
+      "response": {"status": 200, "jsonBody": {"id": 1}, "headers": {"Content-Type": "application/json"}}
+    },
+    {
+      "name": "broken",
+      "request": {"method": "GET", "url": "/broken"},
+      "response": {"fault": "CONNECTION_RESET_BY_PEER"}
+    },
+    {
+      "name": "scenario",
+      "scenarioName": "flow",
+      "request": {"method": "POST", "url": "/orders"},
+      "response": {"status": 201, "body": "created"}
+    }
+  ]
+}`
+
+func TestFromWireMock(t *testing.T) {
+	rules, report, err := FromWireMock([]byte(wireMockStubs))
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, 2, report.Converted)
+	assert.NotEmpty(t, report.Unsupported)
+
+	ctx := context.Background()
+	for _, rule := range rules {
+		assert.NoError(t, rule.Validate())
+	}
+	assert.Greater(t, rules[0].Priority, rules[1].Priority)
+
+	httpReq, _ := http.NewRequest("GET", "http://localhost/users/1?verbose=yes", strings.NewReader(""))
+	httpReq.Header.Set("X-Token", "abc")
+	req := model.NewHTTPRequest(httpReq)
+	assert.True(t, rules[0].IsMatch(ctx, req))
+
+	resp, err := rules[0].ExecuteAction(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.GetStatus())
+	assert.JSONEq(t, `{"id":1}`, string(resp.GetBody()))
+
+	httpReq, _ = http.NewRequest("GET", "http://localhost/users/1?verbose=no", strings.NewReader(""))
+	httpReq.Header.Set("X-Token", "abc")
+	assert.False(t, rules[0].IsMatch(ctx, model.NewHTTPRequest(httpReq)))
+}
+
+func TestWireMockRoundTrip(t *testing.T) {
+	rules, _, err := FromWireMock([]byte(wireMockStubs))
+	assert.NoError(t, err)
+
+	mappings, report := ToWireMock(rules)
+	assert.Len(t, mappings.Mappings, 2)
+	assert.Equal(t, 2, report.Converted)
+
+	data, err := json.Marshal(mappings)
+	assert.NoError(t, err)
+	again, _, err := FromWireMock(data)
+	assert.NoError(t, err)
+	assert.Len(t, again, len(rules))
+	for i := range rules {
+		assert.Equal(t, rules[i].Priority, again[i].Priority)
+		assert.ElementsMatch(t, rules[i].MatchConfig.Conditions, again[i].MatchConfig.Conditions)
+	}
+}
+
+func TestToWireMockUnsupportedOperators(t *testing.T) {
+	rule := &model.MockRule{
+		Name:     "partial",
+		Protocol: "http",
+		MatchConfig: model.MatchConfig{Logical: "AND", Conditions: []model.MatchCondition{
+			{Type: model.MatchMethod, Operator: model.OpEqual, Value: "POST"},
+			{Type: model.MatchPath, Operator: model.OpContains, Value: "/users"},
+			{Type: "body_json", Operator: model.OpJsonPath, Key: "$.name", Value: "bob"},
+			{Type: "body_json", Operator: model.OpExists, Key: "$.id", Value: ""},
+			{Type: "body_json", Operator: model.OpJsonPath, Key: "$.age", Value: 3},
+		}},
+		ActionConfig: model.ActionConfigWrapper{AType: model.ActionTypeResponse, Config: &model.ResponseAction{StatusCode: 200}},
+	}
+	mappings, report := ToWireMock([]*model.MockRule{rule})
+	require.Len(t, mappings.Mappings, 1)
+	req := mappings.Mappings[0].Request
+	assert.Equal(t, "POST", req.Method)
+	assert.Empty(t, req.URLPath, "contains must not be exported as an exact path")
+	assert.Len(t, req.BodyPatterns, 1)
+
+	var features []string
+	for _, issue := range report.Unsupported {
+		features = append(features, issue.Feature)
+	}
+	assert.Equal(t, []string{"match.conditions[1]", "match.conditions[3]", "match.conditions[4]"}, features)
+}
+
+func TestFromWireMockRoutesByIndex(t *testing.T) {
+	rules, report, err := FromWireMock([]byte(`{"mappings": [
+		{"name": "any", "request": {"urlPath": "/api/x"}, "response": {"status": 200}},
+		{"name": "item", "request": {"method": "GET", "urlPathPattern": "/api/v1\\.0/items/\\d+"}, "response": {"status": 200}},
+		{"name": "wildcard", "request": {"method": "GET", "urlPathPattern": "/api/.*"}, "response": {"status": 200}},
+		{"name": "no url", "request": {"method": "GET"}, "response": {"status": 200}}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, rules, len(wireMockAnyMethods)+1)
+	assert.Equal(t, len(rules), report.Converted)
+
+	issues := make(map[string]bool)
+	for _, issue := range report.Unsupported {
+		issues[issue.Item+" "+issue.Feature] = issue.Skipped
+	}
+	assert.Equal(t, map[string]bool{
+		"any request.method":              false,
+		"wildcard request.urlPathPattern": true,
+		"no url request":                  true,
+	}, issues)
+
+	route := func(method, target string) *model.MockRule {
+		httpReq, _ := http.NewRequest(method, "http://localhost"+target, strings.NewReader(""))
+		req := model.NewHTTPRequest(httpReq)
+		for _, rule := range rules {
+			require.NoError(t, rule.Validate())
+			if rule.L1MatchIndex == req.GetMatchIndex() && rule.IsMatch(context.Background(), req) {
+				return rule
+			}
+		}
+		return nil
+	}
+	for _, method := range []string{"GET", "DELETE"} {
+		matched := route(method, "/api/x")
+		require.NotNil(t, matched, method)
+		assert.Equal(t, "any", matched.Name)
+	}
+	matched := route("GET", "/api/v1.0/items/42")
+	require.NotNil(t, matched)
+	assert.Equal(t, `^/api/v1.0/items/\d+$`, matched.MatchConfig.Conditions[1].Value)
+}
@@ -0,0 +1,117 @@
+// Package migration 版本化的数据库结构迁移，替代手工执行 script/sql/ddl.sql
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration 一次结构变更；已发布的迁移不可修改，新的变更追加新版本
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+}
+
+// SchemaMigration 记录已执行的迁移版本
+type SchemaMigration struct {
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"type:varchar(100);not null"`
+	AppliedAt int64  `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus 迁移状态
+type MigrationStatus struct {
+	Version   int    `json:"version"`
+	Name      string `json:"name"`
+	Applied   bool   `json:"applied"`
+	AppliedAt int64  `json:"appliedAt,omitempty"`
+}
+
+// migrations 按版本号递增排列
+var migrations = []Migration{
+	{Version: 1, Name: "baseline", Up: upBaseline},
+	{Version: 2, Name: "rule_match_index_columns", Up: upRuleMatchIndexColumns},
+	{Version: 3, Name: "rule_outbox", Up: upRuleOutbox},
+	{Version: 4, Name: "change_sets", Up: upChangeSets},
+	{Version: 5, Name: "rule_schedule", Up: upRuleSchedule},
+	{Version: 6, Name: "proto_descriptors", Up: upProtoDescriptors},
+}
+
+// Migrations 返回全部迁移（按版本排序）
+func Migrations() []Migration {
+	list := append([]Migration(nil), migrations...)
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list
+}
+
+// Run 依次执行未执行过的迁移，返回本次执行的版本号
+func Run(ctx context.Context, db *gorm.DB) ([]int, error) {
+	db = db.WithContext(ctx)
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []int
+	for _, m := range Migrations() {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		// MySQL 的 DDL 会隐式提交，事务主要保证版本记录与数据变更一致
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now().Unix()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s failed: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m.Version)
+	}
+	return done, nil
+}
+
+// Status 返回每个迁移的执行情况
+func Status(ctx context.Context, db *gorm.DB) ([]MigrationStatus, error) {
+	applied, err := appliedVersions(db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	list := Migrations()
+	status := make([]MigrationStatus, 0, len(list))
+	for _, m := range list {
+		s := MigrationStatus{Version: m.Version, Name: m.Name}
+		if rec, ok := applied[m.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = rec.AppliedAt
+		}
+		status = append(status, s)
+	}
+	return status, nil
+}
+
+func appliedVersions(db *gorm.DB) (map[int]SchemaMigration, error) {
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	var records []SchemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	applied := make(map[int]SchemaMigration, len(records))
+	for _, rec := range records {
+		applied[rec.Version] = rec
+	}
+	return applied, nil
+}
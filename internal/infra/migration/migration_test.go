@@ -0,0 +1,74 @@
+package migration
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "migrate.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestRunUpgradesLegacySchema(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	// 模拟通过 ddl.sql 手工建的旧表：没有匹配索引列
+	require.NoError(t, db.Exec(`CREATE TABLE mock_rules (
+		id VARCHAR(36) NOT NULL PRIMARY KEY,
+		name VARCHAR(50) NOT NULL,
+		protocol VARCHAR(20) NOT NULL,
+		match_config JSON NOT NULL,
+		action_config JSON NOT NULL,
+		priority INT DEFAULT 0,
+		status VARCHAR(20) NOT NULL,
+		version INT DEFAULT 1,
+		tags JSON NULL,
+		created_at INT NOT NULL,
+		updated_at INT NOT NULL)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO mock_rules (id, name, protocol, match_config, action_config, status, created_at, updated_at)
+		VALUES ('r1', 'users', 'http', '{"logical":"AND","conditions":[{"type":"method","operator":"eq","value":"GET"},{"type":"path","operator":"eq","value":"/api/users"}]}', '{}', 'active', 0, 0)`).Error)
+
+	versions, err := Run(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, versions)
+
+	for _, column := range []string{"method", "original_path", "path_pattern", "l1_match_index", "l2_match_index", "active_from", "active_until", "ttl"} {
+		assert.True(t, db.Migrator().HasColumn("mock_rules", column), column)
+	}
+	assert.True(t, db.Migrator().HasTable("mock_rule_histories"))
+	assert.True(t, db.Migrator().HasColumn("mock_rule_histories", "change_set_id"))
+	assert.True(t, db.Migrator().HasTable("mock_change_sets"))
+	assert.True(t, db.Migrator().HasTable("mock_change_set_items"))
+	assert.True(t, db.Migrator().HasTable("mock_proto_descriptors"))
+
+	var row struct {
+		Method       string
+		L1MatchIndex string
+	}
+	require.NoError(t, db.Table("mock_rules").Select("method", "l1_match_index").Where("id = ?", "r1").Scan(&row).Error)
+	assert.Equal(t, "GET", row.Method)
+	assert.Equal(t, "http_get_/api/users", row.L1MatchIndex)
+
+	// 再次执行不做任何变更
+	versions, err = Run(ctx, db)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+
+	status, err := Status(ctx, db)
+	require.NoError(t, err)
+	for _, s := range status {
+		assert.True(t, s.Applied, s.Name)
+	}
+}
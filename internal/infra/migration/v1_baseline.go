@@ -0,0 +1,64 @@
+package migration
+
+import "gorm.io/gorm"
+
+// v1 对应最初的 script/sql/ddl.sql，使用快照结构体而不是当前的领域模型，避免模型变化影响已发布的迁移
+// 已通过 ddl.sql 建好的库会跳过建表，只记录版本
+
+type v1MockRule struct {
+	ID           string `gorm:"primaryKey;type:varchar(36)"`
+	Name         string `gorm:"type:varchar(50);not null"`
+	Protocol     string `gorm:"type:varchar(20);not null;index:idx_protocol"`
+	MatchConfig  string `gorm:"type:json;not null"`
+	ActionConfig string `gorm:"type:json;not null"`
+	Priority     int    `gorm:"default:0;index:idx_priority"`
+	Status       string `gorm:"type:varchar(20);not null;index:idx_status"`
+	Version      int    `gorm:"default:1"`
+	Tags         string `gorm:"type:json"`
+	CreatedAt    int    `gorm:"not null;index:idx_created_at"`
+	UpdatedAt    int    `gorm:"not null"`
+}
+
+func (v1MockRule) TableName() string { return "mock_rules" }
+
+type v1MockRuleTag struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement"`
+	RuleID    string `gorm:"type:varchar(36);not null;uniqueIndex:uk_rule_tag"`
+	TagID     int    `gorm:"not null;uniqueIndex:uk_rule_tag;index:idx_tag_id"`
+	CreatedAt int    `gorm:"not null"`
+}
+
+func (v1MockRuleTag) TableName() string { return "mock_rule_tags" }
+
+type v1Tag struct {
+	ID        int    `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"type:varchar(50);not null;uniqueIndex:uk_name"`
+	CreatedAt int    `gorm:"not null"`
+	UpdatedAt int    `gorm:"not null"`
+}
+
+func (v1Tag) TableName() string { return "tags" }
+
+type v1MockRuleHistory struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement"`
+	RuleID     string `gorm:"type:varchar(36);not null;index:idx_rule_id"`
+	Version    int    `gorm:"not null"`
+	ChangeType string `gorm:"type:varchar(20);not null"`
+	Content    string `gorm:"type:json;not null"`
+	CreatedBy  int    `gorm:"not null"`
+	CreatedAt  int    `gorm:"not null;index:idx_history_created_at"` // SQLite 索引名全库唯一
+}
+
+func (v1MockRuleHistory) TableName() string { return "mock_rule_histories" }
+
+func upBaseline(tx *gorm.DB) error {
+	for _, table := range []any{&v1MockRule{}, &v1MockRuleTag{}, &v1Tag{}, &v1MockRuleHistory{}} {
+		if tx.Migrator().HasTable(table) {
+			continue
+		}
+		if err := tx.Migrator().CreateTable(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
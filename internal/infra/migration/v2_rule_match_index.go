@@ -0,0 +1,75 @@
+package migration
+
+import (
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"gorm.io/gorm"
+)
+
+// v2 补齐模型中已有、ddl.sql 缺失的匹配索引列，并为已有规则回填
+
+type v2MockRule struct {
+	Method       string `gorm:"type:varchar(20)"`
+	OriginalPath string `gorm:"type:varchar(255)"`
+	PathPattern  string `gorm:"type:varchar(255)"`
+	L1MatchIndex string `gorm:"type:varchar(255);index:idx_l1"`
+	L2MatchIndex string `gorm:"type:varchar(255);index:idx_l2"`
+}
+
+func (v2MockRule) TableName() string { return "mock_rules" }
+
+// v2RuleRow 回填时读取的字段
+type v2RuleRow struct {
+	ID          string
+	Protocol    string
+	MatchConfig model.MatchConfig
+}
+
+func (v2RuleRow) TableName() string { return "mock_rules" }
+
+func upRuleMatchIndexColumns(tx *gorm.DB) error {
+	m := tx.Migrator()
+	for _, field := range []string{"Method", "OriginalPath", "PathPattern", "L1MatchIndex", "L2MatchIndex"} {
+		if m.HasColumn(&v2MockRule{}, field) {
+			continue
+		}
+		if err := m.AddColumn(&v2MockRule{}, field); err != nil {
+			return err
+		}
+	}
+	for _, index := range []string{"idx_l1", "idx_l2"} {
+		if m.HasIndex(&v2MockRule{}, index) {
+			continue
+		}
+		if err := m.CreateIndex(&v2MockRule{}, index); err != nil {
+			return err
+		}
+	}
+	return backfillMatchIndex(tx)
+}
+
+// backfillMatchIndex 按 MockRule.ResolveMatchIndex 的规则计算派生字段
+func backfillMatchIndex(tx *gorm.DB) error {
+	var rows []v2RuleRow
+	if err := tx.Select("id", "protocol", "match_config").Where("l1_match_index IS NULL OR l1_match_index = ''").Find(&rows).Error; err != nil {
+		return fmt.Errorf("failed to read rules for backfill: %w", err)
+	}
+	for _, row := range rows {
+		rule := &model.MockRule{ID: row.ID, Protocol: row.Protocol, MatchConfig: row.MatchConfig}
+		if err := rule.ResolveMatchIndex(); err != nil {
+			// 无效的历史规则保持原样，不阻塞迁移
+			continue
+		}
+		err := tx.Model(&v2MockRule{}).Where("id = ?", row.ID).Updates(map[string]any{
+			"method":         rule.Method,
+			"original_path":  rule.OriginalPath,
+			"path_pattern":   rule.PathPattern,
+			"l1_match_index": rule.L1MatchIndex,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to backfill rule %s: %w", row.ID, err)
+		}
+	}
+	return nil
+}
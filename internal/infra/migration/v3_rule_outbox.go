@@ -0,0 +1,26 @@
+package migration
+
+import "gorm.io/gorm"
+
+// v3 规则变更 outbox，与规则在同一事务中写入，由分发器应用到 Redis
+
+type v3RuleOutbox struct {
+	ID            int64  `gorm:"primaryKey;autoIncrement"`
+	RuleID        string `gorm:"type:varchar(36);not null"`
+	Op            string `gorm:"type:varchar(20);not null"`
+	OldIndexKey   string `gorm:"type:varchar(255)"`
+	Attempts      int    `gorm:"not null;default:0"`
+	LastError     string `gorm:"type:varchar(512)"`
+	NextAttemptAt int64  `gorm:"not null;default:0;index:idx_outbox_pending,priority:2"`
+	DoneAt        int64  `gorm:"not null;default:0;index:idx_outbox_pending,priority:1"`
+	CreatedAt     int64  `gorm:"not null"`
+}
+
+func (v3RuleOutbox) TableName() string { return "mock_rule_outbox" }
+
+func upRuleOutbox(tx *gorm.DB) error {
+	if tx.Migrator().HasTable(&v3RuleOutbox{}) {
+		return nil
+	}
+	return tx.Migrator().CreateTable(&v3RuleOutbox{})
+}
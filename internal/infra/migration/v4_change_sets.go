@@ -0,0 +1,56 @@
+package migration
+
+import "gorm.io/gorm"
+
+// v4 变更集：暂存多条规则变更并原子发布，历史记录关联发布的变更集
+
+type v4ChangeSet struct {
+	ID          string `gorm:"primaryKey;type:varchar(36)"`
+	Name        string `gorm:"type:varchar(100);not null"`
+	Description string `gorm:"type:varchar(512)"`
+	Status      string `gorm:"type:varchar(20);not null;index:idx_change_set_status"`
+	CreatedAt   int64  `gorm:"not null"`
+	UpdatedAt   int64  `gorm:"not null"`
+	PublishedAt int64  `gorm:"not null;default:0"`
+}
+
+func (v4ChangeSet) TableName() string { return "mock_change_sets" }
+
+type v4ChangeSetItem struct {
+	ID           int64  `gorm:"primaryKey;autoIncrement"`
+	ChangeSetID  string `gorm:"type:varchar(36);not null;uniqueIndex:uk_change_set_rule,priority:1"`
+	RuleID       string `gorm:"type:varchar(36);not null;uniqueIndex:uk_change_set_rule,priority:2"`
+	Op           string `gorm:"type:varchar(20);not null"`
+	Rule         string `gorm:"type:json"`
+	TargetStatus string `gorm:"type:varchar(20)"`
+	CreatedAt    int64  `gorm:"not null"`
+}
+
+func (v4ChangeSetItem) TableName() string { return "mock_change_set_items" }
+
+type v4MockRuleHistory struct {
+	ChangeSetID string `gorm:"type:varchar(36);index:idx_history_change_set"`
+}
+
+func (v4MockRuleHistory) TableName() string { return "mock_rule_histories" }
+
+func upChangeSets(tx *gorm.DB) error {
+	m := tx.Migrator()
+	for _, table := range []any{&v4ChangeSet{}, &v4ChangeSetItem{}} {
+		if m.HasTable(table) {
+			continue
+		}
+		if err := m.CreateTable(table); err != nil {
+			return err
+		}
+	}
+	if !m.HasColumn(&v4MockRuleHistory{}, "ChangeSetID") {
+		if err := m.AddColumn(&v4MockRuleHistory{}, "ChangeSetID"); err != nil {
+			return err
+		}
+	}
+	if !m.HasIndex(&v4MockRuleHistory{}, "idx_history_change_set") {
+		return m.CreateIndex(&v4MockRuleHistory{}, "idx_history_change_set")
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package migration
+
+import "gorm.io/gorm"
+
+// v5 规则生效时间窗口：activeFrom / activeUntil / ttl
+
+type v5MockRule struct {
+	ActiveFrom  int64 `gorm:"not null;default:0"`
+	ActiveUntil int64 `gorm:"not null;default:0;index:idx_active_until"`
+	TTL         int64 `gorm:"column:ttl;not null;default:0"`
+}
+
+func (v5MockRule) TableName() string { return "mock_rules" }
+
+func upRuleSchedule(tx *gorm.DB) error {
+	m := tx.Migrator()
+	for _, field := range []string{"ActiveFrom", "ActiveUntil", "TTL"} {
+		if m.HasColumn(&v5MockRule{}, field) {
+			continue
+		}
+		if err := m.AddColumn(&v5MockRule{}, field); err != nil {
+			return err
+		}
+	}
+	if m.HasIndex(&v5MockRule{}, "idx_active_until") {
+		return nil
+	}
+	return m.CreateIndex(&v5MockRule{}, "idx_active_until")
+}
@@ -0,0 +1,26 @@
+package migration
+
+import "gorm.io/gorm"
+
+// v6 gRPC mock 使用的 protobuf 描述符，按名称递增版本
+
+type v6ProtoDescriptor struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"type:varchar(100);not null;uniqueIndex:uk_descriptor_version,priority:1"`
+	Version   int    `gorm:"not null;uniqueIndex:uk_descriptor_version,priority:2"`
+	Source    string `gorm:"type:varchar(20);not null"`
+	Content   []byte `gorm:"type:longblob;not null"`
+	Size      int    `gorm:"not null;default:0"`
+	Services  string `gorm:"type:json"`
+	CreatedAt int64  `gorm:"not null"`
+}
+
+func (v6ProtoDescriptor) TableName() string { return "mock_proto_descriptors" }
+
+func upProtoDescriptors(tx *gorm.DB) error {
+	m := tx.Migrator()
+	if m.HasTable(&v6ProtoDescriptor{}) {
+		return nil
+	}
+	return m.CreateTable(&v6ProtoDescriptor{})
+}
@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/utils"
+)
+
+// ChangeSetRepositoryIface 变更集仓库，发布后通过 outbox 更新缓存与索引
+type ChangeSetRepositoryIface interface {
+	CreateChangeSet(ctx context.Context, cs *model.ChangeSet) error
+	GetChangeSet(ctx context.Context, id string) (*model.ChangeSet, error)
+	ListChangeSets(ctx context.Context, status model.ChangeSetStatus) ([]*model.ChangeSet, error)
+	SaveChangeSetItem(ctx context.Context, item *model.ChangeSetItem) error
+	DeleteChangeSetItem(ctx context.Context, changeSetID, ruleID string) error
+	DiscardChangeSet(ctx context.Context, id string) error
+	PublishChangeSet(ctx context.Context, id string, upserts []*model.MockRule, deleteIDs []string, histories []*model.RuleHistory) error
+	ListRuleHistory(ctx context.Context, ruleID string) ([]*model.RuleHistory, error)
+	// BatchGetRules 从数据库读取规则当前版本，用于计算发布后的版本号
+	BatchGetRules(ctx context.Context, ruleIDs []string) ([]*model.MockRule, error)
+}
+
+var _ ChangeSetRepositoryIface = (*ruleRepoImpl)(nil)
+
+// ErrChangeSetUnsupported 当前规则来源（如文件目录）不支持变更集
+var ErrChangeSetUnsupported = errors.New("change sets are not supported by this rule source")
+
+// NewChangeSetRepository 变更集与规则共用同一存储，保证发布时的事务性
+func NewChangeSetRepository(ruleRepo RuleRepositoryIface) (ChangeSetRepositoryIface, error) {
+	r, ok := ruleRepo.(ChangeSetRepositoryIface)
+	if !ok {
+		return nil, ErrChangeSetUnsupported
+	}
+	return r, nil
+}
+
+func (r *ruleRepoImpl) CreateChangeSet(ctx context.Context, cs *model.ChangeSet) error {
+	return r.mysqlStorage.CreateChangeSet(ctx, cs)
+}
+
+func (r *ruleRepoImpl) GetChangeSet(ctx context.Context, id string) (*model.ChangeSet, error) {
+	cs, err := r.mysqlStorage.GetChangeSet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cs == nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrChangeSetNotFound, id)
+	}
+	return cs, nil
+}
+
+func (r *ruleRepoImpl) ListChangeSets(ctx context.Context, status model.ChangeSetStatus) ([]*model.ChangeSet, error) {
+	return r.mysqlStorage.ListChangeSets(ctx, status)
+}
+
+func (r *ruleRepoImpl) SaveChangeSetItem(ctx context.Context, item *model.ChangeSetItem) error {
+	return r.mysqlStorage.SaveChangeSetItem(ctx, item)
+}
+
+func (r *ruleRepoImpl) DeleteChangeSetItem(ctx context.Context, changeSetID, ruleID string) error {
+	return r.mysqlStorage.DeleteChangeSetItem(ctx, changeSetID, ruleID)
+}
+
+func (r *ruleRepoImpl) DiscardChangeSet(ctx context.Context, id string) error {
+	return r.mysqlStorage.DiscardChangeSet(ctx, id)
+}
+
+// PublishChangeSet 原子发布变更集，提交后同步分发 outbox 事件
+func (r *ruleRepoImpl) PublishChangeSet(ctx context.Context, id string, upserts []*model.MockRule, deleteIDs []string, histories []*model.RuleHistory) error {
+	if err := r.mysqlStorage.PublishChangeSet(ctx, id, upserts, deleteIDs, histories); err != nil {
+		return fmt.Errorf("failed to publish change set: %w", err)
+	}
+	if _, err := r.dispatcher.DispatchOnce(ctx); err != nil {
+		utils.GetLogger().Warnf("change set %s published, cache and index update deferred: %v", id, err)
+	}
+	return nil
+}
+
+func (r *ruleRepoImpl) ListRuleHistory(ctx context.Context, ruleID string) ([]*model.RuleHistory, error) {
+	return r.mysqlStorage.ListRuleHistory(ctx, ruleID)
+}
+
+func (r *ruleRepoImpl) BatchGetRules(ctx context.Context, ruleIDs []string) ([]*model.MockRule, error) {
+	if len(ruleIDs) == 0 {
+		return nil, nil
+	}
+	return r.mysqlStorage.BatchGetRules(ctx, ruleIDs)
+}
@@ -0,0 +1,304 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/infra/storage"
+	"go_mock_server/utils"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// 注册常用的 well-known types，上传的描述符集合可以不包含它们
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// DescriptorRepositoryIface protobuf 描述符仓库：持久化上传的描述符，并维护由各名称最新版本组成的类型注册表
+type DescriptorRepositoryIface interface {
+	// SaveDescriptor 校验与已注册描述符合并后可以正常链接，然后保存为新版本并立即生效
+	SaveDescriptor(ctx context.Context, d *model.ProtoDescriptorSet) error
+	ListDescriptors(ctx context.Context, name string) ([]*model.ProtoDescriptorSet, error)
+	GetDescriptor(ctx context.Context, name string, version int) (*model.ProtoDescriptorSet, error)
+	// DeleteDescriptor 删除指定版本（0 表示全部版本），删除最新版本后上一个版本生效
+	DeleteDescriptor(ctx context.Context, name string, version int) (int64, error)
+	// LoadDescriptorSetFiles 加载本地 FileDescriptorSet 文件，仅保存在内存中，优先级低于上传的描述符
+	LoadDescriptorSetFiles(paths []string) error
+	// Reload 从存储重新构建注册表，用于多实例部署时同步其他实例的上传
+	Reload(ctx context.Context) error
+	// FindMethod 按 gRPC 完整方法名（/package.Service/Method）查找方法描述符
+	FindMethod(fullMethod string) (protoreflect.MethodDescriptor, error)
+	// Files 当前生效的描述符集合
+	Files() *protoregistry.Files
+}
+
+// descriptorRepoImpl 注册表为不可变快照，读操作只取当前快照，写操作串行构建新快照后整体替换
+type descriptorRepoImpl struct {
+	storage storage.DescriptorStorageIface
+	writeMu sync.Mutex
+	mu      sync.RWMutex
+	static  [][]*descriptorpb.FileDescriptorProto // 本地文件加载的描述符，按加载顺序
+	files   *protoregistry.Files
+}
+
+var _ DescriptorRepositoryIface = (*descriptorRepoImpl)(nil)
+
+// NewDescriptorRepository 描述符与规则共用同一存储；文件规则来源没有数据库，描述符只保存在内存中
+func NewDescriptorRepository(ruleRepo RuleRepositoryIface) (DescriptorRepositoryIface, error) {
+	var s storage.DescriptorStorageIface
+	if r, ok := ruleRepo.(*ruleRepoImpl); ok {
+		s = r.mysqlStorage
+	} else {
+		utils.GetLogger().Warn("rule source has no database, uploaded descriptors are kept in memory only")
+		s = storage.NewMemoryRuleStorage()
+	}
+	return NewDescriptorRepoImpl(s)
+}
+
+func NewDescriptorRepoImpl(s storage.DescriptorStorageIface) (DescriptorRepositoryIface, error) {
+	r := &descriptorRepoImpl{storage: s, files: new(protoregistry.Files)}
+	if err := r.Reload(context.Background()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *descriptorRepoImpl) SaveDescriptor(ctx context.Context, d *model.ProtoDescriptorSet) error {
+	set, services, err := model.ParseFileDescriptorSet(d.Content)
+	if err != nil {
+		return err
+	}
+	d.Services, d.Size = services, len(d.Content)
+
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	latest, err := r.storage.LatestDescriptors(ctx)
+	if err != nil {
+		return err
+	}
+	layers, err := r.storedLayers(latest, d.Name)
+	if err != nil {
+		return err
+	}
+	files, err := r.build(append(layers, set.GetFile()))
+	if err != nil {
+		return err
+	}
+	if err := r.storage.SaveDescriptor(ctx, d); err != nil {
+		return err
+	}
+	r.swap(files)
+	return nil
+}
+
+func (r *descriptorRepoImpl) ListDescriptors(ctx context.Context, name string) ([]*model.ProtoDescriptorSet, error) {
+	return r.storage.ListDescriptors(ctx, name)
+}
+
+func (r *descriptorRepoImpl) GetDescriptor(ctx context.Context, name string, version int) (*model.ProtoDescriptorSet, error) {
+	d, err := r.storage.GetDescriptor(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, fmt.Errorf("%w: %s version %d", model.ErrDescriptorNotFound, name, version)
+	}
+	return d, nil
+}
+
+func (r *descriptorRepoImpl) DeleteDescriptor(ctx context.Context, name string, version int) (int64, error) {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	versions, err := r.storage.ListDescriptors(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	// 删除后该名称生效的版本：剩余版本中最大的一个
+	remaining := 0
+	found := false
+	for _, d := range versions {
+		if version == 0 || d.Version == version {
+			found = true
+			continue
+		}
+		if d.Version > remaining {
+			remaining = d.Version
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("%w: %s version %d", model.ErrDescriptorNotFound, name, version)
+	}
+
+	latest, err := r.storage.LatestDescriptors(ctx)
+	if err != nil {
+		return 0, err
+	}
+	layers, err := r.storedLayers(latest, name)
+	if err != nil {
+		return 0, err
+	}
+	if remaining > 0 {
+		prev, err := r.storage.GetDescriptor(ctx, name, remaining)
+		if err != nil {
+			return 0, err
+		}
+		set, _, err := model.ParseFileDescriptorSet(prev.Content)
+		if err != nil {
+			return 0, fmt.Errorf("descriptor %s version %d: %w", name, remaining, err)
+		}
+		layers = append(layers, set.GetFile())
+	}
+	files, err := r.build(layers)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := r.storage.DeleteDescriptor(ctx, name, version)
+	if err != nil {
+		return 0, err
+	}
+	r.swap(files)
+	return deleted, nil
+}
+
+func (r *descriptorRepoImpl) LoadDescriptorSetFiles(paths []string) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read descriptor set %s: %w", path, err)
+		}
+		set, _, err := model.ParseFileDescriptorSet(data)
+		if err != nil {
+			return fmt.Errorf("descriptor set %s: %w", filepath.Base(path), err)
+		}
+		r.static = append(r.static, set.GetFile())
+	}
+	return r.reloadLocked(context.Background())
+}
+
+func (r *descriptorRepoImpl) Reload(ctx context.Context) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return r.reloadLocked(ctx)
+}
+
+func (r *descriptorRepoImpl) reloadLocked(ctx context.Context) error {
+	latest, err := r.storage.LatestDescriptors(ctx)
+	if err != nil {
+		return err
+	}
+	layers, err := r.storedLayers(latest, "")
+	if err != nil {
+		return err
+	}
+	files, err := r.build(layers)
+	if err != nil {
+		return err
+	}
+	r.swap(files)
+	return nil
+}
+
+func (r *descriptorRepoImpl) FindMethod(fullMethod string) (protoreflect.MethodDescriptor, error) {
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("malformed method name %q", fullMethod)
+	}
+	service, method := parts[0], parts[1]
+
+	desc, err := r.Files().FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("unknown service %s", service)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("unknown method %s for service %s", method, service)
+	}
+	return md, nil
+}
+
+func (r *descriptorRepoImpl) Files() *protoregistry.Files {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.files
+}
+
+func (r *descriptorRepoImpl) swap(files *protoregistry.Files) {
+	r.mu.Lock()
+	r.files = files
+	r.mu.Unlock()
+}
+
+// storedLayers 本地文件在前，上传的描述符按上传顺序在后，exclude 指定的名称不参与构建
+func (r *descriptorRepoImpl) storedLayers(latest []*model.ProtoDescriptorSet, exclude string) ([][]*descriptorpb.FileDescriptorProto, error) {
+	layers := append([][]*descriptorpb.FileDescriptorProto(nil), r.static...)
+	for _, d := range latest {
+		if d.Name == exclude {
+			continue
+		}
+		set, _, err := model.ParseFileDescriptorSet(d.Content)
+		if err != nil {
+			return nil, fmt.Errorf("descriptor %s version %d: %w", d.Name, d.Version, err)
+		}
+		layers = append(layers, set.GetFile())
+	}
+	return layers, nil
+}
+
+// build 合并各层描述符（同名文件后面的覆盖前面的）并链接，缺失的依赖（如 google/protobuf/*.proto）从全局注册表补齐
+func (r *descriptorRepoImpl) build(layers [][]*descriptorpb.FileDescriptorProto) (*protoregistry.Files, error) {
+	merged := make(map[string]*descriptorpb.FileDescriptorProto)
+	var order []string
+	for _, layer := range layers {
+		for _, fd := range layer {
+			if _, ok := merged[fd.GetName()]; !ok {
+				order = append(order, fd.GetName())
+			}
+			merged[fd.GetName()] = fd
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, name := range order {
+		set.File = append(set.File, merged[name])
+	}
+	for i := 0; i < len(set.File); i++ {
+		for _, dep := range set.File[i].GetDependency() {
+			if _, ok := merged[dep]; ok {
+				continue
+			}
+			fd, err := protoregistry.GlobalFiles.FindFileByPath(dep)
+			if err != nil {
+				return nil, fmt.Errorf("%w: missing dependency %s of %s", model.ErrDescriptorLink, dep, set.File[i].GetName())
+			}
+			merged[dep] = protodesc.ToFileDescriptorProto(fd)
+			set.File = append(set.File, merged[dep])
+		}
+	}
+
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrDescriptorLink, err)
+	}
+	return files, nil
+}
@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/internal/infra/converter"
+	"go_mock_server/internal/infra/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compileProto(t *testing.T, files map[string]string) []byte {
+	data, err := converter.CompileProtoFiles(context.Background(), files)
+	require.NoError(t, err)
+	return data
+}
+
+func TestDescriptorRepoVersions(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewMemoryRuleStorage()
+	r, err := NewDescriptorRepoImpl(s)
+	require.NoError(t, err)
+
+	v1 := compileProto(t, map[string]string{"user.proto": `syntax = "proto3";
+package user;
+message GetUserRequest { string id = 1; }
+message User { string id = 1; }
+service UserService { rpc GetUser(GetUserRequest) returns (User); }`})
+	v2 := compileProto(t, map[string]string{"user.proto": `syntax = "proto3";
+package user;
+import "google/protobuf/timestamp.proto";
+message GetUserRequest { string id = 1; }
+message User { string id = 1; google.protobuf.Timestamp created_at = 2; }
+service UserService {
+  rpc GetUser(GetUserRequest) returns (User);
+  rpc DeleteUser(GetUserRequest) returns (User);
+}`})
+
+	d1 := &model.ProtoDescriptorSet{Name: "user", Source: model.DescriptorSourceProto, Content: v1}
+	require.NoError(t, r.SaveDescriptor(ctx, d1))
+	assert.Equal(t, 1, d1.Version)
+	assert.Equal(t, []string{"user.UserService"}, d1.Services)
+	_, err = r.FindMethod("/user.UserService/DeleteUser")
+	assert.Error(t, err)
+
+	// 新版本替换旧版本中的同名文件
+	d2 := &model.ProtoDescriptorSet{Name: "user", Source: model.DescriptorSourceProto, Content: v2}
+	require.NoError(t, r.SaveDescriptor(ctx, d2))
+	assert.Equal(t, 2, d2.Version)
+	md, err := r.FindMethod("/user.UserService/DeleteUser")
+	require.NoError(t, err)
+	assert.NotNil(t, md.Output().Fields().ByName("created_at"))
+
+	// 另一个实例从存储重建，得到相同的注册表
+	other, err := NewDescriptorRepoImpl(s)
+	require.NoError(t, err)
+	_, err = other.FindMethod("/user.UserService/DeleteUser")
+	assert.NoError(t, err)
+
+	// 删除最新版本后回退到上一版本
+	n, err := r.DeleteDescriptor(ctx, "user", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	_, err = r.FindMethod("/user.UserService/DeleteUser")
+	assert.Error(t, err)
+	_, err = r.FindMethod("/user.UserService/GetUser")
+	assert.NoError(t, err)
+
+	_, err = r.DeleteDescriptor(ctx, "user", 5)
+	assert.True(t, errors.Is(err, model.ErrDescriptorNotFound))
+
+	n, err = r.DeleteDescriptor(ctx, "user", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	_, err = r.FindMethod("/user.UserService/GetUser")
+	assert.Error(t, err)
+}
+
+func TestDescriptorRepoRejectsUnlinkableSet(t *testing.T) {
+	ctx := context.Background()
+	r, err := NewDescriptorRepoImpl(storage.NewMemoryRuleStorage())
+	require.NoError(t, err)
+
+	common := compileProto(t, map[string]string{"common.proto": `syntax = "proto3";
+package common;
+message Page { int32 size = 1; }`})
+	require.NoError(t, r.SaveDescriptor(ctx, &model.ProtoDescriptorSet{Name: "common", Content: common}))
+
+	// 不同名称的描述符定义了同名类型，无法链接
+	dup := compileProto(t, map[string]string{"other/common.proto": `syntax = "proto3";
+package common;
+message Page { int32 size = 1; }`})
+	err = r.SaveDescriptor(ctx, &model.ProtoDescriptorSet{Name: "dup", Content: dup})
+	assert.True(t, errors.Is(err, model.ErrDescriptorLink), err)
+
+	err = r.SaveDescriptor(ctx, &model.ProtoDescriptorSet{Name: "bad", Content: []byte("not a descriptor set")})
+	assert.Error(t, err)
+
+	list, err := r.ListDescriptors(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, list, 1, "rejected descriptors are not saved")
+}
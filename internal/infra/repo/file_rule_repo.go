@@ -0,0 +1,330 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/converter"
+	"go_mock_server/utils"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const defaultReloadDebounce = 200 * time.Millisecond
+
+// ErrReadOnlyRuleSource 文件模式下规则以文件为准，不支持通过接口修改
+var ErrReadOnlyRuleSource = errors.New("rule source is read-only, edit the rule files instead")
+
+// fileRuleSet 一次加载得到的不可变规则集合，通过原子指针整体替换
+type fileRuleSet struct {
+	rules   []*model.MockRule
+	byID    map[string]*model.MockRule
+	byIndex map[string][]*model.MockRule
+	status  *model.RuleSourceStatus
+}
+
+// FileRuleRepo 从目录中的 JSON/YAML 文件加载规则，并在文件变化时热加载
+type FileRuleRepo struct {
+	dir      string
+	debounce time.Duration
+	watcher  *fsnotify.Watcher
+	active   atomic.Pointer[fileRuleSet]
+
+	mu        sync.Mutex                   // 保护 lastGood，串行化 reload
+	lastGood  map[string][]*model.MockRule // 每个文件最近一次成功加载的规则
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+var _ RuleRepositoryIface = (*FileRuleRepo)(nil)
+
+func NewRuleSourceConfig(c *configs.RuleConfig) *configs.RuleSourceConfig {
+	return &c.RuleSource
+}
+
+// NewFileRuleRepo 加载规则目录并开始监听变更，返回的 cleanup 用于停止监听
+func NewFileRuleRepo(config *configs.RuleSourceConfig) (*FileRuleRepo, func(), error) {
+	repo, err := newFileRuleRepo(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := repo.Watch(); err != nil {
+		return nil, nil, err
+	}
+	return repo, func() { repo.Close() }, nil
+}
+
+func newFileRuleRepo(config *configs.RuleSourceConfig) (*FileRuleRepo, error) {
+	info, err := os.Stat(config.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rule dir: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("rule dir %s is not a directory", config.Dir)
+	}
+
+	debounce := config.Debounce
+	if debounce <= 0 {
+		debounce = defaultReloadDebounce
+	}
+	repo := &FileRuleRepo{
+		dir:      config.Dir,
+		debounce: debounce,
+		lastGood: make(map[string][]*model.MockRule),
+		done:     make(chan struct{}),
+	}
+	repo.Reload()
+	return repo, nil
+}
+
+// Reload 重新扫描目录：解析成功的文件替换其规则，失败的文件保留上一次成功的规则并记录错误
+func (r *FileRuleRepo) Reload() *model.RuleSourceStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var fileErrors []model.RuleFileError
+	current := make(map[string][]*model.MockRule)
+	err := filepath.WalkDir(r.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			fileErrors = append(fileErrors, model.RuleFileError{File: path, Error: err.Error()})
+			return nil
+		}
+		if d.IsDir() || !converter.IsRuleFile(path) {
+			return nil
+		}
+		rel, _ := filepath.Rel(r.dir, path)
+		rules, err := r.loadFile(path, rel)
+		if err != nil {
+			fileErrors = append(fileErrors, model.RuleFileError{File: rel, Error: err.Error()})
+			if prev, ok := r.lastGood[rel]; ok {
+				current[rel] = prev
+			}
+			return nil
+		}
+		current[rel] = rules
+		return nil
+	})
+	if err != nil {
+		fileErrors = append(fileErrors, model.RuleFileError{File: r.dir, Error: err.Error()})
+	}
+	r.lastGood = current
+
+	set, dupErrors := buildFileRuleSet(current)
+	set.status = &model.RuleSourceStatus{
+		Dir:      r.dir,
+		Files:    len(current),
+		Rules:    len(set.rules),
+		LoadedAt: time.Now().Unix(),
+		Errors:   append(fileErrors, dupErrors...),
+	}
+	r.active.Store(set)
+
+	log := utils.GetLogger()
+	for _, fe := range set.status.Errors {
+		log.Errorf("failed to load rule file %s: %s", fe.File, fe.Error)
+	}
+	log.Infof("loaded %d rules from %d files in %s", set.status.Rules, set.status.Files, r.dir)
+	return set.status
+}
+
+func (r *FileRuleRepo) loadFile(path, rel string) ([]*model.MockRule, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return converter.FromRuleFile(rel, data, info.ModTime().Unix())
+}
+
+// buildFileRuleSet 合并各文件的规则并建立索引，ID 重复的规则以文件名排序靠前者为准
+func buildFileRuleSet(files map[string][]*model.MockRule) (*fileRuleSet, []model.RuleFileError) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []model.RuleFileError
+	set := &fileRuleSet{
+		byID:    make(map[string]*model.MockRule),
+		byIndex: make(map[string][]*model.MockRule),
+	}
+	owner := make(map[string]string)
+	for _, name := range names {
+		for _, rule := range files[name] {
+			if prev, ok := owner[rule.ID]; ok {
+				errs = append(errs, model.RuleFileError{File: name, Error: fmt.Sprintf("duplicate rule id %s, already defined in %s", rule.ID, prev)})
+				continue
+			}
+			owner[rule.ID] = name
+			set.rules = append(set.rules, rule)
+			set.byID[rule.ID] = rule
+			set.byIndex[rule.L1MatchIndex] = append(set.byIndex[rule.L1MatchIndex], rule)
+		}
+	}
+	// 与 Redis 索引的 ZRevRange 顺序一致：优先级高的在前
+	for _, rules := range set.byIndex {
+		sort.SliceStable(rules, func(i, j int) bool {
+			return rules[i].Priority > rules[j].Priority
+		})
+	}
+	return set, errs
+}
+
+// Watch 监听目录（含子目录）变化，变更合并后触发 Reload
+func (r *FileRuleRepo) Watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	err = filepath.WalkDir(r.dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+	if err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch rule dir: %w", err)
+	}
+	r.watcher = watcher
+	go r.watchLoop()
+	return nil
+}
+
+func (r *FileRuleRepo) watchLoop() {
+	log := utils.GetLogger()
+	var timer *time.Timer
+	reload := make(chan struct{}, 1)
+	for {
+		select {
+		case <-r.done:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			// 新建的子目录也需要监听
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = r.watcher.Add(event.Name)
+				}
+			}
+			if timer == nil {
+				timer = time.AfterFunc(r.debounce, func() {
+					select {
+					case reload <- struct{}{}:
+					default:
+					}
+				})
+			} else {
+				timer.Reset(r.debounce)
+			}
+		case <-reload:
+			r.Reload()
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Errorf("rule dir watcher error: %v", err)
+		}
+	}
+}
+
+// Close 停止监听
+func (r *FileRuleRepo) Close() {
+	r.closeOnce.Do(func() {
+		close(r.done)
+		if r.watcher != nil {
+			r.watcher.Close()
+		}
+	})
+}
+
+// SourceStatus 返回最近一次加载的状态
+func (r *FileRuleRepo) SourceStatus() *model.RuleSourceStatus {
+	return r.active.Load().status
+}
+
+func (r *FileRuleRepo) FindByID(ctx context.Context, ruleID string) (*model.MockRule, error) {
+	rule, ok := r.active.Load().byID[ruleID]
+	if !ok {
+		return nil, fmt.Errorf("rule %s not found", ruleID)
+	}
+	return rule, nil
+}
+
+// GetStoredRule 文件规则没有独立的缓存，与 FindByID 相同
+func (r *FileRuleRepo) GetStoredRule(ctx context.Context, ruleID string) (*model.MockRule, error) {
+	return r.FindByID(ctx, ruleID)
+}
+
+func (r *FileRuleRepo) GetIndexRule(ctx context.Context, indexKey string) ([]*model.MockRule, error) {
+	rules := r.active.Load().byIndex[indexKey]
+	return append([]*model.MockRule(nil), rules...), nil
+}
+
+func (r *FileRuleRepo) FindBestMatchRule(ctx context.Context, req model.RequestInfo) (*model.MockRule, error) {
+	for _, rule := range r.active.Load().byIndex[req.GetMatchIndex()] {
+		if rule.IsMatch(ctx, req) {
+			return rule, nil
+		}
+	}
+	return nil, ErrNoMatchingRule
+}
+
+func (r *FileRuleRepo) ListRulesWithPage(ctx context.Context, filter *model.RuleFilter, page, pageSize int) ([]*model.MockRule, int64, error) {
+	var matched []*model.MockRule
+	for _, rule := range r.active.Load().rules {
+		if filter.Accept(rule) {
+			matched = append(matched, rule)
+		}
+	}
+	return paginateRules(matched, page, pageSize), int64(len(matched)), nil
+}
+
+func (r *FileRuleRepo) SaveRule(ctx context.Context, rule *model.MockRule) error {
+	return ErrReadOnlyRuleSource
+}
+
+func (r *FileRuleRepo) DeleteRule(ctx context.Context, ruleID string) error {
+	return ErrReadOnlyRuleSource
+}
+
+func (r *FileRuleRepo) ApplyRuleChanges(ctx context.Context, upserts []*model.MockRule, deleteIDs []string) error {
+	return ErrReadOnlyRuleSource
+}
+
+// paginateRules 对内存中的规则列表分页，page 从 1 开始
+func paginateRules(rules []*model.MockRule, page, pageSize int) []*model.MockRule {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		return rules
+	}
+	start := (page - 1) * pageSize
+	if start >= len(rules) {
+		return []*model.MockRule{}
+	}
+	end := start + pageSize
+	if end > len(rules) {
+		end = len(rules)
+	}
+	return rules[start:end]
+}
@@ -0,0 +1,107 @@
+package repo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userRuleJSON = `{
+  "protocol": "http",
+  "matcher": {
+    "logical": "AND",
+    "conditions": [
+      {"type": "method", "operator": "eq", "value": "POST"},
+      {"type": "path", "operator": "eq", "value": "/api/v1/users"}
+    ]
+  },
+  "action": {"type": "response", "statusCode": 201, "body": "created"},
+  "isEnabled": true,
+  "priority": 1
+}`
+
+const orderRulesYAML = `
+rules:
+  - id: list-orders
+    protocol: http
+    matcher:
+      logical: AND
+      conditions:
+        - {type: method, operator: eq, value: GET}
+        - {type: path, operator: eq, value: /api/v1/orders}
+    action:
+      type: response
+      statusCode: 200
+      body: "[]"
+`
+
+func TestFileRuleRepoReload(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "users.json", userRuleJSON)
+	writeFile(t, dir, "orders/orders.yaml", orderRulesYAML)
+	writeFile(t, dir, "broken.json", `{"protocol": "http"`)
+
+	repo, err := newFileRuleRepo(&configs.RuleSourceConfig{Dir: dir})
+	require.NoError(t, err)
+
+	status := repo.SourceStatus()
+	assert.Equal(t, 2, status.Rules)
+	if assert.Len(t, status.Errors, 1) {
+		assert.Equal(t, "broken.json", status.Errors[0].File)
+	}
+
+	ctx := context.Background()
+	req := model.NewSyntheticRequest("http", "POST", "/api/v1/users", nil, nil, nil)
+	rule, err := repo.FindBestMatchRule(ctx, req)
+	require.NoError(t, err)
+	resp, err := rule.ExecuteAction(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.GetStatus())
+
+	_, err = repo.FindByID(ctx, "list-orders")
+	assert.NoError(t, err)
+
+	// 文件被改坏时保留上一次成功加载的规则
+	writeFile(t, dir, "users.json", `{"protocol": `)
+	status = repo.Reload()
+	assert.Equal(t, 2, status.Rules)
+	assert.Len(t, status.Errors, 2)
+	_, err = repo.FindBestMatchRule(ctx, req)
+	assert.NoError(t, err)
+
+	// 删除文件后其规则被移除
+	require.NoError(t, os.Remove(filepath.Join(dir, "users.json")))
+	status = repo.Reload()
+	assert.Equal(t, 1, status.Rules)
+	_, err = repo.FindBestMatchRule(ctx, req)
+	assert.Error(t, err)
+
+	assert.ErrorIs(t, repo.SaveRule(ctx, rule), ErrReadOnlyRuleSource)
+}
+
+func TestFileRuleRepoWatch(t *testing.T) {
+	dir := t.TempDir()
+	repo, cleanup, err := NewFileRuleRepo(&configs.RuleSourceConfig{Dir: dir, Debounce: 20 * time.Millisecond})
+	require.NoError(t, err)
+	defer cleanup()
+	assert.Equal(t, 0, repo.SourceStatus().Rules)
+
+	writeFile(t, dir, "users.json", userRuleJSON)
+	assert.Eventually(t, func() bool {
+		return repo.SourceStatus().Rules == 1
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
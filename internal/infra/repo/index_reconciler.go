@@ -0,0 +1,182 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/storage"
+	"go_mock_server/utils"
+	"sync"
+	"time"
+)
+
+// IndexReconciler 以 MySQL 为准检查并修复 Redis 中的规则缓存与 L1 索引
+//
+// 索引更新在 ants 任务中异步执行，失败只记录日志，长期运行后会出现：
+// 删除后残留的规则 ID、优先级变化后分数未更新、缓存缺失等问题。
+type IndexReconciler struct {
+	mysqlStorage storage.MySQLRuleStorageIface
+	redisCache   storage.RedisRuleCacheIface
+	config       *configs.RuleRepoConfig
+	mu           sync.Mutex // 同一时间只允许一次检查
+}
+
+func NewIndexReconciler(mysqlStorage storage.MySQLRuleStorageIface, redisCache storage.RedisRuleCacheIface, config *configs.RuleRepoConfig) *IndexReconciler {
+	return &IndexReconciler{
+		mysqlStorage: mysqlStorage,
+		redisCache:   redisCache,
+		config:       config,
+	}
+}
+
+// Reconcile 扫描全部规则并修复缓存与索引；dryRun 时只生成报告不做修改
+// 检查期间新写入的规则可能被误判为孤儿，下一轮检查或下一次写入会自动恢复
+func (c *IndexReconciler) Reconcile(ctx context.Context, dryRun bool) (*model.IndexReconcileReport, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	start := time.Now()
+	report := &model.IndexReconcileReport{DryRun: dryRun, StartedAt: start.Unix(), Repaired: map[string]int{}}
+	defer func() {
+		report.DurationMs = time.Since(start).Milliseconds()
+	}()
+
+	rules, err := c.mysqlStorage.ListRules(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules from db: %w", err)
+	}
+	report.RulesScanned = len(rules)
+
+	// 1. 规则缓存
+	expected := make(map[string]map[string]float64) // indexKey -> ruleID -> priority
+	ruleIDs := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		ruleIDs[rule.ID] = struct{}{}
+		// 非 active 规则只保留缓存，不进入索引
+		if rule.Status.IsIndexable() {
+			key := rule.L1MatchIndex
+			if key == "" {
+				key = model.BuildL1MatchIndexKeyFromRule(rule)
+			}
+			if expected[key] == nil {
+				expected[key] = make(map[string]float64)
+			}
+			expected[key][rule.ID] = float64(rule.Priority)
+		}
+
+		if c.cacheUpToDate(ctx, rule) {
+			continue
+		}
+		report.AddRepair(model.RepairCacheRefreshed, "", rule.ID)
+		if !dryRun {
+			c.record(report, c.redisCache.SetRuleToCache(ctx, rule))
+		}
+	}
+
+	// 2. 索引：补齐缺失成员、修正分数、移除孤儿
+	keys, err := c.redisCache.ListIndexKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list index keys: %w", err)
+	}
+	allKeys := make(map[string]struct{}, len(keys)+len(expected))
+	for _, key := range keys {
+		allKeys[key] = struct{}{}
+	}
+	for key := range expected {
+		allKeys[key] = struct{}{}
+	}
+	report.KeysScanned = len(allKeys)
+
+	for key := range allKeys {
+		actual, err := c.redisCache.GetIndexMembers(ctx, key)
+		if err != nil {
+			c.record(report, err)
+			continue
+		}
+		for id, priority := range expected[key] {
+			score, ok := actual[id]
+			if ok && score == priority {
+				continue
+			}
+			if ok {
+				report.AddRepair(model.RepairIndexScoreFixed, key, id)
+			} else {
+				report.AddRepair(model.RepairIndexAdded, key, id)
+			}
+			if !dryRun {
+				c.record(report, c.redisCache.SetIndexCache(ctx, key, id))
+			}
+		}
+		for id := range actual {
+			if _, ok := expected[key][id]; ok {
+				continue
+			}
+			report.AddRepair(model.RepairIndexOrphan, key, id)
+			if !dryRun {
+				c.record(report, c.redisCache.RemoveIndexMember(ctx, key, id))
+			}
+		}
+	}
+
+	// 3. 已删除规则的缓存
+	cachedIDs, err := c.redisCache.ListCachedRuleIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cached rules: %w", err)
+	}
+	for _, id := range cachedIDs {
+		if _, ok := ruleIDs[id]; ok {
+			continue
+		}
+		report.AddRepair(model.RepairCacheOrphan, "", id)
+		if !dryRun {
+			c.record(report, c.redisCache.DeleteRuleFromCache(ctx, id))
+		}
+	}
+
+	return report, nil
+}
+
+// cacheUpToDate 比较缓存与数据库中的规则内容
+func (c *IndexReconciler) cacheUpToDate(ctx context.Context, rule *model.MockRule) bool {
+	cached, err := c.redisCache.GetRuleFromCache(ctx, rule.ID)
+	if err != nil || cached == nil {
+		return false
+	}
+	a, err1 := json.Marshal(cached)
+	b, err2 := json.Marshal(rule)
+	return err1 == nil && err2 == nil && string(a) == string(b)
+}
+
+func (c *IndexReconciler) record(report *model.IndexReconcileReport, err error) {
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+}
+
+// Run 按 ReconcileInterval 周期执行，直到 ctx 取消；间隔为 0 时不启动
+func (c *IndexReconciler) Run(ctx context.Context) {
+	interval := c.config.ReconcileInterval
+	if interval <= 0 {
+		return
+	}
+	log := utils.GetLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := c.Reconcile(ctx, false)
+			if err != nil {
+				log.Errorf("index reconcile failed: %v", err)
+				continue
+			}
+			if n := report.TotalRepaired(); n > 0 || len(report.Errors) > 0 {
+				log.Warnf("index reconcile repaired %d entries %v, errors: %v", n, report.Repaired, report.Errors)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReconcileTestRule(id string, priority int) *model.MockRule {
+	rule := &model.MockRule{
+		ID:       id,
+		Name:     id,
+		Protocol: "http",
+		Priority: priority,
+		Status:   model.RuleStatusActive,
+		MatchConfig: model.MatchConfig{
+			Logical: "AND",
+			Conditions: []model.MatchCondition{
+				{Type: "method", Operator: "eq", Value: "GET"},
+				{Type: "path", Operator: "eq", Value: "/api/users"},
+			},
+		},
+		ActionConfig: model.ActionConfigWrapper{
+			AType:  model.ActionTypeResponse,
+			Config: &model.ResponseAction{StatusCode: 200, Body: id},
+		},
+	}
+	_ = rule.Validate()
+	return rule
+}
+
+func TestIndexReconciler(t *testing.T) {
+	ctx := context.Background()
+	db := storage.NewMemoryRuleStorage()
+	cache := storage.NewMemoryRuleCache()
+
+	a := newReconcileTestRule("a", 1)
+	b := newReconcileTestRule("b", 2)
+	require.NoError(t, db.SaveRuleToDB(ctx, a))
+	require.NoError(t, db.SaveRuleToDB(ctx, b))
+
+	// a 的缓存优先级过期，b 不在索引中，deleted 已从数据库删除但仍在缓存和索引中
+	staleA := newReconcileTestRule("a", 9)
+	deleted := newReconcileTestRule("deleted", 5)
+	for _, rule := range []*model.MockRule{staleA, deleted} {
+		require.NoError(t, cache.SetRuleToCache(ctx, rule))
+		require.NoError(t, cache.UpdateIndexCache(ctx, rule))
+	}
+
+	reconciler := NewIndexReconciler(db, cache, configs.DefaultRuleRepoConfig())
+
+	report, err := reconciler.Reconcile(ctx, true)
+	require.NoError(t, err)
+	expected := map[string]int{
+		model.RepairCacheRefreshed:  2, // a 内容不一致，b 缺失
+		model.RepairIndexScoreFixed: 1,
+		model.RepairIndexAdded:      1,
+		model.RepairIndexOrphan:     1,
+		model.RepairCacheOrphan:     1,
+	}
+	assert.Equal(t, expected, report.Repaired)
+	ids, _ := cache.GetIndexCache(ctx, a.L1MatchIndex)
+	assert.Equal(t, []string{"a", "deleted"}, ids, "dry run must not modify the index")
+
+	report, err = reconciler.Reconcile(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, expected, report.Repaired)
+	assert.Empty(t, report.Errors)
+
+	ids, _ = cache.GetIndexCache(ctx, a.L1MatchIndex)
+	assert.Equal(t, []string{"b", "a"}, ids)
+	cachedIDs, _ := cache.ListCachedRuleIDs(ctx)
+	assert.Equal(t, []string{"a", "b"}, cachedIDs)
+
+	report, err = reconciler.Reconcile(ctx, false)
+	require.NoError(t, err)
+	assert.Zero(t, report.TotalRepaired())
+}
@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// grpc 规则保存后能通过请求侧构建的索引键找到
+func TestFindGRPCRuleThroughIndex(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(r.Close)
+	rule := &model.MockRule{
+		ID:       "get_user",
+		Name:     "get_user",
+		Protocol: "grpc",
+		Status:   model.RuleStatusActive,
+		MatchConfig: model.MatchConfig{Logical: "AND", Conditions: []model.MatchCondition{
+			{Type: "path", Operator: "eq", Value: "user.v1.UserService"},
+			{Type: "method", Operator: "eq", Value: "GetUser"},
+		}},
+		ActionConfig: model.ActionConfigWrapper{AType: model.ActionTypeResponse, Config: &model.ResponseAction{Body: `{}`}},
+	}
+	require.NoError(t, rule.Validate())
+	require.NoError(t, r.SaveRule(ctx, rule))
+
+	msg, err := structpb.NewStruct(map[string]any{"id": "1"})
+	require.NoError(t, err)
+	found, err := r.FindBestMatchRule(ctx, model.NewGRPCRequest(ctx, "/user.v1.UserService/GetUser", msg))
+	require.NoError(t, err)
+	assert.Equal(t, "get_user", found.ID)
+
+	_, err = r.FindBestMatchRule(ctx, model.NewGRPCRequest(ctx, "/user.v1.UserService/DeleteUser", msg))
+	assert.ErrorIs(t, err, ErrNoMatchingRule)
+}
@@ -0,0 +1,183 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/storage"
+	"go_mock_server/utils"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOutboxPollInterval = time.Second
+	defaultOutboxBatchSize    = 100
+	defaultOutboxRetention    = 24 * time.Hour
+	outboxMaxBackoff          = time.Minute
+)
+
+// OutboxDispatcher 将 outbox 事件应用到 Redis 缓存与索引
+//
+// 规则写入与 outbox 事件在同一事务中提交，分发失败的事件会按退避时间重试，
+// 进程重启后仍会被重新处理，从而保证缓存与索引最终与数据库一致。
+// 所有操作都是幂等的：upsert 以数据库中的最新内容为准。
+type OutboxDispatcher struct {
+	mysqlStorage storage.MySQLRuleStorageIface
+	redisCache   storage.RedisRuleCacheIface
+	config       *configs.RuleRepoConfig
+	notify       chan struct{}
+	mu           sync.Mutex // 串行分发，保证同一规则的事件按写入顺序应用
+}
+
+func NewOutboxDispatcher(mysqlStorage storage.MySQLRuleStorageIface, redisCache storage.RedisRuleCacheIface, config *configs.RuleRepoConfig) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		mysqlStorage: mysqlStorage,
+		redisCache:   redisCache,
+		config:       config,
+		notify:       make(chan struct{}, 1),
+	}
+}
+
+// Notify 唤醒分发循环，不阻塞
+func (d *OutboxDispatcher) Notify() {
+	select {
+	case d.notify <- struct{}{}:
+	default:
+	}
+}
+
+// DispatchOnce 处理当前全部到期事件，返回成功分发的数量
+func (d *OutboxDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	batchSize := d.config.OutboxBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultOutboxBatchSize
+	}
+
+	dispatched := 0
+	for {
+		events, err := d.mysqlStorage.FetchPendingOutbox(ctx, batchSize)
+		if err != nil {
+			return dispatched, err
+		}
+
+		var done []int64
+		failed := 0
+		for _, event := range events {
+			if err := d.apply(ctx, event); err != nil {
+				failed++
+				next := time.Now().Add(outboxBackoff(event.Attempts + 1)).Unix()
+				if markErr := d.mysqlStorage.MarkOutboxFailed(ctx, event.ID, err.Error(), next); markErr != nil {
+					return dispatched, markErr
+				}
+				continue
+			}
+			done = append(done, event.ID)
+		}
+		if err := d.mysqlStorage.MarkOutboxDone(ctx, done); err != nil {
+			return dispatched, err
+		}
+		dispatched += len(done)
+
+		if len(events) < batchSize || failed == len(events) {
+			if failed > 0 {
+				return dispatched, fmt.Errorf("%d outbox events failed, will retry", failed)
+			}
+			return dispatched, nil
+		}
+	}
+}
+
+func (d *OutboxDispatcher) apply(ctx context.Context, event *storage.RuleOutboxEvent) error {
+	switch event.Op {
+	case storage.OutboxOpUpsert:
+		rule, err := d.mysqlStorage.GetRuleFromDB(ctx, event.RuleID)
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			// 规则已被后续操作删除，按删除处理
+			return d.remove(ctx, event)
+		}
+		if err := d.redisCache.SetRuleToCache(ctx, rule); err != nil {
+			return err
+		}
+		if !rule.Status.IsIndexable() {
+			// 非 active 规则移出匹配索引
+			if err := d.redisCache.RemoveFromIndex(ctx, rule); err != nil {
+				return err
+			}
+		} else if err := d.redisCache.UpdateIndexCache(ctx, rule); err != nil {
+			return err
+		}
+		if event.OldIndexKey != "" && event.OldIndexKey != rule.L1MatchIndex {
+			return d.redisCache.RemoveIndexMember(ctx, event.OldIndexKey, rule.ID)
+		}
+		return nil
+	case storage.OutboxOpDelete:
+		return d.remove(ctx, event)
+	default:
+		return fmt.Errorf("unknown outbox op: %s", event.Op)
+	}
+}
+
+func (d *OutboxDispatcher) remove(ctx context.Context, event *storage.RuleOutboxEvent) error {
+	if event.OldIndexKey != "" {
+		if err := d.redisCache.RemoveIndexMember(ctx, event.OldIndexKey, event.RuleID); err != nil {
+			return err
+		}
+	}
+	return d.redisCache.DeleteRuleFromCache(ctx, event.RuleID)
+}
+
+// Run 启动分发循环：写入后被 Notify 唤醒，另外定期轮询以处理重试和重启前遗留的事件
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	log := utils.GetLogger()
+	interval := d.config.OutboxPollInterval
+	if interval <= 0 {
+		interval = defaultOutboxPollInterval
+	}
+	retention := d.config.OutboxRetention
+	if retention <= 0 {
+		retention = defaultOutboxRetention
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastPurge := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.notify:
+		case <-ticker.C:
+		}
+
+		if _, err := d.DispatchOnce(ctx); err != nil {
+			log.Warnf("outbox dispatch: %v", err)
+		}
+		if time.Since(lastPurge) > time.Hour {
+			lastPurge = time.Now()
+			if n, err := d.mysqlStorage.PurgeOutbox(ctx, time.Now().Add(-retention).Unix()); err != nil {
+				log.Warnf("outbox purge failed: %v", err)
+			} else if n > 0 {
+				log.Infof("purged %d outbox events", n)
+			}
+		}
+	}
+}
+
+// outboxBackoff 指数退避，最长 1 分钟
+func outboxBackoff(attempts int) time.Duration {
+	backoff := time.Second
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	return backoff
+}
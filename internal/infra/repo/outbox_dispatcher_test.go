@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyCache 在 failures 次数内写缓存失败
+type flakyCache struct {
+	storage.RedisRuleCacheIface
+	failures int
+}
+
+func (c *flakyCache) SetRuleToCache(ctx context.Context, rule *model.MockRule) error {
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("redis unavailable")
+	}
+	return c.RedisRuleCacheIface.SetRuleToCache(ctx, rule)
+}
+
+func indexMemberIDs(t *testing.T, cache storage.RedisRuleCacheIface, key string) []string {
+	members, err := cache.GetIndexMembers(context.Background(), key)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestOutboxDispatcher(t *testing.T) {
+	ctx := context.Background()
+	db := storage.NewMemoryRuleStorage()
+	cache := storage.NewMemoryRuleCache()
+	dispatcher := NewOutboxDispatcher(db, cache, configs.DefaultRuleRepoConfig())
+
+	rule := newReconcileTestRule("a", 1)
+	oldKey := rule.L1MatchIndex
+	require.NoError(t, db.SaveRuleToDB(ctx, rule))
+
+	n, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	cached, err := cache.GetRuleFromCache(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", cached.ID)
+	assert.Equal(t, []string{"a"}, indexMemberIDs(t, cache, oldKey))
+
+	// 路径变化后旧索引中的成员被移除
+	moved := newReconcileTestRule("a", 1)
+	moved.MatchConfig.Conditions[1].Value = "/api/orders"
+	require.NoError(t, moved.Validate())
+	require.NoError(t, db.ApplyRuleChanges(ctx, []*model.MockRule{moved}, nil))
+	_, err = dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, indexMemberIDs(t, cache, oldKey))
+	assert.Equal(t, []string{"a"}, indexMemberIDs(t, cache, moved.L1MatchIndex))
+
+	require.NoError(t, db.DeleteRuleFromDB(ctx, "a"))
+	_, err = dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, indexMemberIDs(t, cache, moved.L1MatchIndex))
+	_, err = cache.GetRuleFromCache(ctx, "a")
+	assert.Error(t, err)
+
+	pending, err := db.FetchPendingOutbox(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestOutboxDispatcherRetry(t *testing.T) {
+	ctx := context.Background()
+	db := storage.NewMemoryRuleStorage()
+	cache := &flakyCache{RedisRuleCacheIface: storage.NewMemoryRuleCache(), failures: 1}
+	dispatcher := NewOutboxDispatcher(db, cache, configs.DefaultRuleRepoConfig())
+
+	require.NoError(t, db.SaveRuleToDB(ctx, newReconcileTestRule("a", 1)))
+
+	_, err := dispatcher.DispatchOnce(ctx)
+	assert.Error(t, err)
+	_, err = cache.GetRuleFromCache(ctx, "a")
+	assert.Error(t, err)
+
+	// 失败事件在退避时间内不会被再次取出
+	pending, err := db.FetchPendingOutbox(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, outboxBackoff(1))
+	assert.Equal(t, 4*time.Second, outboxBackoff(3))
+	assert.Equal(t, time.Minute, outboxBackoff(10))
+}
+
+func TestRuleRepoSyncOutbox(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRuleRepo(configs.DefaultRuleRepoConfig())
+	t.Cleanup(repo.Close)
+
+	rule := newReconcileTestRule("a", 1)
+	require.NoError(t, repo.SaveRule(ctx, rule))
+	rules, err := repo.GetIndexRule(ctx, rule.L1MatchIndex)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	require.NoError(t, repo.DeleteRule(ctx, "a"))
+	rules, err = repo.GetIndexRule(ctx, rule.L1MatchIndex)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestOutboxDispatcherNonActiveRule(t *testing.T) {
+	ctx := context.Background()
+	db := storage.NewMemoryRuleStorage()
+	cache := storage.NewMemoryRuleCache()
+	dispatcher := NewOutboxDispatcher(db, cache, configs.DefaultRuleRepoConfig())
+
+	rule := newReconcileTestRule("a", 1)
+	require.NoError(t, db.SaveRuleToDB(ctx, rule))
+	_, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, indexMemberIDs(t, cache, rule.L1MatchIndex))
+
+	// 归档后移出索引，缓存仍保留最新状态
+	rule.Status = model.RuleStatusArchived
+	require.NoError(t, db.ApplyRuleChanges(ctx, []*model.MockRule{rule}, nil))
+	_, err = dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, indexMemberIDs(t, cache, rule.L1MatchIndex))
+	cached, err := cache.GetRuleFromCache(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, model.RuleStatusArchived, cached.Status)
+}
+
+func TestRuleRepoSyncModeRetriesFailedEvents(t *testing.T) {
+	ctx := context.Background()
+	config := configs.DefaultRuleRepoConfig()
+	config.OutboxPollInterval = 10 * time.Millisecond
+	cache := &flakyCache{RedisRuleCacheIface: storage.NewMemoryRuleCache(), failures: 1}
+	repo := NewRuleRepoImpl(storage.NewMemoryRuleStorage(), cache, nil, config)
+	t.Cleanup(repo.Close)
+
+	// 同步分发失败后，无需再次写入也会由后台循环重试
+	require.NoError(t, repo.SaveRule(ctx, newReconcileTestRule("a", 1)))
+	assert.Eventually(t, func() bool {
+		_, err := cache.GetRuleFromCache(ctx, "a")
+		return err == nil
+	}, 3*time.Second, 10*time.Millisecond)
+}
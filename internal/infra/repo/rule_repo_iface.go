@@ -2,17 +2,27 @@ package repo
 
 import (
 	"context"
+	"errors"
 	model "go_mock_server/internal/domain/model/mock_rule"
 )
 
+// ErrNoMatchingRule FindBestMatchRule 未找到命中的规则
+var ErrNoMatchingRule = errors.New("no matching rule found")
+
 // RuleRepository 接口 - 定义数据仓库操作
 type RuleRepositoryIface interface {
 	SaveRule(ctx context.Context, rule *model.MockRule) error
 	DeleteRule(ctx context.Context, ruleID string) error
 	FindByID(ctx context.Context, ruleID string) (*model.MockRule, error)
+	// GetStoredRule 绕过缓存读取存储中的最新规则，用于先读后写的操作
+	GetStoredRule(ctx context.Context, ruleID string) (*model.MockRule, error)
 	FindBestMatchRule(ctx context.Context, req model.RequestInfo) (*model.MockRule, error)
 	ListRulesWithPage(ctx context.Context, filter *model.RuleFilter, page, pageSize int) ([]*model.MockRule, int64, error)
 	// ListAll(ctx context.Context) ([]*MockRule, error)
 
 	GetIndexRule(ctx context.Context, indexKey string) ([]*model.MockRule, error)
+	// ApplyRuleChanges 事务性地批量写入/删除规则，并重建相关缓存与索引
+	ApplyRuleChanges(ctx context.Context, upserts []*model.MockRule, deleteIDs []string) error
+	// Close 停止仓库的后台任务
+	Close()
 }
@@ -8,6 +8,7 @@ import (
 	"go_mock_server/internal/infra/storage"
 	"go_mock_server/utils"
 	"strings"
+	"sync"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/go-redis/redis/v8"
@@ -23,25 +24,15 @@ type ruleRepoImpl struct {
 	config       *configs.RuleRepoConfig
 	taskPool     *ants.Pool
 	sfGroup      singleflight.Group
+	dispatcher   *OutboxDispatcher
+	cancel       context.CancelFunc // 停止后台循环
+	wg           sync.WaitGroup
+	closeOnce    sync.Once
 }
 
 // 确保 ruleRepoImpl 实现了 RuleRepository 接口 (编译时检查)
 var _ RuleRepositoryIface = (*ruleRepoImpl)(nil)
 
-// indexUpdateRequest 定义异步索引更新请求的结构体
-type indexUpdateRequest struct {
-	ctx           context.Context
-	rule          *model.MockRule
-	operationType indexOperationType
-}
-
-type indexOperationType string
-
-const (
-	indexOperationTypeUpdate = "update"
-	indexOperationTypeRemove = "remove"
-)
-
 func NewRuleRepoConfig(c *configs.RuleConfig) *configs.RuleRepoConfig {
 	return &c.RuleRepoConfig
 }
@@ -52,6 +43,7 @@ func NewRuleRepoImpl(mysqlStorage storage.MySQLRuleStorageIface, redisCache stor
 		panic(fmt.Errorf("failed to create ants pool: %w", err)) //  ants pool 初始化失败，直接 panic
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
 	repo := &ruleRepoImpl{
 		mysqlStorage: mysqlStorage,
 		redisCache:   redisCache,
@@ -59,10 +51,45 @@ func NewRuleRepoImpl(mysqlStorage storage.MySQLRuleStorageIface, redisCache stor
 		config:       config,
 		taskPool:     taskPool,             //  使用 ants pool
 		sfGroup:      singleflight.Group{}, // 初始化 singleflight Group
+		dispatcher:   NewOutboxDispatcher(mysqlStorage, redisCache, config),
+		cancel:       cancel,
+	}
+	// SyncCacheUpdate 只决定写入后是否同步分发，失败重试与 outbox 清理在任何模式下都需要后台循环
+	repo.goBackground(func() { repo.dispatcher.Run(ctx) })
+	// 过期归档与缓存模式无关，内存模式与不带 Redis 的 SQLite 模式同样需要
+	if config.ExpireSweepInterval >= 0 {
+		repo.goBackground(func() { repo.runExpireSweeper(ctx) })
 	}
 	return repo
 }
 
+// goBackground 启动后台循环，Close 时等待其退出
+func (r *ruleRepoImpl) goBackground(loop func()) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		loop()
+	}()
+}
+
+// Close 停止后台循环并释放任务池，需在关闭存储与 Redis 之前调用
+func (r *ruleRepoImpl) Close() {
+	r.closeOnce.Do(func() {
+		r.cancel()
+		r.wg.Wait()
+		r.taskPool.Release()
+	})
+}
+
+// submitTask 异步执行缓存与索引更新；SyncCacheUpdate 开启时同步执行，便于测试和单机模式读到自己的写入
+func (r *ruleRepoImpl) submitTask(task func()) error {
+	if r.config.SyncCacheUpdate {
+		task()
+		return nil
+	}
+	return r.taskPool.Submit(task)
+}
+
 // ListRulesWithPage 列出规则并支持分页
 func (r *ruleRepoImpl) ListRulesWithPage(ctx context.Context, filter *model.RuleFilter, page, pageSize int) ([]*model.MockRule, int64, error) {
 	// 使用 singleflight 防止并发查询
@@ -107,6 +134,9 @@ func (r *ruleRepoImpl) FindByID(ctx context.Context, id string) (*model.MockRule
 		if err != nil {
 			return nil, fmt.Errorf("failed to get rule from db: %w", err)
 		}
+		if rule == nil {
+			return nil, fmt.Errorf("rule %s not found", id)
+		}
 
 		// 设置缓存，使用重试机制
 		err = retry.Do(
@@ -130,13 +160,26 @@ func (r *ruleRepoImpl) FindByID(ctx context.Context, id string) (*model.MockRule
 	return data.(*model.MockRule), nil
 }
 
+// GetStoredRule 直接从数据库读取规则，缓存可能落后于数据库
+func (r *ruleRepoImpl) GetStoredRule(ctx context.Context, ruleID string) (*model.MockRule, error) {
+	rule, err := r.mysqlStorage.GetRuleFromDB(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule from db: %w", err)
+	}
+	if rule == nil {
+		return nil, fmt.Errorf("rule %s not found", ruleID)
+	}
+	return rule, nil
+}
+
 func (r *ruleRepoImpl) GetIndexRule(ctx context.Context, indexKey string) ([]*model.MockRule, error) {
 	// 2. 优先从 Redis sorted set 获取可能匹配的规则ID集合
 	ruleIDs, err := r.redisCache.GetIndexCache(ctx, indexKey)
 	if err != nil || len(ruleIDs) == 0 {
 		// Redis 未命中，从数据库中查找 path like 的规则集合
 		utils.GetLogger().Debugf("index cache miss for key: %s, now get data from db", indexKey)
-		rules, err := r.mysqlStorage.ListRules(ctx, &model.RuleFilter{L1MatchIndex: &indexKey})
+		active := true
+		rules, err := r.mysqlStorage.ListRules(ctx, &model.RuleFilter{L1MatchIndex: &indexKey, IsEnabled: &active})
 		if err != nil {
 			return nil, fmt.Errorf("failed to get rules from db: %w", err)
 		}
@@ -144,7 +187,7 @@ func (r *ruleRepoImpl) GetIndexRule(ctx context.Context, indexKey string) ([]*mo
 		// 将查询结果更新到 Redis
 		if len(rules) > 0 {
 			// Async update rule scores using task pool
-			if err := r.taskPool.Submit(func() {
+			if err := r.submitTask(func() {
 				err := retry.Do(
 					func() error {
 						for _, rule := range rules {
@@ -194,7 +237,7 @@ func (r *ruleRepoImpl) GetIndexRule(ctx context.Context, indexKey string) ([]*mo
 		rules = append(rules, dbRules...)
 
 		// 异步更新缓存
-		if err := r.taskPool.Submit(func() {
+		if err := r.submitTask(func() {
 			err := retry.Do(
 				func() error {
 					for _, rule := range dbRules {
@@ -246,7 +289,7 @@ func (r *ruleRepoImpl) FindBestMatchRule(ctx context.Context, req model.RequestI
 		}
 
 		if bestMatch == nil {
-			return nil, fmt.Errorf("no matching rule found")
+			return nil, ErrNoMatchingRule
 		}
 
 		return bestMatch, nil
@@ -259,11 +302,10 @@ func (r *ruleRepoImpl) FindBestMatchRule(ctx context.Context, req model.RequestI
 	return data.(*model.MockRule), nil
 }
 
-// SaveRule 保存规则，同时更新缓存和索引
+// SaveRule 保存规则，缓存和索引由 outbox 分发更新
 func (r *ruleRepoImpl) SaveRule(ctx context.Context, rule *model.MockRule) error {
-	log := utils.GetLogger()
 	_, err, _ := r.sfGroup.Do(fmt.Sprintf("save_rule_%s", rule.ID), func() (interface{}, error) {
-		// 1. Save to DB (primary source of truth)
+		// 规则与 outbox 事件在同一事务中写入
 		err := retry.Do(
 			func() error {
 				return r.mysqlStorage.SaveRuleToDB(ctx, rule)
@@ -275,29 +317,7 @@ func (r *ruleRepoImpl) SaveRule(ctx context.Context, rule *model.MockRule) error
 			return nil, fmt.Errorf("failed to save rule to db: %w", err)
 		}
 
-		// 2. Async update cache and index
-		r.taskPool.Submit(func() {
-			// Update cache
-			err := retry.Do(
-				func() error {
-					log.Infof("updating cache for rule %s", rule.ID)
-					return r.redisCache.SetRuleToCache(ctx, rule)
-				},
-				retry.Attempts(uint(r.config.RedisCacheRetryCount)),
-				retry.Delay(r.config.RedisCacheRetryDelay),
-			)
-			if err != nil {
-				log.Printf("async cache update failed: %v", err)
-			}
-
-			// Update index
-			r.handleIndexUpdate(&indexUpdateRequest{
-				ctx:           ctx,
-				rule:          rule,
-				operationType: indexOperationTypeUpdate,
-			})
-		})
-
+		r.afterWrite(ctx)
 		return rule, nil
 	})
 
@@ -342,69 +362,50 @@ func (r *ruleRepoImpl) GetRule(ctx context.Context, ruleID string) (*model.MockR
 	return data.(*model.MockRule), nil
 }
 
-// DeleteRule 删除规则，同时删除缓存和索引
+// DeleteRule 删除规则，缓存和索引由 outbox 分发清理
 func (r *ruleRepoImpl) DeleteRule(ctx context.Context, ruleID string) error {
 	// 使用 singleflight 防止并发删除
 	_, err, _ := r.sfGroup.Do(fmt.Sprintf("delete_rule_%s", ruleID), func() (interface{}, error) {
-		// 获取规则信息（用于更新索引）
 		rule, err := r.mysqlStorage.GetRuleFromDB(ctx, ruleID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get rule before delete: %w", err)
 		}
+		if rule == nil {
+			return nil, fmt.Errorf("rule %s not found", ruleID)
+		}
 
-		// 从数据库删除
 		if err := r.mysqlStorage.DeleteRuleFromDB(ctx, ruleID); err != nil {
 			return nil, fmt.Errorf("failed to delete rule from db: %w", err)
 		}
 
-		// 异步更新索引
-		updateReq := &indexUpdateRequest{
-			ctx:           ctx,
-			rule:          rule,
-			operationType: indexOperationTypeRemove,
-		}
-		if err := r.taskPool.Submit(func() {
-			r.handleIndexUpdate(updateReq)
-		}); err != nil {
-			return nil, fmt.Errorf("failed to submit index update task: %w", err)
-		}
-
-		// 删除缓存，使用重试机制
-		err = retry.Do(
-			func() error {
-				return r.redisCache.DeleteRuleFromCache(ctx, ruleID)
-			},
-			retry.Attempts(uint(r.config.RedisCacheRetryCount)),
-			retry.Delay(r.config.RedisCacheRetryDelay),
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to delete rule cache: %w", err)
-		}
-
+		r.afterWrite(ctx)
 		return nil, nil
 	})
 
 	return err
 }
 
-// handleIndexUpdate 处理索引更新请求
-func (r *ruleRepoImpl) handleIndexUpdate(req *indexUpdateRequest) {
-	err := retry.Do(
-		func() error {
-			switch req.operationType {
-			case indexOperationTypeUpdate:
-				return r.redisCache.UpdateIndexCache(req.ctx, req.rule)
-			case indexOperationTypeRemove:
-				return r.redisCache.RemoveFromIndex(req.ctx, req.rule)
-			default:
-				return fmt.Errorf("unknown index operation type: %s", req.operationType)
-			}
-		},
-		retry.Attempts(uint(r.config.IndexUpdateRetryCount)),
-		retry.Delay(r.config.IndexUpdateRetryDelay),
-	)
-	if err != nil {
-		utils.GetLogger().Errorf("failed to update index: %v\n", err)
+// ApplyRuleChanges 事务性地批量写入/删除规则，提交后同步分发 outbox 事件
+func (r *ruleRepoImpl) ApplyRuleChanges(ctx context.Context, upserts []*model.MockRule, deleteIDs []string) error {
+	if err := r.mysqlStorage.ApplyRuleChanges(ctx, upserts, deleteIDs); err != nil {
+		return fmt.Errorf("failed to apply rule changes to db: %w", err)
+	}
+
+	// 分发失败的事件保留在 outbox 中，由后台循环重试
+	if _, err := r.dispatcher.DispatchOnce(ctx); err != nil {
+		utils.GetLogger().Warnf("rules saved, cache and index update deferred: %v", err)
+	}
+	return nil
+}
+
+// afterWrite 写入提交后触发 outbox 分发；SyncCacheUpdate 开启时同步执行
+func (r *ruleRepoImpl) afterWrite(ctx context.Context) {
+	if !r.config.SyncCacheUpdate {
+		r.dispatcher.Notify()
+		return
+	}
+	if _, err := r.dispatcher.DispatchOnce(ctx); err != nil {
+		utils.GetLogger().Warnf("outbox dispatch: %v", err)
 	}
 }
 
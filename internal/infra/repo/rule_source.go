@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"fmt"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/storage"
+)
+
+// NewMemoryRuleRepo 使用内存存储与缓存组装 ruleRepoImpl，优先级排序与 L1MatchIndex 语义与 MySQL + Redis 一致
+func NewMemoryRuleRepo(config *configs.RuleRepoConfig) RuleRepositoryIface {
+	return NewRuleRepoImpl(storage.NewMemoryRuleStorage(), storage.NewMemoryRuleCache(), nil, config)
+}
+
+// NewRuleRepository 根据 ruleSource.type 选择规则仓库实现：mysql（默认，database.driver 为 sqlite 时使用 SQLite）、file、memory
+func NewRuleRepository(c *configs.RuleConfig) (RuleRepositoryIface, func(), error) {
+	switch c.RuleSource.Type {
+	case "", configs.RuleSourceMySQL:
+		if c.DatabaseConfig.IsSQLite() {
+			return newSQLiteRuleRepo(c)
+		}
+		db := storage.NewMySQLClient(c)
+		client := storage.NewRedisClient(c)
+		repo := NewRuleRepoImpl(storage.NewMysqlRuleStorage(db), storage.NewredisRuleStorageImpl(client), client, NewRuleRepoConfig(c))
+		return repo, func() {
+			repo.Close()
+			client.Close()
+		}, nil
+	case configs.RuleSourceFile:
+		return NewFileRuleRepo(NewRuleSourceConfig(c))
+	case configs.RuleSourceMemory:
+		repo := NewMemoryRuleRepo(localRuleRepoConfig(c))
+		return repo, repo.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown rule source type: %s", c.RuleSource.Type)
+	}
+}
+
+// newSQLiteRuleRepo SQLite 持久化；未配置 Redis 时使用内存缓存，便于单机运行
+func newSQLiteRuleRepo(c *configs.RuleConfig) (RuleRepositoryIface, func(), error) {
+	db, err := storage.OpenSQLite(c.DatabaseConfig.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	sqlStorage := storage.NewSQLiteRuleStorage(db)
+	closeDB := func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+
+	if c.RedisConfig.Host == "" {
+		repo := NewRuleRepoImpl(sqlStorage, storage.NewMemoryRuleCache(), nil, localRuleRepoConfig(c))
+		return repo, func() {
+			repo.Close()
+			closeDB()
+		}, nil
+	}
+
+	client := storage.NewRedisClient(c)
+	repo := NewRuleRepoImpl(sqlStorage, storage.NewredisRuleStorageImpl(client), client, NewRuleRepoConfig(c))
+	return repo, func() {
+		repo.Close()
+		client.Close()
+		closeDB()
+	}, nil
+}
+
+// localRuleRepoConfig 使用内存缓存时按字段补齐缺省值，保留过期归档、一致性检查等其他配置；
+// 未配置任务池时缓存与索引同步更新
+func localRuleRepoConfig(c *configs.RuleConfig) *configs.RuleRepoConfig {
+	config := *NewRuleRepoConfig(c)
+	defaults := configs.DefaultRuleRepoConfig()
+	if config.IndexUpdatePoolSize <= 0 {
+		config.IndexUpdatePoolSize = defaults.IndexUpdatePoolSize
+		config.SyncCacheUpdate = true
+	}
+	// retry-go 的重试次数为 0 表示无限重试
+	if config.RedisCacheRetryCount <= 0 {
+		config.RedisCacheRetryCount = defaults.RedisCacheRetryCount
+	}
+	if config.SaveRuleDBRetryCount <= 0 {
+		config.SaveRuleDBRetryCount = defaults.SaveRuleDBRetryCount
+	}
+	if config.IndexUpdateRetryCount <= 0 {
+		config.IndexUpdateRetryCount = defaults.IndexUpdateRetryCount
+	}
+	return &config
+}
@@ -0,0 +1,33 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	configs "go_mock_server/internal/infra/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalRuleRepoConfigKeepsUserSettings(t *testing.T) {
+	c := &configs.RuleConfig{}
+	c.RuleRepoConfig.ExpireSweepInterval = -1
+	c.RuleRepoConfig.ReconcileInterval = time.Minute
+	c.RuleRepoConfig.SaveRuleDBRetryCount = 3
+	c.RuleRepoConfig.RedisCacheRetryDelay = time.Second
+
+	config := localRuleRepoConfig(c)
+	assert.Equal(t, time.Duration(-1), config.ExpireSweepInterval)
+	assert.Equal(t, time.Minute, config.ReconcileInterval)
+	assert.Equal(t, 3, config.SaveRuleDBRetryCount)
+	assert.Equal(t, time.Second, config.RedisCacheRetryDelay)
+	assert.Equal(t, 1, config.RedisCacheRetryCount)
+	assert.Equal(t, 1, config.IndexUpdatePoolSize)
+	assert.True(t, config.SyncCacheUpdate)
+	assert.Zero(t, c.RuleRepoConfig.IndexUpdatePoolSize, "the loaded config is not modified")
+
+	c.RuleRepoConfig.IndexUpdatePoolSize = 4
+	config = localRuleRepoConfig(c)
+	assert.Equal(t, 4, config.IndexUpdatePoolSize)
+	assert.False(t, config.SyncCacheUpdate)
+}
@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"go_mock_server/utils"
+	"time"
+)
+
+const defaultExpireSweepInterval = time.Minute
+
+// SweepExpiredRules 将 activeUntil 已到期且未归档的规则归档，返回归档数量
+// 过期规则在 IsMatch 中已不会命中，归档用于将其移出索引并在列表中体现状态
+func (r *ruleRepoImpl) SweepExpiredRules(ctx context.Context, now int64) (int, error) {
+	rules, err := r.mysqlStorage.ListRules(ctx, &model.RuleFilter{ExpiredBefore: &now})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired rules: %w", err)
+	}
+
+	expired := make([]*model.MockRule, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.Status.CanTransitionTo(model.RuleStatusArchived) {
+			continue
+		}
+		rule.Status = model.RuleStatusArchived
+		rule.Version++
+		rule.UpdatedAt = int(now)
+		expired = append(expired, rule)
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+	if err := r.ApplyRuleChanges(ctx, expired, nil); err != nil {
+		return 0, fmt.Errorf("failed to archive expired rules: %w", err)
+	}
+	return len(expired), nil
+}
+
+// runExpireSweeper 按 ExpireSweepInterval 周期归档过期规则，直到 ctx 取消（ruleRepoImpl.Close）
+func (r *ruleRepoImpl) runExpireSweeper(ctx context.Context) {
+	log := utils.GetLogger()
+	interval := r.config.ExpireSweepInterval
+	if interval == 0 {
+		interval = defaultExpireSweepInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := r.SweepExpiredRules(ctx, now.Unix())
+			if err != nil {
+				log.Warnf("expire sweep: %v", err)
+			} else if n > 0 {
+				log.Infof("archived %d expired rules", n)
+			}
+		}
+	}
+}
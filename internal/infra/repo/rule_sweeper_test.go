@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+	configs "go_mock_server/internal/infra/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepExpiredRules(t *testing.T) {
+	ctx := context.Background()
+	r := NewMemoryRuleRepo(configs.DefaultRuleRepoConfig()).(*ruleRepoImpl)
+	t.Cleanup(r.Close)
+	now := time.Now().Unix()
+
+	expired := newReconcileTestRule("expired", 1)
+	expired.ActiveUntil = now - 10
+	expired.Version = 1
+	alive := newReconcileTestRule("alive", 2)
+	alive.ActiveUntil = now + 3600
+	forever := newReconcileTestRule("forever", 3)
+	for _, rule := range []*model.MockRule{expired, alive, forever} {
+		require.NoError(t, r.SaveRule(ctx, rule))
+	}
+
+	n, err := r.SweepExpiredRules(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	got, err := r.FindByID(ctx, "expired")
+	require.NoError(t, err)
+	assert.Equal(t, model.RuleStatusArchived, got.Status)
+	assert.Equal(t, 2, got.Version)
+
+	rules, err := r.GetIndexRule(ctx, expired.L1MatchIndex)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	assert.Equal(t, []string{"forever", "alive"}, ids)
+
+	// 已归档的规则不会重复处理
+	n, err = r.SweepExpiredRules(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestExpireSweeperRunsInSyncMode(t *testing.T) {
+	ctx := context.Background()
+	config := configs.DefaultRuleRepoConfig()
+	require.True(t, config.SyncCacheUpdate)
+	config.ExpireSweepInterval = 10 * time.Millisecond
+	r := NewMemoryRuleRepo(config)
+	t.Cleanup(r.Close)
+
+	rule := newReconcileTestRule("expired", 1)
+	rule.ActiveUntil = time.Now().Unix() - 10
+	require.NoError(t, r.SaveRule(ctx, rule))
+
+	assert.Eventually(t, func() bool {
+		got, err := r.FindByID(ctx, "expired")
+		return err == nil && got.Status == model.RuleStatusArchived
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestExpireSweeperStopsOnClose(t *testing.T) {
+	ctx := context.Background()
+	config := configs.DefaultRuleRepoConfig()
+	config.ExpireSweepInterval = 10 * time.Millisecond
+	r := NewMemoryRuleRepo(config)
+	r.Close()
+
+	rule := newReconcileTestRule("expired", 1)
+	rule.ActiveUntil = time.Now().Unix() - 10
+	require.NoError(t, r.(*ruleRepoImpl).mysqlStorage.SaveRuleToDB(ctx, rule))
+
+	time.Sleep(50 * time.Millisecond)
+	got, err := r.(*ruleRepoImpl).mysqlStorage.GetRuleFromDB(ctx, "expired")
+	require.NoError(t, err)
+	assert.Equal(t, model.RuleStatusActive, got.Status)
+}
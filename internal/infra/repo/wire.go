@@ -1,6 +1,7 @@
 package repo
 
 import (
+	configs "go_mock_server/internal/infra/config"
 	"go_mock_server/internal/infra/storage"
 
 	"github.com/google/wire"
@@ -10,4 +11,29 @@ var Reposet = wire.NewSet(
 	NewRuleRepoConfig,
 	storage.StorageSet,
 	NewRuleRepoImpl,
+	NewIndexReconciler,
+	NewChangeSetRepository,
+	NewDescriptorRepository,
+)
+
+// FileReposet 文件规则来源，不依赖 MySQL / Redis
+var FileReposet = wire.NewSet(
+	configs.LoadRuleConfig,
+	NewRuleSourceConfig,
+	NewFileRuleRepo,
+	wire.Bind(new(RuleRepositoryIface), new(*FileRuleRepo)),
+)
+
+// MemoryReposet 纯内存规则仓库，不读取配置文件，供单元测试使用
+var MemoryReposet = wire.NewSet(
+	configs.DefaultRuleRepoConfig,
+	NewMemoryRuleRepo,
+)
+
+// ConfigurableReposet 根据配置文件中的 ruleSource.type 选择实现
+var ConfigurableReposet = wire.NewSet(
+	configs.LoadRuleConfig,
+	NewRuleRepository,
+	NewChangeSetRepository,
+	NewDescriptorRepository,
 )
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (s *MysqlRuleStorage) CreateChangeSet(ctx context.Context, cs *model.ChangeSet) error {
+	if err := s.mysqlClient.WithContext(ctx).Create(cs).Error; err != nil {
+		return fmt.Errorf("failed to create change set: %w", err)
+	}
+	return nil
+}
+
+func (s *MysqlRuleStorage) GetChangeSet(ctx context.Context, id string) (*model.ChangeSet, error) {
+	db := s.mysqlClient.WithContext(ctx)
+	cs := &model.ChangeSet{}
+	if err := db.First(cs, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get change set: %w", err)
+	}
+	if err := db.Where("change_set_id = ?", id).Order("id").Find(&cs.Items).Error; err != nil {
+		return nil, fmt.Errorf("failed to get change set items: %w", err)
+	}
+	return cs, nil
+}
+
+func (s *MysqlRuleStorage) ListChangeSets(ctx context.Context, status model.ChangeSetStatus) ([]*model.ChangeSet, error) {
+	var sets []*model.ChangeSet
+	db := s.mysqlClient.WithContext(ctx)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	if err := db.Order("created_at DESC").Find(&sets).Error; err != nil {
+		return nil, fmt.Errorf("failed to list change sets: %w", err)
+	}
+	return sets, nil
+}
+
+func (s *MysqlRuleStorage) SaveChangeSetItem(ctx context.Context, item *model.ChangeSetItem) error {
+	return s.mysqlClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := touchOpenChangeSet(tx, item.ChangeSetID); err != nil {
+			return err
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "change_set_id"}, {Name: "rule_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"op", "rule", "target_status"}),
+		}).Create(item).Error
+		if err != nil {
+			return fmt.Errorf("failed to save change set item: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *MysqlRuleStorage) DeleteChangeSetItem(ctx context.Context, changeSetID, ruleID string) error {
+	return s.mysqlClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := touchOpenChangeSet(tx, changeSetID); err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.ChangeSetItem{}, "change_set_id = ? AND rule_id = ?", changeSetID, ruleID).Error; err != nil {
+			return fmt.Errorf("failed to delete change set item: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *MysqlRuleStorage) DiscardChangeSet(ctx context.Context, id string) error {
+	return s.mysqlClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return closeChangeSet(tx, id, model.ChangeSetDiscarded)
+	})
+}
+
+func (s *MysqlRuleStorage) PublishChangeSet(ctx context.Context, id string, upserts []*model.MockRule, deleteIDs []string, histories []*model.RuleHistory) error {
+	return s.mysqlClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先更新状态，锁住变更集行，防止并发发布或继续暂存
+		if err := closeChangeSet(tx, id, model.ChangeSetPublished); err != nil {
+			return err
+		}
+		if err := applyRuleChangesTx(tx, upserts, deleteIDs); err != nil {
+			return err
+		}
+		if len(histories) > 0 {
+			if err := tx.Create(&histories).Error; err != nil {
+				return fmt.Errorf("failed to write rule history: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *MysqlRuleStorage) ListRuleHistory(ctx context.Context, ruleID string) ([]*model.RuleHistory, error) {
+	var histories []*model.RuleHistory
+	err := s.mysqlClient.WithContext(ctx).Where("rule_id = ?", ruleID).Order("id DESC").Find(&histories).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule history: %w", err)
+	}
+	return histories, nil
+}
+
+// touchOpenChangeSet 更新 open 变更集的 updated_at，同时在事务内锁住该行
+func touchOpenChangeSet(tx *gorm.DB, id string) error {
+	res := tx.Model(&model.ChangeSet{}).Where("id = ? AND status = ?", id, model.ChangeSetOpen).
+		Update("updated_at", time.Now().Unix())
+	if res.Error != nil {
+		return fmt.Errorf("failed to update change set: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return changeSetStateError(tx, id)
+	}
+	return nil
+}
+
+// closeChangeSet 将 open 变更集迁移到 published / discarded
+func closeChangeSet(tx *gorm.DB, id string, status model.ChangeSetStatus) error {
+	now := time.Now().Unix()
+	updates := map[string]any{"status": status, "updated_at": now}
+	if status == model.ChangeSetPublished {
+		updates["published_at"] = now
+	}
+	res := tx.Model(&model.ChangeSet{}).Where("id = ? AND status = ?", id, model.ChangeSetOpen).Updates(updates)
+	if res.Error != nil {
+		return fmt.Errorf("failed to update change set: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return changeSetStateError(tx, id)
+	}
+	return nil
+}
+
+func changeSetStateError(tx *gorm.DB, id string) error {
+	var count int64
+	if err := tx.Model(&model.ChangeSet{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to get change set: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", model.ErrChangeSetNotFound, id)
+	}
+	return fmt.Errorf("%w: %s", model.ErrChangeSetNotOpen, id)
+}
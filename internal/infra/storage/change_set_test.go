@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeSetStorage(t *testing.T) {
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "rules.db"))
+	require.NoError(t, err)
+	backends := map[string]MySQLRuleStorageIface{
+		"sqlite": NewSQLiteRuleStorage(db),
+		"memory": NewMemoryRuleStorage(),
+	}
+
+	for name, s := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			old := newStorageTestRule("old", "/api/old", 1)
+			require.NoError(t, s.SaveRuleToDB(ctx, old))
+
+			require.NoError(t, s.CreateChangeSet(ctx, &model.ChangeSet{ID: "cs1", Name: "release", Status: model.ChangeSetOpen}))
+
+			draft := newStorageTestRule("new", "/api/new", 1)
+			draft.Status = model.RuleStatusDraft
+			require.NoError(t, s.SaveChangeSetItem(ctx, &model.ChangeSetItem{ChangeSetID: "cs1", RuleID: "new", Op: model.ChangeSetOpUpsert, Rule: draft}))
+			// 重复暂存同一规则时覆盖
+			draft.Priority = 7
+			require.NoError(t, s.SaveChangeSetItem(ctx, &model.ChangeSetItem{ChangeSetID: "cs1", RuleID: "new", Op: model.ChangeSetOpUpsert, Rule: draft}))
+			require.NoError(t, s.SaveChangeSetItem(ctx, &model.ChangeSetItem{ChangeSetID: "cs1", RuleID: "old", Op: model.ChangeSetOpDelete}))
+
+			cs, err := s.GetChangeSet(ctx, "cs1")
+			require.NoError(t, err)
+			require.Len(t, cs.Items, 2)
+			assert.Equal(t, "new", cs.Items[0].RuleID)
+			assert.Equal(t, 7, cs.Items[0].Rule.Priority)
+			assert.Equal(t, draft.MatchConfig, cs.Items[0].Rule.MatchConfig)
+
+			// 草稿不影响线上规则
+			live, err := s.GetRuleFromDB(ctx, "new")
+			require.NoError(t, err)
+			assert.Nil(t, live)
+
+			published := cs.Items[0].PublishedRule()
+			histories := []*model.RuleHistory{
+				{RuleID: "new", Version: 1, ChangeType: model.HistoryChangeCreate, Content: published, ChangeSetID: "cs1"},
+				{RuleID: "old", Version: 1, ChangeType: model.HistoryChangeDelete, Content: old, ChangeSetID: "cs1"},
+			}
+			require.NoError(t, s.PublishChangeSet(ctx, "cs1", []*model.MockRule{published}, []string{"old"}, histories))
+
+			got, err := s.GetRuleFromDB(ctx, "new")
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, model.RuleStatusActive, got.Status)
+			gone, err := s.GetRuleFromDB(ctx, "old")
+			require.NoError(t, err)
+			assert.Nil(t, gone)
+
+			history, err := s.ListRuleHistory(ctx, "new")
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+			assert.Equal(t, "cs1", history[0].ChangeSetID)
+			assert.Equal(t, 7, history[0].Content.Priority)
+
+			cs, err = s.GetChangeSet(ctx, "cs1")
+			require.NoError(t, err)
+			assert.Equal(t, model.ChangeSetPublished, cs.Status)
+			assert.NotZero(t, cs.PublishedAt)
+
+			// 已发布的变更集不能再次发布、修改或丢弃
+			err = s.PublishChangeSet(ctx, "cs1", nil, nil, nil)
+			assert.True(t, errors.Is(err, model.ErrChangeSetNotOpen))
+			assert.True(t, errors.Is(s.DeleteChangeSetItem(ctx, "cs1", "new"), model.ErrChangeSetNotOpen))
+			assert.True(t, errors.Is(s.DiscardChangeSet(ctx, "cs1"), model.ErrChangeSetNotOpen))
+			assert.True(t, errors.Is(s.DiscardChangeSet(ctx, "missing"), model.ErrChangeSetNotFound))
+
+			sets, err := s.ListChangeSets(ctx, model.ChangeSetPublished)
+			require.NoError(t, err)
+			require.Len(t, sets, 1)
+			assert.Empty(t, sets[0].Items)
+		})
+	}
+}
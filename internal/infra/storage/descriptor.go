@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"gorm.io/gorm"
+)
+
+const descriptorListColumns = "id, name, version, source, size, services, created_at"
+
+func (s *MysqlRuleStorage) SaveDescriptor(ctx context.Context, d *model.ProtoDescriptorSet) error {
+	return s.mysqlClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest int
+		err := tx.Model(&model.ProtoDescriptorSet{}).Where("name = ?", d.Name).
+			Select("COALESCE(MAX(version), 0)").Scan(&latest).Error
+		if err != nil {
+			return fmt.Errorf("failed to get latest descriptor version: %w", err)
+		}
+		d.Version = latest + 1
+		// 并发上传同名描述符时由唯一索引 uk_descriptor_version 拒绝后提交的一方
+		if err := tx.Create(d).Error; err != nil {
+			return fmt.Errorf("failed to save descriptor: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *MysqlRuleStorage) ListDescriptors(ctx context.Context, name string) ([]*model.ProtoDescriptorSet, error) {
+	var list []*model.ProtoDescriptorSet
+	db := s.mysqlClient.WithContext(ctx).Select(descriptorListColumns)
+	if name != "" {
+		db = db.Where("name = ?", name)
+	}
+	if err := db.Order("name, version").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to list descriptors: %w", err)
+	}
+	return list, nil
+}
+
+func (s *MysqlRuleStorage) GetDescriptor(ctx context.Context, name string, version int) (*model.ProtoDescriptorSet, error) {
+	db := s.mysqlClient.WithContext(ctx).Where("name = ?", name)
+	if version > 0 {
+		db = db.Where("version = ?", version)
+	}
+	d := &model.ProtoDescriptorSet{}
+	if err := db.Order("version DESC").First(d).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get descriptor: %w", err)
+	}
+	return d, nil
+}
+
+func (s *MysqlRuleStorage) LatestDescriptors(ctx context.Context) ([]*model.ProtoDescriptorSet, error) {
+	var list []*model.ProtoDescriptorSet
+	err := s.mysqlClient.WithContext(ctx).
+		Joins("JOIN (SELECT name AS latest_name, MAX(version) AS latest_version FROM mock_proto_descriptors GROUP BY name) latest " +
+			"ON latest.latest_name = mock_proto_descriptors.name AND latest.latest_version = mock_proto_descriptors.version").
+		Order("mock_proto_descriptors.id").
+		Find(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest descriptors: %w", err)
+	}
+	return list, nil
+}
+
+func (s *MysqlRuleStorage) DeleteDescriptor(ctx context.Context, name string, version int) (int64, error) {
+	db := s.mysqlClient.WithContext(ctx).Where("name = ?", name)
+	if version > 0 {
+		db = db.Where("version = ?", version)
+	}
+	result := db.Delete(&model.ProtoDescriptorSet{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete descriptor: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
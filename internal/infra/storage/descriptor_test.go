@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescriptorStorage(t *testing.T) {
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "rules.db"))
+	require.NoError(t, err)
+	backends := map[string]MySQLRuleStorageIface{
+		"sqlite": NewSQLiteRuleStorage(db),
+		"memory": NewMemoryRuleStorage(),
+	}
+
+	for name, s := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for _, d := range []*model.ProtoDescriptorSet{
+				{Name: "user", Source: model.DescriptorSourceSet, Content: []byte("u1"), Services: []string{"user.UserService"}},
+				{Name: "order", Source: model.DescriptorSourceProto, Content: []byte("o1")},
+				{Name: "user", Source: model.DescriptorSourceSet, Content: []byte("u2")},
+			} {
+				require.NoError(t, s.SaveDescriptor(ctx, d))
+			}
+
+			list, err := s.ListDescriptors(ctx, "")
+			require.NoError(t, err)
+			require.Len(t, list, 3)
+			assert.Equal(t, "order", list[0].Name)
+			assert.Equal(t, []int{1, 2}, []int{list[1].Version, list[2].Version})
+			assert.Empty(t, list[1].Content, "list does not load content")
+			assert.Equal(t, []string{"user.UserService"}, list[1].Services)
+
+			latest, err := s.GetDescriptor(ctx, "user", 0)
+			require.NoError(t, err)
+			assert.Equal(t, 2, latest.Version)
+			assert.Equal(t, []byte("u2"), latest.Content)
+
+			v1, err := s.GetDescriptor(ctx, "user", 1)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("u1"), v1.Content)
+
+			missing, err := s.GetDescriptor(ctx, "user", 9)
+			require.NoError(t, err)
+			assert.Nil(t, missing)
+
+			// 每个名称的最新版本，按上传顺序
+			active, err := s.LatestDescriptors(ctx)
+			require.NoError(t, err)
+			require.Len(t, active, 2)
+			assert.Equal(t, "order", active[0].Name)
+			assert.Equal(t, []byte("u2"), active[1].Content)
+
+			n, err := s.DeleteDescriptor(ctx, "user", 2)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), n)
+			latest, err = s.GetDescriptor(ctx, "user", 0)
+			require.NoError(t, err)
+			assert.Equal(t, 1, latest.Version)
+
+			// 删除后重新上传，版本号在剩余最大版本上递增
+			next := &model.ProtoDescriptorSet{Name: "user", Source: model.DescriptorSourceSet, Content: []byte("u3")}
+			require.NoError(t, s.SaveDescriptor(ctx, next))
+			assert.Equal(t, 2, next.Version)
+
+			n, err = s.DeleteDescriptor(ctx, "user", 0)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), n)
+			list, err = s.ListDescriptors(ctx, "user")
+			require.NoError(t, err)
+			assert.Empty(t, list)
+		})
+	}
+}
@@ -0,0 +1,708 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryRuleStorage MySQLRuleStorageIface 的内存实现，用于单元测试和单机运行
+// 读写都会复制规则，行为与从数据库读取的独立对象一致
+type MemoryRuleStorage struct {
+	mu          sync.RWMutex
+	rules       map[string]*model.MockRule
+	outbox      []*RuleOutboxEvent
+	nextID      int64
+	changeSets  map[string]*model.ChangeSet
+	histories   []*model.RuleHistory
+	descriptors []*model.ProtoDescriptorSet // 按上传顺序
+}
+
+var _ MySQLRuleStorageIface = (*MemoryRuleStorage)(nil)
+
+func NewMemoryRuleStorage() MySQLRuleStorageIface {
+	return &MemoryRuleStorage{
+		rules:      make(map[string]*model.MockRule),
+		changeSets: make(map[string]*model.ChangeSet),
+	}
+}
+
+func (s *MemoryRuleStorage) SaveRuleToDB(ctx context.Context, rule *model.MockRule) error {
+	clone, err := cloneRule(rule)
+	if err != nil {
+		return fmt.Errorf("failed to save rule: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.rules[rule.ID]; ok {
+		return fmt.Errorf("failed to save rule: duplicate rule id %s", rule.ID)
+	}
+	s.rules[rule.ID] = clone
+	s.appendOutboxLocked(rule.ID, OutboxOpUpsert, "")
+	return nil
+}
+
+func (s *MemoryRuleStorage) GetRuleFromDB(ctx context.Context, ruleID string) (*model.MockRule, error) {
+	s.mu.RLock()
+	rule, ok := s.rules[ruleID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, nil // 与 MySQL 实现一致，不存在时返回 nil, nil
+	}
+	return cloneRule(rule)
+}
+
+func (s *MemoryRuleStorage) DeleteRuleFromDB(ctx context.Context, ruleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteLocked(ruleID)
+	return nil
+}
+
+// deleteLocked 删除规则并写入 outbox 事件，调用方需持有写锁
+func (s *MemoryRuleStorage) deleteLocked(ruleID string) {
+	oldKey := ""
+	if old, ok := s.rules[ruleID]; ok {
+		oldKey = old.L1MatchIndex
+	}
+	delete(s.rules, ruleID)
+	s.appendOutboxLocked(ruleID, OutboxOpDelete, oldKey)
+}
+
+func (s *MemoryRuleStorage) BatchGetRules(ctx context.Context, ruleIDs []string) ([]*model.MockRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rules := make([]*model.MockRule, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		rule, ok := s.rules[id]
+		if !ok {
+			continue
+		}
+		clone, err := cloneRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, clone)
+	}
+	return rules, nil
+}
+
+func (s *MemoryRuleStorage) ListRules(ctx context.Context, filter *model.RuleFilter) ([]*model.MockRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.listLocked(filter)
+}
+
+func (s *MemoryRuleStorage) ListRulesWithPage(ctx context.Context, filter *model.RuleFilter, page, pageSize int) ([]*model.MockRule, int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rules, err := s.listLocked(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := int64(len(rules))
+
+	start := (page - 1) * pageSize
+	if start < 0 {
+		start = 0
+	}
+	if start >= len(rules) {
+		return []*model.MockRule{}, total, nil
+	}
+	end := start + pageSize
+	if end > len(rules) {
+		end = len(rules)
+	}
+	return rules[start:end], total, nil
+}
+
+// listLocked 按 ID 排序返回满足过滤条件的规则副本，调用方需持有读锁
+func (s *MemoryRuleStorage) listLocked(filter *model.RuleFilter) ([]*model.MockRule, error) {
+	rules := make([]*model.MockRule, 0, len(s.rules))
+	for _, rule := range s.rules {
+		if !filter.Accept(rule) {
+			continue
+		}
+		clone, err := cloneRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, clone)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules, nil
+}
+
+func (s *MemoryRuleStorage) ApplyRuleChanges(ctx context.Context, upserts []*model.MockRule, deleteIDs []string) error {
+	clones := make([]*model.MockRule, 0, len(upserts))
+	for _, rule := range upserts {
+		clone, err := cloneRule(rule)
+		if err != nil {
+			return fmt.Errorf("failed to save rule %s: %w", rule.ID, err)
+		}
+		clones = append(clones, clone)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.applyLocked(clones, deleteIDs)
+	return nil
+}
+
+func (s *MemoryRuleStorage) applyLocked(clones []*model.MockRule, deleteIDs []string) {
+	for _, id := range deleteIDs {
+		s.deleteLocked(id)
+	}
+	for _, rule := range clones {
+		oldKey := ""
+		if old, ok := s.rules[rule.ID]; ok {
+			oldKey = old.L1MatchIndex
+		}
+		s.rules[rule.ID] = rule
+		s.appendOutboxLocked(rule.ID, OutboxOpUpsert, oldKey)
+	}
+}
+
+func (s *MemoryRuleStorage) appendOutboxLocked(ruleID, op, oldIndexKey string) {
+	s.nextID++
+	s.outbox = append(s.outbox, &RuleOutboxEvent{
+		ID:          s.nextID,
+		RuleID:      ruleID,
+		Op:          op,
+		OldIndexKey: oldIndexKey,
+		CreatedAt:   time.Now().Unix(),
+	})
+}
+
+func (s *MemoryRuleStorage) FetchPendingOutbox(ctx context.Context, limit int) ([]*RuleOutboxEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := time.Now().Unix()
+	var events []*RuleOutboxEvent
+	for _, e := range s.outbox {
+		if e.DoneAt == 0 && e.NextAttemptAt <= now {
+			copied := *e
+			events = append(events, &copied)
+			if len(events) >= limit {
+				break
+			}
+		}
+	}
+	return events, nil
+}
+
+// MarkOutboxDone 内存存储不跨重启，已完成的事件没有保留价值，直接移除而不是等待 PurgeOutbox
+func (s *MemoryRuleStorage) MarkOutboxDone(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	done := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		done[id] = struct{}{}
+	}
+	kept := s.outbox[:0]
+	for _, e := range s.outbox {
+		if _, ok := done[e.ID]; !ok {
+			kept = append(kept, e)
+		}
+	}
+	clear(s.outbox[len(kept):])
+	s.outbox = kept
+	return nil
+}
+
+func (s *MemoryRuleStorage) MarkOutboxFailed(ctx context.Context, id int64, cause string, nextAttemptAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.outbox {
+		if e.ID == id {
+			e.Attempts++
+			e.LastError = truncateError(cause)
+			e.NextAttemptAt = nextAttemptAt
+		}
+	}
+	return nil
+}
+
+func (s *MemoryRuleStorage) PurgeOutbox(ctx context.Context, before int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.outbox[:0]
+	var purged int64
+	for _, e := range s.outbox {
+		if e.DoneAt > 0 && e.DoneAt < before {
+			purged++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	s.outbox = kept
+	return purged, nil
+}
+
+func (s *MemoryRuleStorage) CreateChangeSet(ctx context.Context, cs *model.ChangeSet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.changeSets[cs.ID]; ok {
+		return fmt.Errorf("failed to create change set: duplicate id %s", cs.ID)
+	}
+	now := time.Now().Unix()
+	cs.CreatedAt, cs.UpdatedAt = now, now
+	clone := *cs
+	clone.Items = nil
+	s.changeSets[cs.ID] = &clone
+	return nil
+}
+
+func (s *MemoryRuleStorage) GetChangeSet(ctx context.Context, id string) (*model.ChangeSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cs, ok := s.changeSets[id]
+	if !ok {
+		return nil, nil
+	}
+	var clone model.ChangeSet
+	if err := cloneJSON(cs, &clone); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
+func (s *MemoryRuleStorage) ListChangeSets(ctx context.Context, status model.ChangeSetStatus) ([]*model.ChangeSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sets := make([]*model.ChangeSet, 0, len(s.changeSets))
+	for _, cs := range s.changeSets {
+		if status != "" && cs.Status != status {
+			continue
+		}
+		clone := *cs
+		clone.Items = nil
+		sets = append(sets, &clone)
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].CreatedAt != sets[j].CreatedAt {
+			return sets[i].CreatedAt > sets[j].CreatedAt
+		}
+		return sets[i].ID > sets[j].ID
+	})
+	return sets, nil
+}
+
+func (s *MemoryRuleStorage) SaveChangeSetItem(ctx context.Context, item *model.ChangeSetItem) error {
+	var clone model.ChangeSetItem
+	if err := cloneJSON(item, &clone); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cs, err := s.openChangeSetLocked(item.ChangeSetID)
+	if err != nil {
+		return err
+	}
+	for i, existing := range cs.Items {
+		if existing.RuleID == item.RuleID {
+			clone.ID, clone.CreatedAt = existing.ID, existing.CreatedAt
+			cs.Items[i] = &clone
+			return nil
+		}
+	}
+	s.nextID++
+	clone.ID, clone.CreatedAt = s.nextID, time.Now().Unix()
+	cs.Items = append(cs.Items, &clone)
+	return nil
+}
+
+func (s *MemoryRuleStorage) DeleteChangeSetItem(ctx context.Context, changeSetID, ruleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cs, err := s.openChangeSetLocked(changeSetID)
+	if err != nil {
+		return err
+	}
+	for i, item := range cs.Items {
+		if item.RuleID == ruleID {
+			cs.Items = append(cs.Items[:i], cs.Items[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *MemoryRuleStorage) DiscardChangeSet(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cs, err := s.openChangeSetLocked(id)
+	if err != nil {
+		return err
+	}
+	cs.Status = model.ChangeSetDiscarded
+	return nil
+}
+
+func (s *MemoryRuleStorage) PublishChangeSet(ctx context.Context, id string, upserts []*model.MockRule, deleteIDs []string, histories []*model.RuleHistory) error {
+	clones := make([]*model.MockRule, 0, len(upserts))
+	for _, rule := range upserts {
+		clone, err := cloneRule(rule)
+		if err != nil {
+			return fmt.Errorf("failed to save rule %s: %w", rule.ID, err)
+		}
+		clones = append(clones, clone)
+	}
+
+	historyClones := make([]*model.RuleHistory, 0, len(histories))
+	for _, h := range histories {
+		clone := &model.RuleHistory{}
+		if err := cloneJSON(h, clone); err != nil {
+			return err
+		}
+		historyClones = append(historyClones, clone)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cs, err := s.openChangeSetLocked(id)
+	if err != nil {
+		return err
+	}
+	cs.Status = model.ChangeSetPublished
+	cs.PublishedAt = cs.UpdatedAt
+	s.applyLocked(clones, deleteIDs)
+	for i, h := range historyClones {
+		s.nextID++
+		h.ID = s.nextID
+		histories[i].ID = h.ID
+	}
+	s.histories = append(s.histories, historyClones...)
+	return nil
+}
+
+func (s *MemoryRuleStorage) ListRuleHistory(ctx context.Context, ruleID string) ([]*model.RuleHistory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var histories []*model.RuleHistory
+	for i := len(s.histories) - 1; i >= 0; i-- {
+		if s.histories[i].RuleID == ruleID {
+			clone := *s.histories[i]
+			histories = append(histories, &clone)
+		}
+	}
+	return histories, nil
+}
+
+func (s *MemoryRuleStorage) SaveDescriptor(ctx context.Context, d *model.ProtoDescriptorSet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	latest := 0
+	for _, existing := range s.descriptors {
+		if existing.Name == d.Name && existing.Version > latest {
+			latest = existing.Version
+		}
+	}
+	s.nextID++
+	d.ID, d.Version, d.CreatedAt = s.nextID, latest+1, time.Now().Unix()
+	clone := *d
+	clone.Content = append([]byte(nil), d.Content...)
+	s.descriptors = append(s.descriptors, &clone)
+	return nil
+}
+
+func (s *MemoryRuleStorage) ListDescriptors(ctx context.Context, name string) ([]*model.ProtoDescriptorSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var list []*model.ProtoDescriptorSet
+	for _, d := range s.descriptors {
+		if name != "" && d.Name != name {
+			continue
+		}
+		clone := *d
+		clone.Content = nil
+		list = append(list, &clone)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Version < list[j].Version
+	})
+	return list, nil
+}
+
+func (s *MemoryRuleStorage) GetDescriptor(ctx context.Context, name string, version int) (*model.ProtoDescriptorSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var found *model.ProtoDescriptorSet
+	for _, d := range s.descriptors {
+		if d.Name != name || (version > 0 && d.Version != version) {
+			continue
+		}
+		if found == nil || d.Version > found.Version {
+			found = d
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	clone := *found
+	clone.Content = append([]byte(nil), found.Content...)
+	return &clone, nil
+}
+
+func (s *MemoryRuleStorage) LatestDescriptors(ctx context.Context) ([]*model.ProtoDescriptorSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	latest := make(map[string]*model.ProtoDescriptorSet)
+	for _, d := range s.descriptors {
+		if cur, ok := latest[d.Name]; !ok || d.Version > cur.Version {
+			latest[d.Name] = d
+		}
+	}
+	var list []*model.ProtoDescriptorSet
+	for _, d := range s.descriptors {
+		if latest[d.Name] == d {
+			clone := *d
+			clone.Content = append([]byte(nil), d.Content...)
+			list = append(list, &clone)
+		}
+	}
+	return list, nil
+}
+
+func (s *MemoryRuleStorage) DeleteDescriptor(ctx context.Context, name string, version int) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.descriptors[:0]
+	var deleted int64
+	for _, d := range s.descriptors {
+		if d.Name == name && (version == 0 || d.Version == version) {
+			deleted++
+			continue
+		}
+		kept = append(kept, d)
+	}
+	s.descriptors = kept
+	return deleted, nil
+}
+
+func (s *MemoryRuleStorage) openChangeSetLocked(id string) (*model.ChangeSet, error) {
+	cs, ok := s.changeSets[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrChangeSetNotFound, id)
+	}
+	if cs.Status != model.ChangeSetOpen {
+		return nil, fmt.Errorf("%w: %s", model.ErrChangeSetNotOpen, id)
+	}
+	cs.UpdatedAt = time.Now().Unix()
+	return cs, nil
+}
+
+// MemoryRuleCache RedisRuleCacheIface 的内存实现
+// 规则以 JSON 保存，索引按优先级排序，顺序与 Redis sorted set 的 ZRevRange 一致
+type MemoryRuleCache struct {
+	mu      sync.RWMutex
+	rules   map[string][]byte
+	expires map[string]time.Time          // 规则缓存过期时间，与 Redis key TTL 一致
+	index   map[string]map[string]float64 // indexKey -> ruleID -> score
+}
+
+var _ RedisRuleCacheIface = (*MemoryRuleCache)(nil)
+
+func NewMemoryRuleCache() RedisRuleCacheIface {
+	return &MemoryRuleCache{
+		rules:   make(map[string][]byte),
+		expires: make(map[string]time.Time),
+		index:   make(map[string]map[string]float64),
+	}
+}
+
+// cachedLocked 返回未过期的规则缓存
+func (c *MemoryRuleCache) cachedLocked(ruleID string) ([]byte, bool) {
+	data, ok := c.rules[ruleID]
+	if !ok {
+		return nil, false
+	}
+	if exp, ok := c.expires[ruleID]; ok && !time.Now().Before(exp) {
+		return nil, false
+	}
+	return data, true
+}
+
+func (c *MemoryRuleCache) GetRuleFromCache(ctx context.Context, ruleID string) (*model.MockRule, error) {
+	c.mu.RLock()
+	data, ok := c.cachedLocked(ruleID)
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("key %s not exist", ruleKeyPrefix+ruleID)
+	}
+
+	rule := &model.MockRule{}
+	if err := json.Unmarshal(data, rule); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rule from JSON: %w", err)
+	}
+	return rule, nil
+}
+
+func (c *MemoryRuleCache) SetRuleToCache(ctx context.Context, rule *model.MockRule) error {
+	if rule.ID == "" {
+		rule.ID = generateUniqueID(rule)
+	}
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rule to JSON: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rules[rule.ID] = data
+	if ttl := ruleCacheTTL(rule, time.Now()); ttl > 0 {
+		c.expires[rule.ID] = time.Now().Add(ttl)
+	} else {
+		delete(c.expires, rule.ID)
+	}
+	return nil
+}
+
+func (c *MemoryRuleCache) DeleteRuleFromCache(ctx context.Context, ruleID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.rules, ruleID)
+	delete(c.expires, ruleID)
+	return nil
+}
+
+// GetIndexCache 按分数从高到低返回仍在缓存中的规则 ID，分数相同时按成员倒序（与 ZRevRange 一致）
+func (c *MemoryRuleCache) GetIndexCache(ctx context.Context, indexKey string) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	members := c.index[indexKey]
+	ids := make([]string, 0, len(members))
+	for id := range members {
+		if _, ok := c.cachedLocked(id); ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if members[ids[i]] != members[ids[j]] {
+			return members[ids[i]] > members[ids[j]]
+		}
+		return ids[i] > ids[j]
+	})
+	return ids, nil
+}
+
+func (c *MemoryRuleCache) SetIndexCache(ctx context.Context, indexKey string, ruleID string) error {
+	rule, err := c.GetRuleFromCache(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.index[indexKey] == nil {
+		c.index[indexKey] = make(map[string]float64)
+	}
+	c.index[indexKey][ruleID] = float64(rule.Priority)
+	return nil
+}
+
+func (c *MemoryRuleCache) UpdateIndexCache(ctx context.Context, rule *model.MockRule) error {
+	indexKey := rule.L1MatchIndex
+	if indexKey == "" {
+		indexKey = model.BuildL1MatchIndexKeyFromRule(rule)
+	}
+	return c.SetIndexCache(ctx, indexKey, rule.ID)
+}
+
+func (c *MemoryRuleCache) RemoveFromIndex(ctx context.Context, rule *model.MockRule) error {
+	indexKey := model.BuildL1MatchIndexKeyFromRule(rule)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if members, ok := c.index[indexKey]; ok {
+		delete(members, rule.ID)
+		if len(members) == 0 {
+			delete(c.index, indexKey)
+		}
+	}
+	return nil
+}
+
+func (c *MemoryRuleCache) ListIndexKeys(ctx context.Context) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]string, 0, len(c.index))
+	for key := range c.index {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (c *MemoryRuleCache) GetIndexMembers(ctx context.Context, indexKey string) (map[string]float64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	members := make(map[string]float64, len(c.index[indexKey]))
+	for id, score := range c.index[indexKey] {
+		members[id] = score
+	}
+	return members, nil
+}
+
+func (c *MemoryRuleCache) RemoveIndexMember(ctx context.Context, indexKey string, ruleID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if members, ok := c.index[indexKey]; ok {
+		delete(members, ruleID)
+		if len(members) == 0 {
+			delete(c.index, indexKey)
+		}
+	}
+	return nil
+}
+
+func (c *MemoryRuleCache) ListCachedRuleIDs(ctx context.Context) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ids := make([]string, 0, len(c.rules))
+	for id := range c.rules {
+		if _, ok := c.cachedLocked(id); ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// cloneJSON 通过 JSON 深拷贝对象
+func cloneJSON(src, dst any) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %T: %w", src, err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("failed to unmarshal %T: %w", dst, err)
+	}
+	return nil
+}
+
+// cloneRule 通过 JSON 复制规则，与数据库 / Redis 读出的对象一样互不共享
+func cloneRule(rule *model.MockRule) (*model.MockRule, error) {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rule: %w", err)
+	}
+	clone := &model.MockRule{}
+	if err := json.Unmarshal(data, clone); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rule: %w", err)
+	}
+	return clone, nil
+}
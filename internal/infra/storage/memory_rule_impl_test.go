@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleCacheTTL(t *testing.T) {
+	now := time.Unix(1000, 0)
+	rule := newStorageTestRule("r1", "/api/r1", 1)
+	assert.Equal(t, time.Duration(0), ruleCacheTTL(rule, now))
+
+	rule.ActiveUntil = 1060
+	assert.Equal(t, time.Minute, ruleCacheTTL(rule, now))
+
+	// 已到期的规则保留 1 秒，避免写入永不过期的 key
+	rule.ActiveUntil = 900
+	assert.Equal(t, time.Second, ruleCacheTTL(rule, now))
+}
+
+func TestMemoryRuleCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryRuleCache()
+
+	rule := newStorageTestRule("r1", "/api/r1", 1)
+	rule.ActiveUntil = time.Now().Add(-time.Minute).Unix()
+	require.NoError(t, cache.SetRuleToCache(ctx, rule))
+	require.NoError(t, cache.UpdateIndexCache(ctx, rule))
+	_, err := cache.GetRuleFromCache(ctx, "r1")
+	require.NoError(t, err)
+
+	time.Sleep(time.Second)
+	_, err = cache.GetRuleFromCache(ctx, "r1")
+	assert.Error(t, err)
+	ids, err := cache.GetIndexCache(ctx, rule.L1MatchIndex)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	// 重新写入不带过期时间的规则后恢复
+	rule.ActiveUntil = 0
+	require.NoError(t, cache.SetRuleToCache(ctx, rule))
+	_, err = cache.GetRuleFromCache(ctx, "r1")
+	assert.NoError(t, err)
+}
+
+func TestMemoryOutboxDropsDoneEvents(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRuleStorage().(*MemoryRuleStorage)
+	for _, id := range []string{"r1", "r2", "r3"} {
+		require.NoError(t, store.SaveRuleToDB(ctx, newStorageTestRule(id, "/api/"+id, 1)))
+	}
+
+	events, err := store.FetchPendingOutbox(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.NoError(t, store.MarkOutboxFailed(ctx, events[2].ID, "redis down", 0))
+	require.NoError(t, store.MarkOutboxDone(ctx, []int64{events[0].ID, events[1].ID}))
+
+	// 已完成的事件立即移除，失败的事件保留等待重试
+	require.Len(t, store.outbox, 1)
+	assert.Equal(t, events[2].ID, store.outbox[0].ID)
+	assert.Equal(t, 1, store.outbox[0].Attempts)
+}
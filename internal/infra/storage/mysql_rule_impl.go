@@ -5,6 +5,8 @@ import (
 	"fmt"
 	model "go_mock_server/internal/domain/model/mock_rule"
 	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/migration"
+	"go_mock_server/utils"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -24,6 +26,13 @@ func NewMySQLClient(c *configs.RuleConfig) *gorm.DB {
 	if err != nil {
 		panic("failed to connect database")
 	}
+	if c.DatabaseConfig.AutoMigrate {
+		versions, err := migration.Run(context.Background(), db)
+		if err != nil {
+			panic(fmt.Errorf("failed to migrate database: %w", err))
+		}
+		utils.GetLogger().Infof("applied migrations: %v", versions)
+	}
 	return db
 }
 
@@ -41,7 +50,7 @@ func (s *MysqlRuleStorage) SaveRuleToDB(ctx context.Context, rule *model.MockRul
 			}
 			return fmt.Errorf("failed to save rule: %w", err)
 		}
-		return nil
+		return writeOutbox(tx, []*RuleOutboxEvent{{RuleID: rule.ID, Op: OutboxOpUpsert}})
 	})
 }
 
@@ -57,10 +66,16 @@ func (s *MysqlRuleStorage) GetRuleFromDB(ctx context.Context, ruleID string) (*m
 }
 
 func (s *MysqlRuleStorage) DeleteRuleFromDB(ctx context.Context, ruleID string) error {
-	if err := s.mysqlClient.WithContext(ctx).Delete(&model.MockRule{}, "id = ?", ruleID).Error; err != nil {
-		return fmt.Errorf("failed to delete rule from mysql: %w", err)
-	}
-	return nil
+	return s.mysqlClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		oldKeys, err := previousIndexKeys(tx, []string{ruleID})
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.MockRule{}, "id = ?", ruleID).Error; err != nil {
+			return fmt.Errorf("failed to delete rule from mysql: %w", err)
+		}
+		return writeOutbox(tx, []*RuleOutboxEvent{{RuleID: ruleID, Op: OutboxOpDelete, OldIndexKey: oldKeys[ruleID]}})
+	})
 }
 
 // ListRules  通用的规则列表查询方法，支持 RuleFilter (不变)
@@ -80,14 +95,24 @@ func (s *MysqlRuleStorage) ListRules(ctx context.Context, filter *model.RuleFilt
 			db = db.Where("created_by_user_id = ?", *filter.CreatedByUserID)
 		}
 		if filter.IsEnabled != nil {
-			db = db.Where("is_enabled = ?", *filter.IsEnabled)
+			if *filter.IsEnabled {
+				db = db.Where("status = ?", model.RuleStatusActive)
+			} else {
+				db = db.Where("status <> ?", model.RuleStatusActive)
+			}
+		}
+		if filter.Status != nil {
+			db = db.Where("status = ?", *filter.Status)
 		}
 		if filter.PathContains != nil {
-			db = db.Where("matcher_config LIKE ?", fmt.Sprintf("%%%s%%", *filter.PathContains)) // 模糊匹配 Path (JSON 字符串中)
+			db = db.Where("match_config LIKE ?", fmt.Sprintf("%%%s%%", *filter.PathContains)) // 模糊匹配 Path (JSON 字符串中)
 		}
 		if filter.L1MatchIndex != nil {
 			db = db.Where("l1_match_index = ?", *filter.L1MatchIndex)
 		}
+		if filter.ExpiredBefore != nil {
+			db = db.Where("active_until > 0 AND active_until <= ?", *filter.ExpiredBefore)
+		}
 		// ... 可以根据 RuleFilter 中的字段继续添加 WHERE 条件 ...
 	}
 
@@ -114,14 +139,24 @@ func (s *MysqlRuleStorage) ListRulesWithPage(ctx context.Context, filter *model.
 			db = db.Where("created_by_user_id = ?", *filter.CreatedByUserID)
 		}
 		if filter.IsEnabled != nil {
-			db = db.Where("is_enabled = ?", *filter.IsEnabled)
+			if *filter.IsEnabled {
+				db = db.Where("status = ?", model.RuleStatusActive)
+			} else {
+				db = db.Where("status <> ?", model.RuleStatusActive)
+			}
+		}
+		if filter.Status != nil {
+			db = db.Where("status = ?", *filter.Status)
 		}
 		if filter.PathContains != nil {
-			db = db.Where("matcher_config LIKE ?", fmt.Sprintf("%%%s%%", *filter.PathContains))
+			db = db.Where("match_config LIKE ?", fmt.Sprintf("%%%s%%", *filter.PathContains))
 		}
 		if filter.L1MatchIndex != nil {
 			db = db.Where("l1_match_index = ?", *filter.L1MatchIndex)
 		}
+		if filter.ExpiredBefore != nil {
+			db = db.Where("active_until > 0 AND active_until <= ?", *filter.ExpiredBefore)
+		}
 	}
 
 	// Get total count
@@ -144,3 +179,38 @@ func (s *MysqlRuleStorage) BatchGetRules(ctx context.Context, ruleIDs []string)
 	}
 	return rules, nil
 }
+
+// ApplyRuleChanges 先删除再写入，保证 name+protocol 被替换的规则不会触发唯一键冲突
+func (s *MysqlRuleStorage) ApplyRuleChanges(ctx context.Context, upserts []*model.MockRule, deleteIDs []string) error {
+	return s.mysqlClient.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return applyRuleChangesTx(tx, upserts, deleteIDs)
+	})
+}
+
+// applyRuleChangesTx 在事务内删除、写入规则并记录 outbox 事件
+func applyRuleChangesTx(tx *gorm.DB, upserts []*model.MockRule, deleteIDs []string) error {
+	oldKeys, err := previousIndexKeys(tx, append(model.RuleIDs(upserts), deleteIDs...))
+	if err != nil {
+		return err
+	}
+	events := make([]*RuleOutboxEvent, 0, len(upserts)+len(deleteIDs))
+
+	if len(deleteIDs) > 0 {
+		if err := tx.Delete(&model.MockRule{}, "id IN ?", deleteIDs).Error; err != nil {
+			return fmt.Errorf("failed to delete rules: %w", err)
+		}
+		for _, id := range deleteIDs {
+			events = append(events, &RuleOutboxEvent{RuleID: id, Op: OutboxOpDelete, OldIndexKey: oldKeys[id]})
+		}
+	}
+	for _, rule := range upserts {
+		if err := tx.Save(rule).Error; err != nil {
+			if err == gorm.ErrDuplicatedKey {
+				return fmt.Errorf("rule with name '%s' and protocol '%s' already exists", rule.Name, rule.Protocol)
+			}
+			return fmt.Errorf("failed to save rule %s: %w", rule.ID, err)
+		}
+		events = append(events, &RuleOutboxEvent{RuleID: rule.ID, Op: OutboxOpUpsert, OldIndexKey: oldKeys[rule.ID]})
+	}
+	return writeOutbox(tx, events)
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	model "go_mock_server/internal/domain/model/mock_rule"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// outbox 事件类型
+const (
+	OutboxOpUpsert = "upsert" // 规则新增或更新，分发时以数据库中的最新内容为准
+	OutboxOpDelete = "delete" // 规则删除
+)
+
+// RuleOutboxEvent 与规则变更在同一事务中写入，由分发器异步应用到 Redis
+type RuleOutboxEvent struct {
+	ID            int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	RuleID        string `gorm:"type:varchar(36);not null" json:"ruleId"`
+	Op            string `gorm:"type:varchar(20);not null" json:"op"`
+	OldIndexKey   string `gorm:"type:varchar(255)" json:"oldIndexKey,omitempty"` // 变更前的 L1 索引，用于移除旧索引
+	Attempts      int    `gorm:"not null;default:0" json:"attempts"`
+	LastError     string `gorm:"type:varchar(512)" json:"lastError,omitempty"`
+	NextAttemptAt int64  `gorm:"not null;default:0;index:idx_outbox_pending,priority:2" json:"nextAttemptAt"`
+	DoneAt        int64  `gorm:"not null;default:0;index:idx_outbox_pending,priority:1" json:"doneAt"`
+	CreatedAt     int64  `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+func (RuleOutboxEvent) TableName() string {
+	return "mock_rule_outbox"
+}
+
+// previousIndexKeys 查询事务内规则变更前的 L1 索引
+func previousIndexKeys(tx *gorm.DB, ruleIDs []string) (map[string]string, error) {
+	keys := make(map[string]string, len(ruleIDs))
+	if len(ruleIDs) == 0 {
+		return keys, nil
+	}
+	var rows []struct {
+		ID           string
+		L1MatchIndex string
+	}
+	if err := tx.Model(&model.MockRule{}).Select("id", "l1_match_index").Where("id IN ?", ruleIDs).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read previous index keys: %w", err)
+	}
+	for _, row := range rows {
+		keys[row.ID] = row.L1MatchIndex
+	}
+	return keys, nil
+}
+
+func writeOutbox(tx *gorm.DB, events []*RuleOutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := tx.Create(&events).Error; err != nil {
+		return fmt.Errorf("failed to write outbox: %w", err)
+	}
+	return nil
+}
+
+// FetchPendingOutbox 按写入顺序取出到期的待分发事件
+func (s *MysqlRuleStorage) FetchPendingOutbox(ctx context.Context, limit int) ([]*RuleOutboxEvent, error) {
+	var events []*RuleOutboxEvent
+	err := s.mysqlClient.WithContext(ctx).
+		Where("done_at = 0 AND next_attempt_at <= ?", time.Now().Unix()).
+		Order("id").Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch outbox: %w", err)
+	}
+	return events, nil
+}
+
+func (s *MysqlRuleStorage) MarkOutboxDone(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := s.mysqlClient.WithContext(ctx).Model(&RuleOutboxEvent{}).
+		Where("id IN ?", ids).Update("done_at", time.Now().Unix()).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox done: %w", err)
+	}
+	return nil
+}
+
+func (s *MysqlRuleStorage) MarkOutboxFailed(ctx context.Context, id int64, cause string, nextAttemptAt int64) error {
+	err := s.mysqlClient.WithContext(ctx).Model(&RuleOutboxEvent{}).Where("id = ?", id).Updates(map[string]any{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      truncateError(cause),
+		"next_attempt_at": nextAttemptAt,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox failed: %w", err)
+	}
+	return nil
+}
+
+// PurgeOutbox 删除 before 之前已完成的事件
+func (s *MysqlRuleStorage) PurgeOutbox(ctx context.Context, before int64) (int64, error) {
+	res := s.mysqlClient.WithContext(ctx).Where("done_at > 0 AND done_at < ?", before).Delete(&RuleOutboxEvent{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to purge outbox: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
+
+func truncateError(s string) string {
+	if len(s) > 512 {
+		return s[:512]
+	}
+	return s
+}
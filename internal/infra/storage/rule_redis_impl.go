@@ -15,6 +15,12 @@ import (
 
 const ruleKeyPrefix = "mock_rule:" // Redis Key 前缀
 
+// indexRegistryKey 记录全部索引 key 的集合，便于一致性检查时枚举索引
+const indexRegistryKey = "mock_rule_index_keys"
+
+// indexKeyScanPattern 索引 key 形如 proto_method_/path，用于找回未登记的历史索引
+const indexKeyScanPattern = "*_*_/*"
+
 type redisRuleStorageImpl struct {
 	redisClient *redis.Client
 }
@@ -76,11 +82,13 @@ func (r *redisRuleStorageImpl) SetIndexCache(ctx context.Context, indexKey strin
 		return err
 	}
 
-	err = r.redisClient.ZAdd(ctx, indexKey, &redis.Z{
+	pipe := r.redisClient.TxPipeline()
+	pipe.ZAdd(ctx, indexKey, &redis.Z{
 		Score:  float64(rule.Priority),
 		Member: ruleID,
-	}).Err()
-	if err != nil {
+	})
+	pipe.SAdd(ctx, indexRegistryKey, indexKey)
+	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to add rule to index: %w", err)
 	}
 	return nil
@@ -130,13 +138,25 @@ func (r *redisRuleStorageImpl) SetRuleToCache(ctx context.Context, rule *model.M
 	}
 
 	key := ruleKeyPrefix + rule.ID
-	err = r.redisClient.Set(ctx, key, ruleJSON, 0).Err() //  存储到 Redis，Key 为 ruleKeyPrefix + RuleID
+	err = r.redisClient.Set(ctx, key, ruleJSON, ruleCacheTTL(rule, time.Now())).Err() //  存储到 Redis，Key 为 ruleKeyPrefix + RuleID
 	if err != nil {
 		return fmt.Errorf("failed to set rule to redis: %w", err)
 	}
 	return nil
 }
 
+// ruleCacheTTL 设置了 activeUntil 的规则在到期时自动过期，已到期的规则保留 1 秒，0 表示不过期
+func ruleCacheTTL(rule *model.MockRule, now time.Time) time.Duration {
+	if rule.ActiveUntil == 0 {
+		return 0
+	}
+	ttl := time.Unix(rule.ActiveUntil, 0).Sub(now)
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	return ttl
+}
+
 // DeleteRuleFromCache deletes a rule from Redis cache by its ID
 func (r *redisRuleStorageImpl) DeleteRuleFromCache(ctx context.Context, ruleID string) error {
 	key := ruleKeyPrefix + ruleID
@@ -166,6 +186,89 @@ func (r *redisRuleStorageImpl) GetRuleFromCache(ctx context.Context, ruleID stri
 	return rule, nil
 }
 
+// ListIndexKeys 合并登记集合与 SCAN 结果，兼容登记集合出现之前写入的索引
+func (r *redisRuleStorageImpl) ListIndexKeys(ctx context.Context) ([]string, error) {
+	registered, err := r.redisClient.SMembers(ctx, indexRegistryKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list index registry: %w", err)
+	}
+	seen := make(map[string]struct{}, len(registered))
+	keys := make([]string, 0, len(registered))
+	for _, key := range registered {
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	scanned, err := r.scanKeys(ctx, indexKeyScanPattern)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range scanned {
+		if _, ok := seen[key]; ok || strings.HasPrefix(key, ruleKeyPrefix) {
+			continue
+		}
+		if t, err := r.redisClient.Type(ctx, key).Result(); err != nil || t != "zset" {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (r *redisRuleStorageImpl) GetIndexMembers(ctx context.Context, indexKey string) (map[string]float64, error) {
+	members, err := r.redisClient.ZRangeWithScores(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index members: %w", err)
+	}
+	result := make(map[string]float64, len(members))
+	for _, z := range members {
+		if id, ok := z.Member.(string); ok {
+			result[id] = z.Score
+		}
+	}
+	return result, nil
+}
+
+// RemoveIndexMember 移除成员，索引为空时同时移出登记集合（Redis 会自动删除空的 sorted set）
+func (r *redisRuleStorageImpl) RemoveIndexMember(ctx context.Context, indexKey string, ruleID string) error {
+	if err := r.redisClient.ZRem(ctx, indexKey, ruleID).Err(); err != nil {
+		return fmt.Errorf("failed to remove rule from index: %w", err)
+	}
+	n, err := r.redisClient.ZCard(ctx, indexKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to count index members: %w", err)
+	}
+	if n == 0 {
+		return r.redisClient.SRem(ctx, indexRegistryKey, indexKey).Err()
+	}
+	return nil
+}
+
+func (r *redisRuleStorageImpl) ListCachedRuleIDs(ctx context.Context) ([]string, error) {
+	keys, err := r.scanKeys(ctx, ruleKeyPrefix+"*")
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, strings.TrimPrefix(key, ruleKeyPrefix))
+	}
+	return ids, nil
+}
+
+func (r *redisRuleStorageImpl) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	iter := r.redisClient.Scan(ctx, 0, pattern, 1000).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan keys %s: %w", pattern, err)
+	}
+	return keys, nil
+}
+
 func generateUniqueID(rule *model.MockRule) string {
 	// Replace spaces with underscores and convert to lowercase for consistency
 	sanitizedName := strings.ReplaceAll(strings.ToLower(rule.Name), " ", "_")
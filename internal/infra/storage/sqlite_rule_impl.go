@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	configs "go_mock_server/internal/infra/config"
+	"go_mock_server/internal/infra/migration"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SQLiteRuleStorage 基于 SQLite 的规则存储，查询与 MysqlRuleStorage 共用（均为可移植的 GORM 查询）
+type SQLiteRuleStorage struct {
+	*MysqlRuleStorage
+}
+
+var _ MySQLRuleStorageIface = (*SQLiteRuleStorage)(nil)
+
+// OpenSQLite 打开（必要时创建）SQLite 数据库并执行结构迁移
+func OpenSQLite(path string) (*gorm.DB, error) {
+	// WAL + busy_timeout：允许读写并发，写锁冲突时等待而不是直接失败
+	dsn := fmt.Sprintf("file:%s?_journal_mode=WAL&_busy_timeout=5000", path)
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Warn),
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite %s: %w", path, err)
+	}
+	if _, err := migration.Run(context.Background(), db); err != nil {
+		return nil, fmt.Errorf("failed to migrate sqlite schema: %w", err)
+	}
+	return db, nil
+}
+
+// NewSQLiteClient 与 NewMySQLClient 对应，失败时直接 panic
+func NewSQLiteClient(c *configs.RuleConfig) *gorm.DB {
+	db, err := OpenSQLite(c.DatabaseConfig.Path)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+func NewSQLiteRuleStorage(db *gorm.DB) MySQLRuleStorageIface {
+	return &SQLiteRuleStorage{MysqlRuleStorage: &MysqlRuleStorage{mysqlClient: db}}
+}
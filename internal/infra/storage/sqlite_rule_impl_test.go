@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStorageTestRule(id, path string, priority int) *model.MockRule {
+	rule := &model.MockRule{
+		ID:       id,
+		Name:     id,
+		Protocol: "http",
+		Priority: priority,
+		Status:   model.RuleStatusActive,
+		MatchConfig: model.MatchConfig{
+			Logical: "AND",
+			Conditions: []model.MatchCondition{
+				{Type: "method", Operator: "eq", Value: "GET"},
+				{Type: "path", Operator: "eq", Value: path},
+			},
+		},
+		ActionConfig: model.ActionConfigWrapper{
+			AType:  model.ActionTypeResponse,
+			Config: &model.ResponseAction{StatusCode: 200, Body: id},
+		},
+		Tags: model.RuleTags{"sqlite"},
+	}
+	_ = rule.Validate()
+	return rule
+}
+
+func TestSQLiteRuleStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.db")
+	db, err := OpenSQLite(path)
+	require.NoError(t, err)
+	s := NewSQLiteRuleStorage(db)
+	ctx := context.Background()
+
+	users := newStorageTestRule("users", "/api/v1/users", 1)
+	orders := newStorageTestRule("orders", "/api/v1/orders", 2)
+	require.NoError(t, s.SaveRuleToDB(ctx, users))
+	require.NoError(t, s.SaveRuleToDB(ctx, orders))
+	assert.Error(t, s.SaveRuleToDB(ctx, newStorageTestRule("users", "/api/v1/users", 1)))
+
+	got, err := s.GetRuleFromDB(ctx, "users")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, users.MatchConfig, got.MatchConfig)
+	assert.Equal(t, model.RuleTags{"sqlite"}, got.Tags)
+	action, ok := got.ActionConfig.Config.(*model.ResponseAction)
+	require.True(t, ok)
+	assert.Equal(t, "users", action.Body)
+
+	missing, err := s.GetRuleFromDB(ctx, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	l1 := users.L1MatchIndex
+	rules, err := s.ListRules(ctx, &model.RuleFilter{L1MatchIndex: &l1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users"}, model.RuleIDs(rules))
+
+	paged, total, err := s.ListRulesWithPage(ctx, nil, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, paged, 1)
+
+	orders.Priority = 10
+	require.NoError(t, s.ApplyRuleChanges(ctx, []*model.MockRule{orders}, []string{"users"}))
+	batch, err := s.BatchGetRules(ctx, []string{"users", "orders"})
+	require.NoError(t, err)
+	if assert.Len(t, batch, 1) {
+		assert.Equal(t, 10, batch[0].Priority)
+	}
+
+	require.NoError(t, s.DeleteRuleFromDB(ctx, "orders"))
+	rules, err = s.ListRules(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	// 重新打开时自动迁移幂等
+	_, err = OpenSQLite(path)
+	assert.NoError(t, err)
+}
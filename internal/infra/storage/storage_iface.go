@@ -15,6 +15,56 @@ type MySQLRuleStorageIface interface {
 	ListRules(ctx context.Context, filter *model.RuleFilter) ([]*model.MockRule, error)
 	ListRulesWithPage(ctx context.Context, filter *model.RuleFilter, page, pageSize int) ([]*model.MockRule, int64, error)
 	// ... 可以根据需求继续添加 ListRulesByXxx 方法 ...
+
+	// ApplyRuleChanges 在同一事务中删除并写入（新增或覆盖）规则
+	ApplyRuleChanges(ctx context.Context, upserts []*model.MockRule, deleteIDs []string) error
+
+	// 写操作会在同一事务中写入 outbox 事件，以下方法供分发器使用
+
+	// FetchPendingOutbox 按写入顺序取出到期的待分发事件
+	FetchPendingOutbox(ctx context.Context, limit int) ([]*RuleOutboxEvent, error)
+	// MarkOutboxDone 标记事件已分发
+	MarkOutboxDone(ctx context.Context, ids []int64) error
+	// MarkOutboxFailed 记录失败原因并设置下次重试时间
+	MarkOutboxFailed(ctx context.Context, id int64, cause string, nextAttemptAt int64) error
+	// PurgeOutbox 清理 before 之前已完成的事件
+	PurgeOutbox(ctx context.Context, before int64) (int64, error)
+
+	ChangeSetStorageIface
+	DescriptorStorageIface
+}
+
+// ChangeSetStorageIface 变更集与规则历史的持久化
+type ChangeSetStorageIface interface {
+	CreateChangeSet(ctx context.Context, cs *model.ChangeSet) error
+	// GetChangeSet 返回变更集及其条目，不存在时返回 nil
+	GetChangeSet(ctx context.Context, id string) (*model.ChangeSet, error)
+	// ListChangeSets 按状态列出变更集（不含条目），status 为空时返回全部
+	ListChangeSets(ctx context.Context, status model.ChangeSetStatus) ([]*model.ChangeSet, error)
+	// SaveChangeSetItem 暂存变更，同一规则重复暂存时覆盖；变更集必须为 open
+	SaveChangeSetItem(ctx context.Context, item *model.ChangeSetItem) error
+	// DeleteChangeSetItem 撤销暂存的变更；变更集必须为 open
+	DeleteChangeSetItem(ctx context.Context, changeSetID, ruleID string) error
+	// DiscardChangeSet 丢弃 open 状态的变更集
+	DiscardChangeSet(ctx context.Context, id string) error
+	// PublishChangeSet 在同一事务中写入规则、outbox 事件与历史记录，并将变更集标记为已发布
+	PublishChangeSet(ctx context.Context, id string, upserts []*model.MockRule, deleteIDs []string, histories []*model.RuleHistory) error
+	// ListRuleHistory 按时间倒序列出规则的变更历史
+	ListRuleHistory(ctx context.Context, ruleID string) ([]*model.RuleHistory, error)
+}
+
+// DescriptorStorageIface gRPC mock 使用的 protobuf 描述符的持久化，同名上传时版本号递增
+type DescriptorStorageIface interface {
+	// SaveDescriptor 写入新版本，版本号为同名最大版本 +1 并回写到 d.Version
+	SaveDescriptor(ctx context.Context, d *model.ProtoDescriptorSet) error
+	// ListDescriptors 列出描述符（不含内容），name 为空时返回全部，按名称、版本排序
+	ListDescriptors(ctx context.Context, name string) ([]*model.ProtoDescriptorSet, error)
+	// GetDescriptor 返回指定版本（含内容），version 为 0 时返回最新版本，不存在时返回 nil
+	GetDescriptor(ctx context.Context, name string, version int) (*model.ProtoDescriptorSet, error)
+	// LatestDescriptors 返回每个名称的最新版本（含内容），按上传顺序排序
+	LatestDescriptors(ctx context.Context) ([]*model.ProtoDescriptorSet, error)
+	// DeleteDescriptor 删除指定版本，version 为 0 时删除该名称的全部版本，返回删除的数量
+	DeleteDescriptor(ctx context.Context, name string, version int) (int64, error)
 }
 
 // RedisRuleCacheInterface 定义 Redis 缓存操作接口
@@ -28,4 +78,15 @@ type RedisRuleCacheIface interface {
 	GetIndexCache(ctx context.Context, indexKey string) ([]string, error)
 	SetIndexCache(ctx context.Context, indexKey string, ruleID string) error
 	UpdateIndexCache(ctx context.Context, rule *model.MockRule) error
+
+	// 以下方法供索引一致性检查使用
+
+	// ListIndexKeys 列出所有索引 key
+	ListIndexKeys(ctx context.Context) ([]string, error)
+	// GetIndexMembers 返回索引中的全部规则 ID 及分数（不过滤缓存缺失的 ID）
+	GetIndexMembers(ctx context.Context, indexKey string) (map[string]float64, error)
+	// RemoveIndexMember 从索引中移除指定规则 ID
+	RemoveIndexMember(ctx context.Context, indexKey string, ruleID string) error
+	// ListCachedRuleIDs 列出所有已缓存的规则 ID
+	ListCachedRuleIDs(ctx context.Context) ([]string, error)
 }
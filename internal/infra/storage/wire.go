@@ -14,3 +14,11 @@ var StorageSet = wire.NewSet(
 	NewRedisClient,
 	NewredisRuleStorageImpl,
 )
+
+// SQLiteStorageSet SQLite 持久化 + 内存缓存，不依赖 MySQL / Redis
+var SQLiteStorageSet = wire.NewSet(
+	configs.LoadRuleConfig,
+	NewSQLiteClient,
+	NewSQLiteRuleStorage,
+	NewMemoryRuleCache,
+)
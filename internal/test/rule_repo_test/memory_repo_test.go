@@ -0,0 +1,88 @@
+package rulerepotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	model "go_mock_server/internal/domain/model/mock_rule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMemoryTestRule(id string, priority int, header string) *model.MockRule {
+	conditions := []model.MatchCondition{
+		{Type: "method", Operator: "eq", Value: "GET"},
+		{Type: "path", Operator: "eq", Value: "/api/v1/orders/1"},
+	}
+	if header != "" {
+		conditions = append(conditions, model.MatchCondition{Type: "header", Operator: "eq", Key: "x-env", Value: header})
+	}
+	return &model.MockRule{
+		ID:       id,
+		Name:     id,
+		Protocol: "http",
+		Priority: priority,
+		Status:   model.RuleStatusActive,
+		MatchConfig: model.MatchConfig{
+			Logical:    "AND",
+			Conditions: conditions,
+		},
+		ActionConfig: model.ActionConfigWrapper{
+			AType:  model.ActionTypeResponse,
+			Config: &model.ResponseAction{StatusCode: 200, Body: id},
+		},
+	}
+}
+
+func TestMemoryRepoPriority(t *testing.T) {
+	ts, err := InitializeMemoryRepoTest()
+	require.NoError(t, err)
+	repo := ts.Repo
+	ctx := context.Background()
+
+	rules := []*model.MockRule{
+		newMemoryTestRule("fallback", 1, ""),
+		newMemoryTestRule("staging", 10, "staging"),
+		newMemoryTestRule("prod", 5, "prod"),
+	}
+	for _, rule := range rules {
+		require.NoError(t, rule.Validate())
+		require.NoError(t, repo.SaveRule(ctx, rule))
+	}
+
+	indexed, err := repo.GetIndexRule(ctx, rules[0].L1MatchIndex)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"staging", "prod", "fallback"}, model.RuleIDs(indexed))
+
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "staging", expected: "staging"},
+		{header: "prod", expected: "prod"},
+		{header: "dev", expected: "fallback"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			req := model.NewSyntheticRequest("http", "GET", "/api/v1/orders/1", map[string]string{"x-env": tt.header}, nil, nil)
+			rule, err := repo.FindBestMatchRule(ctx, req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule.ID)
+		})
+	}
+
+	require.NoError(t, repo.DeleteRule(ctx, "staging"))
+	indexed, err = repo.GetIndexRule(ctx, rules[0].L1MatchIndex)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod", "fallback"}, model.RuleIDs(indexed))
+
+	list, total, err := repo.ListRulesWithPage(ctx, nil, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, list, 2)
+
+	_, err = repo.FindByID(ctx, fmt.Sprintf("missing-%d", total))
+	assert.Error(t, err)
+}
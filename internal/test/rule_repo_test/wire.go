@@ -21,3 +21,9 @@ func InitializeRepoTest() (*RepoRuleTestSuite, error) {
 	wire.Build(repo.Reposet, NewRepoRuleTestSuite)
 	return &RepoRuleTestSuite{}, nil
 }
+
+// InitializeMemoryRepoTest 使用内存仓库，不依赖 MySQL / Redis
+func InitializeMemoryRepoTest() (*RepoRuleTestSuite, error) {
+	wire.Build(repo.MemoryReposet, NewRepoRuleTestSuite)
+	return &RepoRuleTestSuite{}, nil
+}
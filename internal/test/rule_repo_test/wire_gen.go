@@ -29,6 +29,13 @@ func InitializeRepoTest() (*RepoRuleTestSuite, error) {
 	return repoRuleTestSuite, nil
 }
 
+func InitializeMemoryRepoTest() (*RepoRuleTestSuite, error) {
+	ruleRepoConfig := configs.DefaultRuleRepoConfig()
+	ruleRepositoryIface := repo.NewMemoryRuleRepo(ruleRepoConfig)
+	repoRuleTestSuite := NewRepoRuleTestSuite(ruleRepositoryIface)
+	return repoRuleTestSuite, nil
+}
+
 // wire.go:
 
 type RepoRuleTestSuite struct {
@@ -1,3 +1,6 @@
+-- 参考用的完整表结构，与 internal/infra/migration 中的迁移结果一致
+-- 建库 / 升级请使用 `mockctl migrate` 或配置 database.autoMigrate，不要手工执行本文件
+
 -- 规则主表
 CREATE TABLE `mock_rules` (
     `id` VARCHAR(36) NOT NULL COMMENT '规则ID',
@@ -8,13 +11,25 @@ CREATE TABLE `mock_rules` (
     `priority` INT DEFAULT 0 COMMENT '匹配优先级',
     `status` VARCHAR(20) NOT NULL COMMENT '规则状态',
     `version` INT DEFAULT 1 COMMENT '版本号',
+    `tags` JSON NULL COMMENT '规则标签',
     `created_at` INT NOT NULL COMMENT '创建时间',
     `updated_at` INT NOT NULL COMMENT '更新时间',
+    `method` VARCHAR(20) NULL COMMENT '请求方法',
+    `original_path` VARCHAR(255) NULL COMMENT '原始路径',
+    `path_pattern` VARCHAR(255) NULL COMMENT '路径匹配模式',
+    `l1_match_index` VARCHAR(255) NULL COMMENT 'L1 匹配索引',
+    `l2_match_index` VARCHAR(255) NULL COMMENT 'L2 匹配索引',
+    `active_from` BIGINT NOT NULL DEFAULT 0 COMMENT '生效开始时间，0 表示不限',
+    `active_until` BIGINT NOT NULL DEFAULT 0 COMMENT '生效结束时间，0 表示不限',
+    `ttl` BIGINT NOT NULL DEFAULT 0 COMMENT '存活时长（秒）',
     PRIMARY KEY (`id`),
     INDEX `idx_protocol` (`protocol`),
     INDEX `idx_status` (`status`),
     INDEX `idx_priority` (`priority`),
-    INDEX `idx_created_at` (`created_at`)
+    INDEX `idx_created_at` (`created_at`),
+    INDEX `idx_l1` (`l1_match_index`),
+    INDEX `idx_l2` (`l2_match_index`),
+    INDEX `idx_active_until` (`active_until`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Mock规则表';
 
 -- 规则标签关联表
@@ -45,9 +60,73 @@ CREATE TABLE `mock_rule_histories` (
     `version` INT NOT NULL COMMENT '版本号',
     `change_type` VARCHAR(20) NOT NULL COMMENT '变更类型：create/update/delete',
     `content` JSON NOT NULL COMMENT '规则完整内容',
+    `change_set_id` VARCHAR(36) NULL COMMENT '发布该变更的变更集ID',
     `created_by` INT NOT NULL COMMENT '操作人ID',
     `created_at` INT NOT NULL COMMENT '创建时间',
     PRIMARY KEY (`id`),
     INDEX `idx_rule_id` (`rule_id`),
-    INDEX `idx_created_at` (`created_at`)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='规则变更历史表';
\ No newline at end of file
+    INDEX `idx_history_created_at` (`created_at`),
+    INDEX `idx_history_change_set` (`change_set_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='规则变更历史表';
+
+-- 已执行的迁移版本
+CREATE TABLE `schema_migrations` (
+    `version` BIGINT NOT NULL,
+    `name` VARCHAR(100) NOT NULL,
+    `applied_at` BIGINT NOT NULL,
+    PRIMARY KEY (`version`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='结构迁移记录表';
+
+-- 规则变更 outbox：与规则在同一事务中写入，由分发器应用到 Redis
+CREATE TABLE `mock_rule_outbox` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `rule_id` VARCHAR(36) NOT NULL COMMENT '规则ID',
+    `op` VARCHAR(20) NOT NULL COMMENT '事件类型：upsert/delete',
+    `old_index_key` VARCHAR(255) NULL COMMENT '变更前的 L1 索引',
+    `attempts` INT NOT NULL DEFAULT 0 COMMENT '失败次数',
+    `last_error` VARCHAR(512) NULL COMMENT '最近一次失败原因',
+    `next_attempt_at` BIGINT NOT NULL DEFAULT 0 COMMENT '下次重试时间',
+    `done_at` BIGINT NOT NULL DEFAULT 0 COMMENT '完成时间，0 表示待分发',
+    `created_at` BIGINT NOT NULL COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    INDEX `idx_outbox_pending` (`done_at`, `next_attempt_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='规则变更 outbox 表';
+
+-- 变更集：暂存多条规则变更，发布时原子写入
+CREATE TABLE `mock_change_sets` (
+    `id` VARCHAR(36) NOT NULL,
+    `name` VARCHAR(100) NOT NULL COMMENT '变更集名称',
+    `description` VARCHAR(512) NULL COMMENT '描述',
+    `status` VARCHAR(20) NOT NULL COMMENT '状态：open/published/discarded',
+    `created_at` BIGINT NOT NULL COMMENT '创建时间',
+    `updated_at` BIGINT NOT NULL COMMENT '更新时间',
+    `published_at` BIGINT NOT NULL DEFAULT 0 COMMENT '发布时间',
+    PRIMARY KEY (`id`),
+    INDEX `idx_change_set_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='规则变更集表';
+
+CREATE TABLE `mock_change_set_items` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `change_set_id` VARCHAR(36) NOT NULL COMMENT '变更集ID',
+    `rule_id` VARCHAR(36) NOT NULL COMMENT '规则ID',
+    `op` VARCHAR(20) NOT NULL COMMENT '变更类型：upsert/delete',
+    `rule` JSON NULL COMMENT '暂存的规则内容（draft）',
+    `target_status` VARCHAR(20) NULL COMMENT '发布后的规则状态',
+    `created_at` BIGINT NOT NULL COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_change_set_rule` (`change_set_id`, `rule_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='规则变更集条目表';
+
+-- gRPC mock 使用的 protobuf 描述符，同名上传时版本号递增，最新版本生效
+CREATE TABLE `mock_proto_descriptors` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `name` VARCHAR(100) NOT NULL COMMENT '描述符名称',
+    `version` INT NOT NULL COMMENT '版本号',
+    `source` VARCHAR(20) NOT NULL COMMENT '上传形式：descriptor_set/proto',
+    `content` LONGBLOB NOT NULL COMMENT '序列化的 FileDescriptorSet',
+    `size` INT NOT NULL DEFAULT 0 COMMENT '内容字节数',
+    `services` JSON NULL COMMENT '定义的服务全名',
+    `created_at` BIGINT NOT NULL COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_descriptor_version` (`name`, `version`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='protobuf 描述符表';